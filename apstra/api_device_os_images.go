@@ -0,0 +1,245 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+const (
+	apiUrlDeviceOsImages       = "/api/device-os/images"
+	apiUrlDeviceOsImagesPrefix = apiUrlDeviceOsImages + apiUrlPathDelim
+	apiUrlDeviceOsImageById    = apiUrlDeviceOsImagesPrefix + "%s"
+)
+
+// DeviceOsImageData describes a device OS image known to Apstra. The image
+// itself is hosted at ImageUrl; Apstra stores only its metadata.
+type DeviceOsImageData struct {
+	Description  string                         `json:"description"`
+	ImageName    string                         `json:"image_name"`
+	ImageUrl     string                         `json:"image_url"`
+	ImageSize    int64                          `json:"image_size,omitempty"`
+	Platform     enum.DeviceOsPlatform          `json:"platform"`
+	Checksum     string                         `json:"checksum"`
+	ChecksumType enum.DeviceOsImageChecksumType `json:"type"`
+}
+
+func (o DeviceOsImageData) validate() error {
+	if o.ImageUrl == "" {
+		return errors.New("device OS image URL is required")
+	}
+
+	var digits int
+	switch o.ChecksumType {
+	case enum.DeviceOsImageChecksumTypeMd5:
+		digits = md5.Size * 2
+	case enum.DeviceOsImageChecksumTypeSha512:
+		digits = sha512.Size * 2
+	default:
+		return fmt.Errorf("unsupported device OS image checksum type %q", o.ChecksumType.Value)
+	}
+
+	if _, err := hex.DecodeString(o.Checksum); err != nil || len(o.Checksum) != digits {
+		return fmt.Errorf("%s checksum must be %d hex digits, got %q", o.ChecksumType.Value, digits, o.Checksum)
+	}
+
+	return nil
+}
+
+type DeviceOsImage struct {
+	Id         ObjectId
+	UploadDate time.Time
+	Data       *DeviceOsImageData
+}
+
+type rawDeviceOsImage struct {
+	Id         ObjectId  `json:"id"`
+	UploadDate time.Time `json:"upload_date"`
+	DeviceOsImageData
+}
+
+func (o *rawDeviceOsImage) polish() *DeviceOsImage {
+	data := o.DeviceOsImageData
+	return &DeviceOsImage{
+		Id:         o.Id,
+		UploadDate: o.UploadDate,
+		Data:       &data,
+	}
+}
+
+// ComputeDeviceOsImageChecksum reads an image file from r and returns its
+// checksum in the hex form expected by DeviceOsImageData.Checksum.
+func ComputeDeviceOsImageChecksum(r io.Reader, checksumType enum.DeviceOsImageChecksumType) (string, error) {
+	var h hash.Hash
+	switch checksumType {
+	case enum.DeviceOsImageChecksumTypeMd5:
+		h = md5.New()
+	case enum.DeviceOsImageChecksumTypeSha512:
+		h = sha512.New()
+	default:
+		return "", fmt.Errorf("unsupported device OS image checksum type %q", checksumType.Value)
+	}
+
+	_, err := io.Copy(h, r)
+	if err != nil {
+		return "", fmt.Errorf("failed reading device OS image - %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (o *Client) getDeviceOsImage(ctx context.Context, id ObjectId) (*rawDeviceOsImage, error) {
+	response := &rawDeviceOsImage{}
+	err := o.talkToApstra(ctx, &talkToApstraIn{
+		method:      http.MethodGet,
+		urlStr:      fmt.Sprintf(apiUrlDeviceOsImageById, id),
+		apiResponse: response,
+	})
+	if err != nil {
+		return nil, convertTtaeToAceWherePossible(err)
+	}
+	return response, nil
+}
+
+func (o *Client) getAllDeviceOsImages(ctx context.Context) ([]rawDeviceOsImage, error) {
+	response := &struct {
+		Items []rawDeviceOsImage `json:"items"`
+	}{}
+	err := o.talkToApstra(ctx, &talkToApstraIn{
+		method:      http.MethodGet,
+		urlStr:      apiUrlDeviceOsImages,
+		apiResponse: response,
+	})
+	if err != nil {
+		return nil, convertTtaeToAceWherePossible(err)
+	}
+	return response.Items, nil
+}
+
+func (o *Client) createDeviceOsImage(ctx context.Context, in *DeviceOsImageData) (ObjectId, error) {
+	response := &objectIdResponse{}
+	err := o.talkToApstra(ctx, &talkToApstraIn{
+		method:      http.MethodPost,
+		urlStr:      apiUrlDeviceOsImages,
+		apiInput:    in,
+		apiResponse: response,
+	})
+	if err != nil {
+		return "", convertTtaeToAceWherePossible(err)
+	}
+	return response.Id, nil
+}
+
+func (o *Client) updateDeviceOsImage(ctx context.Context, id ObjectId, in *DeviceOsImageData) error {
+	err := o.talkToApstra(ctx, &talkToApstraIn{
+		method:   http.MethodPut,
+		urlStr:   fmt.Sprintf(apiUrlDeviceOsImageById, id),
+		apiInput: in,
+	})
+	if err != nil {
+		return convertTtaeToAceWherePossible(err)
+	}
+	return nil
+}
+
+func (o *Client) deleteDeviceOsImage(ctx context.Context, id ObjectId) error {
+	err := o.talkToApstra(ctx, &talkToApstraIn{
+		method: http.MethodDelete,
+		urlStr: fmt.Sprintf(apiUrlDeviceOsImageById, id),
+	})
+	if err != nil {
+		return convertTtaeToAceWherePossible(err)
+	}
+	return nil
+}
+
+// GetDeviceOsImage returns the specified device OS image.
+func (o *Client) GetDeviceOsImage(ctx context.Context, id ObjectId) (*DeviceOsImage, error) {
+	raw, err := o.getDeviceOsImage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return raw.polish(), nil
+}
+
+// GetAllDeviceOsImages returns every device OS image in the catalog.
+func (o *Client) GetAllDeviceOsImages(ctx context.Context) ([]DeviceOsImage, error) {
+	raw, err := o.getAllDeviceOsImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]DeviceOsImage, len(raw))
+	for i, r := range raw {
+		result[i] = *r.polish()
+	}
+	return result, nil
+}
+
+// GetDeviceOsImageByName returns the device OS image with the specified image
+// name. A ClientErr with type ErrNotfound is returned if no image matches, and
+// ErrMultipleMatch if more than one does.
+func (o *Client) GetDeviceOsImageByName(ctx context.Context, name string) (*DeviceOsImage, error) {
+	raw, err := o.getAllDeviceOsImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *DeviceOsImage
+	for _, r := range raw {
+		if !strings.EqualFold(r.ImageName, name) {
+			continue
+		}
+		if result != nil {
+			return nil, ClientErr{
+				errType: ErrMultipleMatch,
+				err:     fmt.Errorf("found multiple device OS images with name %q", name),
+			}
+		}
+		result = r.polish()
+	}
+
+	if result == nil {
+		return nil, ClientErr{
+			errType: ErrNotfound,
+			err:     fmt.Errorf("device OS image with name %q not found", name),
+		}
+	}
+	return result, nil
+}
+
+// CreateDeviceOsImage adds image metadata to the catalog and returns the new
+// image ID. The checksum is validated against the checksum type before the
+// request is sent.
+func (o *Client) CreateDeviceOsImage(ctx context.Context, in *DeviceOsImageData) (ObjectId, error) {
+	if err := in.validate(); err != nil {
+		return "", err
+	}
+	return o.createDeviceOsImage(ctx, in)
+}
+
+// UpdateDeviceOsImage replaces the metadata of the specified image.
+func (o *Client) UpdateDeviceOsImage(ctx context.Context, id ObjectId, in *DeviceOsImageData) error {
+	if err := in.validate(); err != nil {
+		return err
+	}
+	return o.updateDeviceOsImage(ctx, id, in)
+}
+
+// DeleteDeviceOsImage removes the specified image from the catalog.
+func (o *Client) DeleteDeviceOsImage(ctx context.Context, id ObjectId) error {
+	return o.deleteDeviceOsImage(ctx, id)
+}
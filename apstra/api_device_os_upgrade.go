@@ -0,0 +1,388 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+const osUpgradeReconnectTimeoutDefault = 30 * time.Minute
+
+// OsUpgradeRequest describes an OS upgrade of one or more systems. Systems are
+// upgraded in batches of BatchSize, pausing BatchInterval between batches.
+// When more than FailureThreshold systems have failed, no further batches are
+// started.
+type OsUpgradeRequest struct {
+	ImageId   ObjectId
+	SystemIds []SystemId
+
+	// TargetVersion is the OS version installed by the image. When set, systems
+	// already running it are skipped, downgrades are refused unless
+	// AllowDowngrade is set, and each upgrade is complete only once the system
+	// reports the new version.
+	TargetVersion  string
+	AllowDowngrade bool
+
+	BatchSize        int           // default 1
+	BatchInterval    time.Duration // pause between batches
+	FailureThreshold int           // failures tolerated before the rollout stops
+	ReconnectTimeout time.Duration // per-system wait following reboot; default 30 minutes
+
+	// Progress, when set, is invoked whenever a system's result changes. Calls
+	// are serialized, but may come from several goroutines.
+	Progress func(OsUpgradeResult)
+}
+
+// OsUpgradeResult describes the outcome of the upgrade of a single system.
+type OsUpgradeResult struct {
+	SystemId    SystemId
+	AgentId     ObjectId
+	JobId       JobId
+	Status      enum.OsUpgradeStatus
+	FromVersion string
+	ToVersion   string
+	Err         error
+}
+
+// compareOsVersions compares device OS version strings such as "22.4R3-S2.11"
+// or "4.30.1F". Runs of digits are compared numerically, other runs as
+// strings, and punctuation only separates runs.
+func compareOsVersions(a, b string) int {
+	split := func(s string) []string {
+		return strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	}
+	tokenize := func(s string) []string {
+		var result []string
+		for _, field := range split(s) {
+			start := 0
+			for i := 1; i <= len(field); i++ {
+				if i == len(field) || unicode.IsDigit(rune(field[i])) != unicode.IsDigit(rune(field[start])) {
+					result = append(result, field[start:i])
+					start = i
+				}
+			}
+		}
+		return result
+	}
+
+	at, bt := tokenize(a), tokenize(b)
+	for i := range min(len(at), len(bt)) {
+		an, aErr := strconv.ParseUint(at[i], 10, 64)
+		bn, bErr := strconv.ParseUint(bt[i], 10, 64)
+		var c int
+		if aErr == nil && bErr == nil {
+			c = cmp.Compare(an, bn)
+		} else {
+			c = strings.Compare(strings.ToLower(at[i]), strings.ToLower(bt[i]))
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(at), len(bt))
+}
+
+// osUpgradePrecheck determines whether system can be upgraded with image. The
+// returned result has status NotAttempted when the system is ready, Skipped
+// when it already runs the target version, or Failed (with Err set) when the
+// upgrade cannot proceed.
+func osUpgradePrecheck(image DeviceOsImage, system ManagedSystemInfo, agent *SystemAgent, req OsUpgradeRequest) OsUpgradeResult {
+	result := OsUpgradeResult{
+		SystemId:    system.Id,
+		Status:      enum.OsUpgradeStatusFailed,
+		FromVersion: system.Facts.OsVersion,
+		ToVersion:   req.TargetVersion,
+	}
+
+	if agent == nil {
+		result.Err = fmt.Errorf("system %q has no system agent", system.Id)
+		return result
+	}
+	result.AgentId = agent.Id
+
+	switch agent.Status.ConnectionState {
+	case AgentCxnStateConnected, AgentCxnStateNone:
+	default:
+		result.Err = fmt.Errorf("agent %q of system %q is %s", agent.Id, system.Id, agent.Status.ConnectionState)
+		return result
+	}
+
+	if !strings.EqualFold(system.Facts.OsFamily, image.Data.Platform.Value) {
+		result.Err = fmt.Errorf("system %q runs %q, image %q is for %q",
+			system.Id, system.Facts.OsFamily, image.Data.ImageName, image.Data.Platform.Value)
+		return result
+	}
+
+	if req.TargetVersion != "" {
+		c := compareOsVersions(system.Facts.OsVersion, req.TargetVersion)
+		switch {
+		case c == 0:
+			result.Status = enum.OsUpgradeStatusSkipped
+			return result
+		case c > 0 && !req.AllowDowngrade:
+			result.Err = fmt.Errorf("system %q runs %s, upgrading to %s would be a downgrade",
+				system.Id, system.Facts.OsVersion, req.TargetVersion)
+			return result
+		}
+	}
+
+	result.Status = enum.OsUpgradeStatusNotAttempted
+	return result
+}
+
+// runOsUpgradeRollout upgrades the systems in results whose status is
+// NotAttempted, in batches, using upgrade. Batch members are upgraded
+// concurrently. Results are updated in place.
+func runOsUpgradeRollout(ctx context.Context, results []OsUpgradeResult, req OsUpgradeRequest, upgrade func(context.Context, *OsUpgradeResult) error) error {
+	var pending []int
+	for i, r := range results {
+		if r.Status == enum.OsUpgradeStatusNotAttempted {
+			pending = append(pending, i)
+		}
+	}
+
+	var mutex sync.Mutex
+	report := func(r *OsUpgradeResult) {
+		if req.Progress != nil {
+			mutex.Lock()
+			req.Progress(*r)
+			mutex.Unlock()
+		}
+	}
+
+	var failures int
+	for batchNum, batch := range slices.Collect(slices.Chunk(pending, max(req.BatchSize, 1))) {
+		if batchNum > 0 && req.BatchInterval > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(req.BatchInterval):
+			}
+		}
+
+		var wg sync.WaitGroup
+		for _, i := range batch {
+			wg.Add(1)
+			go func(r *OsUpgradeResult) {
+				defer wg.Done()
+				err := upgrade(ctx, r)
+				if err != nil {
+					r.Status, r.Err = enum.OsUpgradeStatusFailed, err
+				} else {
+					r.Status = enum.OsUpgradeStatusSuccess
+				}
+				report(r)
+			}(&results[i])
+		}
+		wg.Wait()
+
+		for _, i := range batch {
+			if results[i].Status == enum.OsUpgradeStatusFailed {
+				failures++
+			}
+		}
+		if failures > req.FailureThreshold {
+			return fmt.Errorf("OS upgrade stopped after %d failures (threshold %d)", failures, req.FailureThreshold)
+		}
+	}
+
+	return nil
+}
+
+func (o *Client) systemAgentStartUpgradeJob(ctx context.Context, agentId ObjectId, imageId ObjectId) (JobId, error) {
+	response := &jobIdResponse{}
+	err := o.talkToApstra(ctx, &talkToApstraIn{
+		method: http.MethodPost,
+		urlStr: fmt.Sprintf(apiUrlSystemAgentUpgrade, agentId),
+		apiInput: &struct {
+			ImageId ObjectId `json:"image_id"`
+		}{ImageId: imageId},
+		apiResponse: response,
+	})
+	if err != nil {
+		return 0, convertTtaeToAceWherePossible(err)
+	}
+	return response.Id, nil
+}
+
+// osUpgradeRebootObserved reports whether a system has begun rebooting
+// following an upgrade: either its agent has lost the connection, or the
+// device start time differs from the one recorded before the upgrade.
+func osUpgradeRebootObserved(before time.Time, cxnState AgentCxnState, after time.Time) bool {
+	return cxnState == AgentCxnStateDisconnected || !after.Equal(before)
+}
+
+// systemWaitForOsUpgradeReboot polls the system and its agent until
+// osUpgradeRebootObserved is satisfied or ctx is done.
+func (o *Client) systemWaitForOsUpgradeReboot(ctx context.Context, r *OsUpgradeResult, startTime time.Time) error {
+	for {
+		agent, err := o.getSystemAgent(ctx, r.AgentId)
+		if err != nil {
+			return fmt.Errorf("error getting agent info - %w", err)
+		}
+
+		system, err := o.getSystemInfo(ctx, r.SystemId)
+		if err != nil {
+			return fmt.Errorf("error getting system info - %w", err)
+		}
+
+		if osUpgradeRebootObserved(startTime, agent.Status.ConnectionState, system.Status.DeviceStartTime) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(clientPollingIntervalMs * time.Millisecond):
+		}
+	}
+}
+
+// upgradeSystemOs runs an upgrade job on the system's agent, waits for the
+// job to finish, then waits for the system to reboot, for the agent to
+// reconnect and (when the target version is known) for the system to report
+// the new version. The reboot is detected by the agent disconnecting or by a
+// change of the device start time, so that a connection which predates the
+// reboot is not mistaken for a reconnection.
+func (o *Client) upgradeSystemOs(ctx context.Context, r *OsUpgradeResult, req OsUpgradeRequest) error {
+	before, err := o.getSystemInfo(ctx, r.SystemId)
+	if err != nil {
+		return fmt.Errorf("failed fetching system %q prior to upgrade - %w", r.SystemId, err)
+	}
+
+	r.JobId, err = o.systemAgentStartUpgradeJob(ctx, r.AgentId, req.ImageId)
+	if err != nil {
+		return fmt.Errorf("failed starting upgrade job on agent %q - %w", r.AgentId, err)
+	}
+
+	err = o.systemAgentWaitForJobToExist(ctx, r.AgentId, r.JobId)
+	if err != nil {
+		return err
+	}
+
+	err = o.systemAgentWaitForJobTermination(ctx, r.AgentId, r.JobId)
+	if err != nil {
+		return err
+	}
+
+	status, err := o.getSystemAgentJobStatus(ctx, r.AgentId, r.JobId)
+	if err != nil {
+		return err
+	}
+	if status.State != AgentJobStateSuccess {
+		return fmt.Errorf("upgrade job %d on agent %q finished in state %s: %s", r.JobId, r.AgentId, status.State, status.Error)
+	}
+
+	reconnectCtx, cancel := context.WithTimeout(ctx, cmp.Or(req.ReconnectTimeout, osUpgradeReconnectTimeoutDefault))
+	defer cancel()
+
+	err = o.systemWaitForOsUpgradeReboot(reconnectCtx, r, before.Status.DeviceStartTime)
+	if err != nil {
+		return fmt.Errorf("system %q did not reboot following upgrade - %w", r.SystemId, err)
+	}
+
+	err = o.systemAgentWaitForConnection(reconnectCtx, r.AgentId)
+	if err != nil {
+		return fmt.Errorf("agent %q did not reconnect following upgrade - %w", r.AgentId, err)
+	}
+
+	if req.TargetVersion == "" {
+		return nil
+	}
+
+	for {
+		system, err := o.getSystemInfo(reconnectCtx, r.SystemId)
+		if err != nil {
+			return fmt.Errorf("failed fetching system %q following upgrade - %w", r.SystemId, err)
+		}
+		if compareOsVersions(system.Facts.OsVersion, req.TargetVersion) == 0 {
+			return nil
+		}
+
+		select {
+		case <-reconnectCtx.Done():
+			return fmt.Errorf("system %q reports OS version %q following upgrade, expected %q - %w",
+				r.SystemId, system.Facts.OsVersion, req.TargetVersion, reconnectCtx.Err())
+		case <-time.After(clientPollingIntervalMs * time.Millisecond):
+		}
+	}
+}
+
+// PrecheckOsUpgrade checks each system in req against the image: the system
+// must have a connected agent, its OS family must match the image platform
+// and, when req.TargetVersion is set, the upgrade must not be a downgrade.
+// No changes are made.
+func (o *Client) PrecheckOsUpgrade(ctx context.Context, req OsUpgradeRequest) ([]OsUpgradeResult, error) {
+	if len(req.SystemIds) == 0 {
+		return nil, errors.New("no systems to upgrade")
+	}
+
+	image, err := o.GetDeviceOsImage(ctx, req.ImageId)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching device OS image %q - %w", req.ImageId, err)
+	}
+
+	agents, err := o.getAllSystemAgents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching system agents - %w", err)
+	}
+
+	result := make([]OsUpgradeResult, len(req.SystemIds))
+	for i, id := range req.SystemIds {
+		system, err := o.GetSystemInfo(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching system %q - %w", id, err)
+		}
+
+		var agent *SystemAgent
+		if j := slices.IndexFunc(agents, func(a SystemAgent) bool { return a.Status.SystemId == id }); j >= 0 {
+			agent = &agents[j]
+		}
+
+		result[i] = osUpgradePrecheck(*image, *system, agent, req)
+	}
+
+	return result, nil
+}
+
+// UpgradeSystemsOs upgrades the OS of each system in req. Every system is
+// prechecked (see PrecheckOsUpgrade) before any upgrade begins; if any
+// precheck fails nothing is upgraded and the precheck results are returned
+// with an error. Results are returned for every system, including those not
+// attempted because the rollout was stopped.
+func (o *Client) UpgradeSystemsOs(ctx context.Context, req OsUpgradeRequest) ([]OsUpgradeResult, error) {
+	results, err := o.PrecheckOsUpgrade(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, r := range results {
+		if r.Status == enum.OsUpgradeStatusFailed {
+			errs = append(errs, r.Err)
+		}
+	}
+	if len(errs) > 0 {
+		return results, fmt.Errorf("OS upgrade precheck failed - %w", errors.Join(errs...))
+	}
+
+	err = runOsUpgradeRollout(ctx, results, req, func(ctx context.Context, r *OsUpgradeResult) error {
+		return o.upgradeSystemOs(ctx, r, req)
+	})
+
+	return results, err
+}
@@ -0,0 +1,251 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareOsVersions(t *testing.T) {
+	type testCase struct {
+		a, b     string
+		expected int
+	}
+
+	testCases := []testCase{
+		{a: "22.4R1", b: "22.4R1", expected: 0},
+		{a: "22.4R1", b: "22.4r1", expected: 0},
+		{a: "22.4R1", b: "22.4R2", expected: -1},
+		{a: "22.4R2", b: "22.4R1-S2", expected: 1},
+		{a: "22.4R1", b: "22.4R1-S2", expected: -1},
+		{a: "22.4R3.25", b: "22.4R3.9", expected: 1},
+		{a: "21.4R3", b: "22.2R1", expected: -1},
+		{a: "4.30.1F", b: "4.30.10F", expected: -1},
+		{a: "4.30.2F", b: "4.29.9F", expected: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s_vs_%s", tc.a, tc.b), func(t *testing.T) {
+			require.Equal(t, tc.expected, compareOsVersions(tc.a, tc.b))
+			require.Equal(t, -tc.expected, compareOsVersions(tc.b, tc.a))
+		})
+	}
+}
+
+func TestDeviceOsImageDataValidate(t *testing.T) {
+	checksum, err := ComputeDeviceOsImageChecksum(strings.NewReader("image"), enum.DeviceOsImageChecksumTypeMd5)
+	require.NoError(t, err)
+	require.Equal(t, "78805a221a988e79ef3f42d7c5bfd418", checksum)
+
+	data := DeviceOsImageData{
+		ImageName:    "junos-22.4R3.tgz",
+		ImageUrl:     "http://images.example.com/junos-22.4R3.tgz",
+		Platform:     enum.DeviceOsPlatformJunos,
+		Checksum:     checksum,
+		ChecksumType: enum.DeviceOsImageChecksumTypeMd5,
+	}
+	require.NoError(t, data.validate())
+
+	data.ChecksumType = enum.DeviceOsImageChecksumTypeSha512
+	require.Error(t, data.validate())
+
+	data.Checksum, err = ComputeDeviceOsImageChecksum(strings.NewReader("image"), enum.DeviceOsImageChecksumTypeSha512)
+	require.NoError(t, err)
+	require.NoError(t, data.validate())
+
+	data.Checksum = strings.Repeat("g", 128)
+	require.Error(t, data.validate())
+}
+
+func TestOsUpgradePrecheck(t *testing.T) {
+	image := DeviceOsImage{Id: "image", Data: &DeviceOsImageData{ImageName: "junos", Platform: enum.DeviceOsPlatformJunos}}
+	system := func(family, version string) ManagedSystemInfo {
+		return ManagedSystemInfo{Id: "sys", Facts: SystemFacts{OsFamily: family, OsVersion: version}}
+	}
+	agent := func(state AgentCxnState) *SystemAgent {
+		return &SystemAgent{Id: "agent", Status: AgentStatus{SystemId: "sys", ConnectionState: state}}
+	}
+	req := OsUpgradeRequest{TargetVersion: "22.4R3"}
+
+	type testCase struct {
+		system   ManagedSystemInfo
+		agent    *SystemAgent
+		req      OsUpgradeRequest
+		expected enum.OsUpgradeStatus
+	}
+
+	testCases := map[string]testCase{
+		"ready": {
+			system:   system("junos", "21.4R3"),
+			agent:    agent(AgentCxnStateConnected),
+			req:      req,
+			expected: enum.OsUpgradeStatusNotAttempted,
+		},
+		"ready_without_target_version": {
+			system:   system("Junos", "22.4R3"),
+			agent:    agent(AgentCxnStateConnected),
+			expected: enum.OsUpgradeStatusNotAttempted,
+		},
+		"already_upgraded": {
+			system:   system("junos", "22.4R3"),
+			agent:    agent(AgentCxnStateConnected),
+			req:      req,
+			expected: enum.OsUpgradeStatusSkipped,
+		},
+		"downgrade": {
+			system:   system("junos", "23.2R1"),
+			agent:    agent(AgentCxnStateConnected),
+			req:      req,
+			expected: enum.OsUpgradeStatusFailed,
+		},
+		"downgrade_allowed": {
+			system:   system("junos", "23.2R1"),
+			agent:    agent(AgentCxnStateConnected),
+			req:      OsUpgradeRequest{TargetVersion: "22.4R3", AllowDowngrade: true},
+			expected: enum.OsUpgradeStatusNotAttempted,
+		},
+		"wrong_platform": {
+			system:   system("eos", "4.30.1F"),
+			agent:    agent(AgentCxnStateConnected),
+			req:      req,
+			expected: enum.OsUpgradeStatusFailed,
+		},
+		"no_agent": {
+			system:   system("junos", "21.4R3"),
+			req:      req,
+			expected: enum.OsUpgradeStatusFailed,
+		},
+		"agent_disconnected": {
+			system:   system("junos", "21.4R3"),
+			agent:    agent(AgentCxnStateDisconnected),
+			req:      req,
+			expected: enum.OsUpgradeStatusFailed,
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			result := osUpgradePrecheck(image, tCase.system, tCase.agent, tCase.req)
+			require.Equal(t, tCase.expected, result.Status)
+			require.Equal(t, tCase.expected == enum.OsUpgradeStatusFailed, result.Err != nil)
+		})
+	}
+}
+
+func TestOsUpgradeRebootObserved(t *testing.T) {
+	before := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type testCase struct {
+		cxnState AgentCxnState
+		after    time.Time
+		expected bool
+	}
+
+	testCases := map[string]testCase{
+		"still_connected": {
+			cxnState: AgentCxnStateConnected,
+			after:    before,
+			expected: false,
+		},
+		"disconnected": {
+			cxnState: AgentCxnStateDisconnected,
+			after:    before,
+			expected: true,
+		},
+		"reconnected_after_reboot": {
+			cxnState: AgentCxnStateConnected,
+			after:    before.Add(10 * time.Minute),
+			expected: true,
+		},
+		"onbox_agent_not_rebooted": {
+			cxnState: AgentCxnStateNone,
+			after:    before,
+			expected: false,
+		},
+		"onbox_agent_rebooted": {
+			cxnState: AgentCxnStateNone,
+			after:    before.Add(10 * time.Minute),
+			expected: true,
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			require.Equal(t, tCase.expected, osUpgradeRebootObserved(before, tCase.cxnState, tCase.after))
+		})
+	}
+}
+
+func TestRunOsUpgradeRollout(t *testing.T) {
+	newResults := func() []OsUpgradeResult {
+		result := make([]OsUpgradeResult, 7)
+		for i := range result {
+			result[i] = OsUpgradeResult{SystemId: SystemId(fmt.Sprintf("sys%d", i)), Status: enum.OsUpgradeStatusNotAttempted}
+		}
+		result[1].Status = enum.OsUpgradeStatusSkipped
+		return result
+	}
+
+	var mutex sync.Mutex
+	var attempted []SystemId
+	upgrade := func(_ context.Context, r *OsUpgradeResult) error {
+		mutex.Lock()
+		attempted = append(attempted, r.SystemId)
+		mutex.Unlock()
+		if r.SystemId == "sys2" || r.SystemId == "sys4" {
+			return errors.New("boom")
+		}
+		return nil
+	}
+
+	t.Run("threshold_exceeded", func(t *testing.T) {
+		attempted = nil
+		results := newResults()
+		err := runOsUpgradeRollout(t.Context(), results, OsUpgradeRequest{BatchSize: 2, FailureThreshold: 1}, upgrade)
+		require.Error(t, err)
+
+		// batches: [sys0 sys2] [sys3 sys4] [sys5 sys6] - the second failure stops the rollout
+		require.ElementsMatch(t, []SystemId{"sys0", "sys2", "sys3", "sys4"}, attempted)
+		statuses := make([]enum.OsUpgradeStatus, len(results))
+		for i, r := range results {
+			statuses[i] = r.Status
+		}
+		require.Equal(t, []enum.OsUpgradeStatus{
+			enum.OsUpgradeStatusSuccess,
+			enum.OsUpgradeStatusSkipped,
+			enum.OsUpgradeStatusFailed,
+			enum.OsUpgradeStatusSuccess,
+			enum.OsUpgradeStatusFailed,
+			enum.OsUpgradeStatusNotAttempted,
+			enum.OsUpgradeStatusNotAttempted,
+		}, statuses)
+	})
+
+	t.Run("threshold_not_exceeded", func(t *testing.T) {
+		attempted = nil
+		var progress []SystemId
+		results := newResults()
+		err := runOsUpgradeRollout(t.Context(), results, OsUpgradeRequest{
+			FailureThreshold: 2,
+			Progress:         func(r OsUpgradeResult) { progress = append(progress, r.SystemId) },
+		}, upgrade)
+		require.NoError(t, err)
+
+		// default batch size of 1 upgrades systems one at a time, in order
+		require.Equal(t, []SystemId{"sys0", "sys2", "sys3", "sys4", "sys5", "sys6"}, attempted)
+		require.Equal(t, attempted, progress)
+		require.Equal(t, enum.OsUpgradeStatusSkipped, results[1].Status)
+		require.Equal(t, enum.OsUpgradeStatusSuccess, results[6].Status)
+	})
+}
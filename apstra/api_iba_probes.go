@@ -15,9 +15,11 @@ import (
 )
 
 const (
-	apiUrlIbaProbes       = apiUrlBlueprintById + apiUrlPathDelim + "probes"
-	apiUrlIbaProbesPrefix = apiUrlIbaProbes + apiUrlPathDelim
-	apiUrlIbaProbesById   = apiUrlIbaProbesPrefix + "%s"
+	apiUrlIbaProbes         = apiUrlBlueprintById + apiUrlPathDelim + "probes"
+	apiUrlIbaProbesPrefix   = apiUrlIbaProbes + apiUrlPathDelim
+	apiUrlIbaProbesById     = apiUrlIbaProbesPrefix + "%s"
+	apiUrlIbaProbeStage     = apiUrlIbaProbesById + apiUrlPathDelim + "stages" + apiUrlPathDelim + "%s"
+	apiUrlIbaProbeStageData = apiUrlIbaProbeStage + apiUrlPathDelim + "data"
 )
 
 type IbaProbe struct {
@@ -47,6 +49,15 @@ type IbaProbeState struct {
 	Description     string                   `json:"description"`
 }
 
+// IbaStageItem is one row of IBA probe stage output. Properties identify the
+// item (e.g. "system_id", "interface"). Single-value stages populate Value,
+// multi-value stages populate Values.
+type IbaStageItem struct {
+	Properties map[string]string  `json:"properties"`
+	Value      *float64           `json:"value"`
+	Values     map[string]float64 `json:"values"`
+}
+
 func (o *IbaProbeState) IbaProbe() IbaProbe {
 	return IbaProbe{
 		Id:              o.Id,
@@ -131,6 +142,22 @@ func (o *Client) getIbaProbeState(ctx context.Context, bpId ObjectId, id ObjectI
 	return response, nil
 }
 
+func (o *Client) getIbaProbeStageData(ctx context.Context, bpId ObjectId, id ObjectId, stage string) ([]IbaStageItem, error) {
+	response := &struct {
+		Items []IbaStageItem `json:"items"`
+	}{}
+
+	err := o.talkToApstra(ctx, &talkToApstraIn{
+		method:      http.MethodGet,
+		urlStr:      fmt.Sprintf(apiUrlIbaProbeStageData, bpId, id, stage),
+		apiResponse: response,
+	})
+	if err != nil {
+		return nil, convertTtaeToAceWherePossible(err)
+	}
+	return response.Items, nil
+}
+
 func (o *Client) deleteIbaProbe(ctx context.Context, bpId ObjectId, id ObjectId) error {
 	return convertTtaeToAceWherePossible(o.talkToApstra(ctx, &talkToApstraIn{
 		method: http.MethodDelete,
@@ -0,0 +1,222 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/netip"
+	"slices"
+)
+
+// IntRangeMerge replaces adjacent ranges in an integer pool with one range.
+type IntRangeMerge struct {
+	From IntRanges
+	To   IntRangeRequest
+}
+
+// IntPoolDefragPlan lists the range merges required to defragment an ASN, VNI
+// or integer pool.
+type IntPoolDefragPlan struct {
+	PoolId ObjectId
+	Merges []IntRangeMerge
+}
+
+// Empty returns true when the pool has no adjacent ranges.
+func (o IntPoolDefragPlan) Empty() bool {
+	return len(o.Merges) == 0
+}
+
+// NewIntPoolDefragPlan identifies runs of adjacent (or overlapping) ranges in
+// pool which can be merged into a single range.
+func NewIntPoolDefragPlan(pool IntPool) IntPoolDefragPlan {
+	result := IntPoolDefragPlan{PoolId: pool.Id}
+
+	ranges := slices.Clone(pool.Ranges)
+	slices.SortFunc(ranges, func(a, b IntRange) int { return cmp.Compare(a.First, b.First) })
+
+	var run IntRanges
+	flush := func() {
+		if len(run) > 1 {
+			merged := run.Coalesce()[0]
+			result.Merges = append(result.Merges, IntRangeMerge{
+				From: run,
+				To:   IntRangeRequest{First: merged.First, Last: merged.Last},
+			})
+		}
+		run = nil
+	}
+
+	var last uint64
+	for _, r := range ranges {
+		if len(run) > 0 && uint64(r.First) > last+1 {
+			flush()
+		}
+		if len(run) == 0 || uint64(r.Last) > last {
+			last = uint64(r.Last)
+		}
+		run = append(run, r)
+	}
+	flush()
+
+	return result
+}
+
+// IpSubnetMerge replaces subnets in an IP pool with a single aggregate prefix.
+type IpSubnetMerge struct {
+	From []netip.Prefix
+	To   netip.Prefix
+}
+
+// IpPoolDefragPlan lists the subnet aggregations required to consolidate an IP
+// pool. Subnets holds the complete, consolidated subnet list.
+type IpPoolDefragPlan struct {
+	PoolId  ObjectId
+	Merges  []IpSubnetMerge
+	Subnets PrefixSet
+}
+
+// Empty returns true when none of the pool's subnets can be aggregated.
+func (o IpPoolDefragPlan) Empty() bool {
+	return len(o.Merges) == 0
+}
+
+// NewIpPoolDefragPlan identifies subnets in pool which can be aggregated into
+// larger prefixes. Adjacent subnets which are not suitably aligned remain
+// separate.
+func NewIpPoolDefragPlan(pool IpPool) (*IpPoolDefragPlan, error) {
+	subnets, err := NewPrefixSetFromIpSubnets(pool.Subnets)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing subnets of IP pool %q - %w", pool.Id, err)
+	}
+
+	result := IpPoolDefragPlan{PoolId: pool.Id, Subnets: subnets.Coalesce()}
+	for _, aggregate := range result.Subnets {
+		var from []netip.Prefix
+		for _, s := range subnets {
+			if s.Bits() >= aggregate.Bits() && aggregate.Contains(s.Addr()) {
+				from = append(from, s.Masked())
+			}
+		}
+		if len(from) > 1 {
+			slices.SortFunc(from, func(a, b netip.Prefix) int { return a.Addr().Compare(b.Addr()) })
+			result.Merges = append(result.Merges, IpSubnetMerge{From: from, To: aggregate})
+		}
+	}
+
+	return &result, nil
+}
+
+// defragIntPool merges adjacent ranges in the specified pool. The merged range
+// list covers every value of the original ranges, so allocations are
+// unaffected. All merges are applied in a single update, so a failure leaves
+// the pool unchanged.
+func (o *Client) defragIntPool(ctx context.Context, apiUrlResourcePoolById string, poolId ObjectId) (*IntPoolDefragPlan, error) {
+	raw, err := o.getIntPool(ctx, apiUrlResourcePoolById, poolId)
+	if err != nil {
+		return nil, fmt.Errorf("error getting Int pool %q - %w", poolId, err)
+	}
+	pool, err := raw.polish()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Int pool %q - %w", poolId, err)
+	}
+
+	plan := NewIntPoolDefragPlan(*pool)
+	if plan.Empty() {
+		return &plan, nil
+	}
+
+	req := &IntPoolRequest{
+		DisplayName: pool.DisplayName,
+		Tags:        pool.Tags,
+	}
+	for _, r := range pool.Ranges {
+		if slices.ContainsFunc(plan.Merges, func(m IntRangeMerge) bool { return m.From.IndexOf(r) >= 0 }) {
+			continue
+		}
+		req.Ranges = append(req.Ranges, r)
+	}
+	for _, merge := range plan.Merges {
+		req.Ranges = append(req.Ranges, merge.To)
+	}
+
+	err = o.updateIntPool(ctx, apiUrlResourcePoolById, poolId, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed merging ranges of Int pool %q - %w", poolId, err)
+	}
+
+	return &plan, nil
+}
+
+// defragIpPool aggregates the subnets of the specified pool. The consolidated
+// subnet list covers exactly the same addresses, so allocations are unaffected.
+func (o *Client) defragIpPool(ctx context.Context, apiUrlResourcePoolById string, poolId ObjectId) (*IpPoolDefragPlan, error) {
+	urlStr := fmt.Sprintf(apiUrlResourcePoolById, poolId)
+
+	raw, err := o.getIpPool(ctx, urlStr)
+	if err != nil {
+		return nil, fmt.Errorf("error getting IP pool %q - %w", poolId, err)
+	}
+	pool, err := raw.polish()
+	if err != nil {
+		return nil, fmt.Errorf("error getting IP pool %q - %w", poolId, err)
+	}
+
+	plan, err := NewIpPoolDefragPlan(*pool)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Empty() {
+		return plan, nil
+	}
+
+	req := &NewIpPoolRequest{
+		DisplayName: pool.DisplayName,
+		Tags:        pool.Tags,
+		Subnets:     make([]NewIpSubnet, len(plan.Subnets)),
+	}
+	for i, s := range plan.Subnets {
+		req.Subnets[i] = NewIpSubnet{Network: s.String()}
+	}
+
+	err = o.updateIpPool(ctx, urlStr, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed consolidating subnets of IP pool %q - %w", poolId, err)
+	}
+
+	return plan, nil
+}
+
+// DefragAsnPool merges adjacent ranges in the specified ASN pool. Allocated
+// ASNs remain within the pool. The returned plan describes the merges.
+func (o *Client) DefragAsnPool(ctx context.Context, poolId ObjectId) (*IntPoolDefragPlan, error) {
+	return o.defragIntPool(ctx, apiUrlResourcesAsnPoolById, poolId)
+}
+
+// DefragVniPool merges adjacent ranges in the specified VNI pool. Allocated
+// VNIs remain within the pool. The returned plan describes the merges.
+func (o *Client) DefragVniPool(ctx context.Context, poolId ObjectId) (*IntPoolDefragPlan, error) {
+	return o.defragIntPool(ctx, apiUrlResourcesVniPoolById, poolId)
+}
+
+// DefragIntegerPool merges adjacent ranges in the specified Integer Pool.
+// Allocated values remain within the pool. The returned plan describes the
+// merges.
+func (o *Client) DefragIntegerPool(ctx context.Context, poolId ObjectId) (*IntPoolDefragPlan, error) {
+	return o.defragIntPool(ctx, apiUrlResourcesIntegerPoolById, poolId)
+}
+
+// DefragIp4Pool aggregates the subnets of the specified IPv4 pool (via the
+// UpdateIp4Pool API) where alignment permits.
+func (o *Client) DefragIp4Pool(ctx context.Context, poolId ObjectId) (*IpPoolDefragPlan, error) {
+	return o.defragIpPool(ctx, apiUrlResourcesIp4PoolById, poolId)
+}
+
+// DefragIp6Pool aggregates the subnets of the specified IPv6 pool (via the
+// UpdateIp6Pool API) where alignment permits.
+func (o *Client) DefragIp6Pool(ctx context.Context, poolId ObjectId) (*IpPoolDefragPlan, error) {
+	return o.defragIpPool(ctx, apiUrlResourcesIp6PoolById, poolId)
+}
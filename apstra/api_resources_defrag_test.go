@@ -0,0 +1,53 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewIntPoolDefragPlan(t *testing.T) {
+	pool := IntPool{Id: "pool", Ranges: IntRanges{
+		{First: 300, Last: 399, Used: 5},
+		{First: 100, Last: 199},
+		{First: 200, Last: 249},
+		{First: 400, Last: 499},
+		{First: 600, Last: 699},
+		{First: 250, Last: 260},
+	}}
+
+	plan := NewIntPoolDefragPlan(pool)
+	require.False(t, plan.Empty())
+	require.Len(t, plan.Merges, 2)
+	require.Equal(t, IntRangeRequest{First: 100, Last: 260}, plan.Merges[0].To)
+	require.Equal(t, []string{"100-199", "200-249", "250-260"}, intRangeStrings(plan.Merges[0].From))
+	require.Equal(t, IntRangeRequest{First: 300, Last: 499}, plan.Merges[1].To)
+
+	require.True(t, NewIntPoolDefragPlan(IntPool{Ranges: testIntRanges(1, 10, 20, 30)}).Empty())
+}
+
+func TestNewIpPoolDefragPlan(t *testing.T) {
+	subnets := func(in ...string) []IpSubnet {
+		result := make([]IpSubnet, len(in))
+		for i, s := range in {
+			result[i] = IpSubnet{Network: ipNetFromPrefix(netip.MustParsePrefix(s))}
+		}
+		return result
+	}
+
+	plan, err := NewIpPoolDefragPlan(IpPool{Id: "pool", Subnets: subnets("10.0.0.128/25", "10.0.0.0/25", "10.0.1.0/24", "10.0.3.0/24", "10.0.4.0/24")})
+	require.NoError(t, err)
+	require.Len(t, plan.Merges, 1)
+	require.Equal(t, netip.MustParsePrefix("10.0.0.0/23"), plan.Merges[0].To)
+	require.Equal(t, []string{"10.0.0.0/25", "10.0.0.128/25", "10.0.1.0/24"}, prefixStrings(plan.Merges[0].From))
+	require.Equal(t, []string{"10.0.0.0/23", "10.0.3.0/24", "10.0.4.0/24"}, prefixStrings(plan.Subnets))
+
+	plan, err = NewIpPoolDefragPlan(IpPool{Subnets: subnets("10.0.1.0/24", "10.0.2.0/24")})
+	require.NoError(t, err)
+	require.True(t, plan.Empty())
+}
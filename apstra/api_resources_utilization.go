@@ -0,0 +1,452 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/Juniper/apstra-go-sdk/design"
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+// PoolUtilizationBlueprint holds the resource allocations of a blueprint.
+type PoolUtilizationBlueprint struct {
+	Id          ObjectId
+	Label       string
+	Allocations ResourceGroupAllocations
+}
+
+// PoolUtilizationState holds the resource pools and the blueprint resource
+// allocations which consume them.
+type PoolUtilizationState struct {
+	AsnPools   []AsnPool
+	VniPools   []VniPool
+	Ip4Pools   []IpPool
+	Ip6Pools   []IpPool
+	Blueprints []PoolUtilizationBlueprint
+}
+
+// PoolBlock is a range of integers (ASN and VNI pools) or a subnet (IP pools)
+// within a resource pool.
+type PoolBlock struct {
+	Range          string // "first-last" or CIDR notation
+	Status         string
+	Total          *big.Int
+	Used           *big.Int
+	Free           *big.Int
+	UsedPercentage float64
+}
+
+// PoolConsumer is a blueprint which allocates resources from a pool.
+type PoolConsumer struct {
+	BlueprintId    ObjectId
+	BlueprintLabel string
+	ResourceGroups []ResourceGroup
+}
+
+// PoolUtilization summarizes a single resource pool.
+type PoolUtilization struct {
+	Type           ResourceType
+	Id             ObjectId
+	Label          string
+	Tags           []string
+	Status         PoolStatus
+	Total          *big.Int
+	Used           *big.Int
+	Free           *big.Int
+	UsedPercentage float64
+	Blocks         []PoolBlock // per range or subnet, in pool order
+	FreeBlocks     []PoolBlock // ranges or subnets with free space, most free space first
+	Consumers      []PoolConsumer
+}
+
+// PoolUtilizationReport summarizes all ASN, VNI and IP pools. Pools are sorted
+// by type, then by label.
+type PoolUtilizationReport struct {
+	Pools      []PoolUtilization
+	Blueprints []PoolUtilizationBlueprint
+}
+
+// Pool returns the pool with the given type and ID, or nil.
+func (o *PoolUtilizationReport) Pool(t ResourceType, id ObjectId) *PoolUtilization {
+	i := slices.IndexFunc(o.Pools, func(p PoolUtilization) bool { return p.Type == t && p.Id == id })
+	if i < 0 {
+		return nil
+	}
+	return &o.Pools[i]
+}
+
+// NewPoolUtilizationReport aggregates the utilization of the pools in state.
+func NewPoolUtilizationReport(state PoolUtilizationState) *PoolUtilizationReport {
+	result := PoolUtilizationReport{Blueprints: state.Blueprints}
+
+	for _, pool := range state.AsnPools {
+		result.Pools = append(result.Pools, newIntPoolUtilization(ResourceTypeAsnPool, IntPool(pool)))
+	}
+	for _, pool := range state.VniPools {
+		result.Pools = append(result.Pools, newIntPoolUtilization(ResourceTypeVniPool, IntPool(pool)))
+	}
+	for _, pool := range state.Ip4Pools {
+		result.Pools = append(result.Pools, newIpPoolUtilization(ResourceTypeIp4Pool, pool))
+	}
+	for _, pool := range state.Ip6Pools {
+		result.Pools = append(result.Pools, newIpPoolUtilization(ResourceTypeIp6Pool, pool))
+	}
+
+	for _, bp := range state.Blueprints {
+		for _, alloc := range bp.Allocations {
+			for _, poolId := range alloc.PoolIds {
+				pool := result.Pool(alloc.ResourceGroup.Type, poolId)
+				if pool == nil {
+					continue
+				}
+				i := slices.IndexFunc(pool.Consumers, func(c PoolConsumer) bool { return c.BlueprintId == bp.Id })
+				if i < 0 {
+					pool.Consumers = append(pool.Consumers, PoolConsumer{BlueprintId: bp.Id, BlueprintLabel: bp.Label})
+					i = len(pool.Consumers) - 1
+				}
+				pool.Consumers[i].ResourceGroups = append(pool.Consumers[i].ResourceGroups, alloc.ResourceGroup)
+			}
+		}
+	}
+
+	for i := range result.Pools {
+		slices.SortFunc(result.Pools[i].Consumers, func(a, b PoolConsumer) int {
+			return cmp.Or(strings.Compare(a.BlueprintLabel, b.BlueprintLabel), strings.Compare(a.BlueprintId.String(), b.BlueprintId.String()))
+		})
+	}
+
+	slices.SortStableFunc(result.Pools, func(a, b PoolUtilization) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), strings.Compare(a.Label, b.Label))
+	})
+
+	return &result
+}
+
+func newIntPoolUtilization(t ResourceType, pool IntPool) PoolUtilization {
+	result := PoolUtilization{
+		Type:   t,
+		Id:     pool.Id,
+		Label:  pool.DisplayName,
+		Tags:   pool.Tags,
+		Status: pool.Status,
+		Total:  new(big.Int).SetUint64(uint64(pool.Total)),
+		Used:   new(big.Int).SetUint64(uint64(pool.Used)),
+		Blocks: make([]PoolBlock, len(pool.Ranges)),
+	}
+
+	for i, r := range pool.Ranges {
+		result.Blocks[i] = newPoolBlock(fmt.Sprintf("%d-%d", r.First, r.Last), r.Status,
+			new(big.Int).SetUint64(uint64(r.Total)), new(big.Int).SetUint64(uint64(r.Used)))
+	}
+
+	result.finish()
+	return result
+}
+
+func newIpPoolUtilization(t ResourceType, pool IpPool) PoolUtilization {
+	result := PoolUtilization{
+		Type:   t,
+		Id:     pool.Id,
+		Label:  pool.DisplayName,
+		Tags:   pool.Tags,
+		Status: pool.Status,
+		Total:  new(big.Int).Set(&pool.Total),
+		Used:   new(big.Int).Set(&pool.Used),
+		Blocks: make([]PoolBlock, len(pool.Subnets)),
+	}
+
+	for i, s := range pool.Subnets {
+		var network string
+		if s.Network != nil {
+			network = s.Network.String()
+		}
+		result.Blocks[i] = newPoolBlock(network, s.Status, new(big.Int).Set(&s.Total), new(big.Int).Set(&s.Used))
+	}
+
+	result.finish()
+	return result
+}
+
+// finish calculates free space and identifies blocks with free space.
+func (o *PoolUtilization) finish() {
+	o.Free = new(big.Int).Sub(o.Total, o.Used)
+	o.UsedPercentage = percentage(o.Used, o.Total)
+
+	for _, block := range o.Blocks {
+		if block.Free.Sign() > 0 {
+			o.FreeBlocks = append(o.FreeBlocks, block)
+		}
+	}
+	slices.SortStableFunc(o.FreeBlocks, func(a, b PoolBlock) int { return b.Free.Cmp(a.Free) })
+}
+
+func newPoolBlock(r, status string, total, used *big.Int) PoolBlock {
+	return PoolBlock{
+		Range:          r,
+		Status:         status,
+		Total:          total,
+		Used:           used,
+		Free:           new(big.Int).Sub(total, used),
+		UsedPercentage: percentage(used, total),
+	}
+}
+
+// percentage returns 100 * a / b, or 0 when b is zero.
+func percentage(a, b *big.Int) float64 {
+	if b.Sign() == 0 {
+		return 0
+	}
+	result, _ := new(big.Rat).SetFrac(new(big.Int).Mul(a, big.NewInt(100)), b).Float64()
+	return result
+}
+
+// ResourceDemand is the number of resources (ASNs, VNIs or individual IP
+// addresses) required from each resource group.
+type ResourceDemand map[ResourceGroupName]uint64
+
+// Add accumulates other into o.
+func (o ResourceDemand) Add(other ResourceDemand) {
+	for k, v := range other {
+		o[k] += v
+	}
+}
+
+// Scale returns a copy of o multiplied by n.
+func (o ResourceDemand) Scale(n uint64) ResourceDemand {
+	result := make(ResourceDemand, len(o))
+	for k, v := range o {
+		result[k] = v * n
+	}
+	return result
+}
+
+// RackResourceDemandOptions describes the fabric into which racks are added.
+type RackResourceDemandOptions struct {
+	SpineCount int  // spines to which each leaf connects
+	IPv6       bool // fabric uses IPv6 addressing in addition to IPv4
+}
+
+// RackTypeResourceDemand estimates the resources consumed by a single rack of
+// the given type: an ASN, loopback and VTEP address for each leaf, a /31 (/127
+// with IPv6) for each spine-facing link, and a loopback and ASN for each
+// generic system which has them enabled.
+func RackTypeResourceDemand(rackType design.RackType, opts RackResourceDemandOptions) ResourceDemand {
+	result := make(ResourceDemand)
+
+	for _, leaf := range rackType.LeafSwitches {
+		leafCount := uint64(1)
+		if leaf.RedundancyProtocol == enum.LeafRedundancyProtocolESI || leaf.RedundancyProtocol == enum.LeafRedundancyProtocolMLAG {
+			leafCount = 2
+		}
+
+		linksPerSpine := uint64(1)
+		if leaf.LinkPerSpineCount != nil {
+			linksPerSpine = uint64(*leaf.LinkPerSpineCount)
+		}
+		fabricIps := leafCount * uint64(opts.SpineCount) * linksPerSpine * 2
+
+		result[ResourceGroupNameLeafAsn] += leafCount
+		result[ResourceGroupNameLeafIp4] += leafCount
+		result[ResourceGroupNameVtepIp4] += leafCount
+		result[ResourceGroupNameSpineLeafIp4] += fabricIps
+		if opts.IPv6 {
+			result[ResourceGroupNameLeafIp6] += leafCount
+			result[ResourceGroupNameSpineLeafIp6] += fabricIps
+		}
+	}
+
+	for _, gs := range rackType.GenericSystems {
+		count := uint64(gs.Count)
+		if gs.ASNDomain != nil && *gs.ASNDomain == enum.FeatureSwitchEnabled {
+			result[ResourceGroupNameGenericAsn] += count
+		}
+		if gs.Loopback != nil && *gs.Loopback == enum.FeatureSwitchEnabled {
+			result[ResourceGroupNameGenericIp4] += count
+			if opts.IPv6 {
+				result[ResourceGroupNameGenericIp6] += count
+			}
+		}
+	}
+
+	return result
+}
+
+// PoolForecastItem is the projected state of a pool after additional demand.
+type PoolForecastItem struct {
+	Type           ResourceType
+	Id             ObjectId
+	Label          string
+	Free           *big.Int
+	Demand         *big.Int
+	Remaining      *big.Int // negative when the pool would be exhausted
+	Exhausted      bool
+	ResourceGroups []ResourceGroupName // resource groups drawing on this pool
+}
+
+// PoolForecast projects pool utilization after a blueprint grows.
+type PoolForecast struct {
+	Pools      []PoolForecastItem
+	Unassigned []ResourceGroupName // groups with demand, but no pools allocated
+}
+
+// Exhausted returns true when any pool would be exhausted, or when demand
+// cannot be met because no pool is allocated.
+func (o PoolForecast) Exhausted() bool {
+	return len(o.Unassigned) > 0 || slices.ContainsFunc(o.Pools, func(p PoolForecastItem) bool { return p.Exhausted })
+}
+
+// Forecast projects whether the blueprint's pools can satisfy demand. The
+// demand of each resource group is drawn from its pools in the order they are
+// allocated. Demand which exceeds the free capacity of every pool in the group
+// is charged to the group's last pool, which is then reported as exhausted.
+// Pools shared between resource groups (or blueprints) are accounted for once.
+func (o *PoolUtilizationReport) Forecast(blueprintId ObjectId, demand ResourceDemand) (*PoolForecast, error) {
+	i := slices.IndexFunc(o.Blueprints, func(bp PoolUtilizationBlueprint) bool { return bp.Id == blueprintId })
+	if i < 0 {
+		return nil, ClientErr{
+			errType: ErrNotfound,
+			err:     fmt.Errorf("blueprint %q not found in pool utilization report", blueprintId),
+		}
+	}
+	bp := o.Blueprints[i]
+
+	var result PoolForecast
+	item := func(t ResourceType, id ObjectId) (*PoolForecastItem, error) {
+		i := slices.IndexFunc(result.Pools, func(p PoolForecastItem) bool { return p.Type == t && p.Id == id })
+		if i >= 0 {
+			return &result.Pools[i], nil
+		}
+		pool := o.Pool(t, id)
+		if pool == nil {
+			return nil, ClientErr{
+				errType: ErrNotfound,
+				err:     fmt.Errorf("blueprint %q uses unknown %s pool %q", blueprintId, t, id),
+			}
+		}
+		result.Pools = append(result.Pools, PoolForecastItem{
+			Type:      t,
+			Id:        id,
+			Label:     pool.Label,
+			Free:      new(big.Int).Set(pool.Free),
+			Demand:    new(big.Int),
+			Remaining: new(big.Int).Set(pool.Free),
+		})
+		return &result.Pools[len(result.Pools)-1], nil
+	}
+
+	names := make([]ResourceGroupName, 0, len(demand))
+	for name, count := range demand {
+		if count > 0 {
+			names = append(names, name)
+		}
+	}
+	slices.SortFunc(names, func(a, b ResourceGroupName) int { return strings.Compare(a.String(), b.String()) })
+
+	for _, name := range names {
+		rg := ResourceGroup{Type: name.Type(), Name: name}
+		alloc := bp.Allocations.Get(&rg)
+		if alloc == nil || alloc.IsEmpty() {
+			result.Unassigned = append(result.Unassigned, name)
+			continue
+		}
+
+		needed := new(big.Int).SetUint64(demand[name])
+		for j, poolId := range alloc.PoolIds {
+			p, err := item(rg.Type, poolId)
+			if err != nil {
+				return nil, err
+			}
+			if !slices.Contains(p.ResourceGroups, name) {
+				p.ResourceGroups = append(p.ResourceGroups, name)
+			}
+
+			take := needed
+			if j < len(alloc.PoolIds)-1 && p.Remaining.Cmp(needed) < 0 {
+				take = new(big.Int).Set(p.Remaining)
+				if take.Sign() < 0 {
+					take.SetInt64(0)
+				}
+			}
+			p.Demand.Add(p.Demand, take)
+			p.Remaining.Sub(p.Remaining, take)
+			needed = new(big.Int).Sub(needed, take)
+			if needed.Sign() == 0 {
+				break
+			}
+		}
+	}
+
+	for i := range result.Pools {
+		result.Pools[i].Exhausted = result.Pools[i].Remaining.Sign() < 0
+	}
+
+	return &result, nil
+}
+
+// GetPoolUtilizationState collects all ASN, VNI and IP pools along with the
+// resource allocations of every datacenter blueprint.
+func (o *Client) GetPoolUtilizationState(ctx context.Context) (*PoolUtilizationState, error) {
+	var result PoolUtilizationState
+	var err error
+
+	if result.AsnPools, err = o.GetAsnPools(ctx); err != nil {
+		return nil, fmt.Errorf("failed fetching ASN pools - %w", err)
+	}
+	if result.VniPools, err = o.GetVniPools(ctx); err != nil {
+		return nil, fmt.Errorf("failed fetching VNI pools - %w", err)
+	}
+	if result.Ip4Pools, err = o.GetIp4Pools(ctx); err != nil {
+		return nil, fmt.Errorf("failed fetching IPv4 pools - %w", err)
+	}
+	if result.Ip6Pools, err = o.GetIp6Pools(ctx); err != nil {
+		return nil, fmt.Errorf("failed fetching IPv6 pools - %w", err)
+	}
+
+	statuses, err := o.GetAllBlueprintStatus(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching blueprint status - %w", err)
+	}
+
+	for _, status := range statuses {
+		if status.Design != enum.RefDesignDatacenter {
+			continue
+		}
+
+		bpClient, err := o.NewTwoStageL3ClosClient(ctx, status.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		allocations, err := bpClient.GetResourceAllocations(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed fetching resource allocations from blueprint %q - %w", status.Id, err)
+		}
+
+		result.Blueprints = append(result.Blueprints, PoolUtilizationBlueprint{
+			Id:          status.Id,
+			Label:       status.Label,
+			Allocations: allocations,
+		})
+	}
+
+	return &result, nil
+}
+
+// GetPoolUtilizationReport returns utilization details for all ASN, VNI and IP
+// pools, including the blueprints which consume each pool.
+func (o *Client) GetPoolUtilizationReport(ctx context.Context) (*PoolUtilizationReport, error) {
+	state, err := o.GetPoolUtilizationState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPoolUtilizationReport(*state), nil
+}
@@ -0,0 +1,189 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/design"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolUtilizationReport(t *testing.T) {
+	subnet := func(cidr string, total, used int64) IpSubnet {
+		_, n, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+		s := IpSubnet{Network: n}
+		s.Total.SetInt64(total)
+		s.Used.SetInt64(used)
+		return s
+	}
+
+	ip4 := IpPool{Id: "ip4", DisplayName: "loopbacks", Subnets: []IpSubnet{
+		subnet("10.0.0.0/28", 16, 10),
+		subnet("10.0.1.0/29", 8, 0),
+		subnet("10.0.2.0/28", 16, 0),
+	}}
+	ip4.Total.SetInt64(40)
+	ip4.Used.SetInt64(10)
+
+	state := PoolUtilizationState{
+		AsnPools: []AsnPool{
+			{Id: "asn2", DisplayName: "asn-b", Total: 10, Used: 9, Ranges: []IntRange{{First: 200, Last: 209, Total: 10, Used: 9}}},
+			{Id: "asn1", DisplayName: "asn-a", Total: 20, Used: 5, Ranges: []IntRange{
+				{First: 100, Last: 109, Total: 10, Used: 5},
+				{First: 110, Last: 119, Total: 10},
+			}},
+		},
+		Ip4Pools: []IpPool{ip4},
+		Blueprints: []PoolUtilizationBlueprint{
+			{Id: "bp2", Label: "two", Allocations: ResourceGroupAllocations{
+				{ResourceGroup: ResourceGroup{Type: ResourceTypeAsnPool, Name: ResourceGroupNameLeafAsn}, PoolIds: []ObjectId{"asn1"}},
+			}},
+			{Id: "bp1", Label: "one", Allocations: ResourceGroupAllocations{
+				{ResourceGroup: ResourceGroup{Type: ResourceTypeAsnPool, Name: ResourceGroupNameLeafAsn}, PoolIds: []ObjectId{"asn2", "asn1"}},
+				{ResourceGroup: ResourceGroup{Type: ResourceTypeAsnPool, Name: ResourceGroupNameSpineAsn}, PoolIds: []ObjectId{"asn2"}},
+				{ResourceGroup: ResourceGroup{Type: ResourceTypeIp4Pool, Name: ResourceGroupNameLeafIp4}, PoolIds: []ObjectId{"ip4"}},
+				{ResourceGroup: ResourceGroup{Type: ResourceTypeIp4Pool, Name: ResourceGroupNameVtepIp4}, PoolIds: []ObjectId{"ip4"}},
+				{ResourceGroup: ResourceGroup{Type: ResourceTypeIp4Pool, Name: ResourceGroupNameSpineLeafIp4}, PoolIds: []ObjectId{"missing"}},
+			}},
+		},
+	}
+
+	report := NewPoolUtilizationReport(state)
+
+	t.Run("report", func(t *testing.T) {
+		require.Len(t, report.Pools, 3)
+		require.Equal(t, "asn-a", report.Pools[0].Label)
+		require.Equal(t, "asn-b", report.Pools[1].Label)
+		require.Equal(t, ResourceTypeIp4Pool, report.Pools[2].Type)
+
+		asn := report.Pool(ResourceTypeAsnPool, "asn1")
+		require.NotNil(t, asn)
+		require.Equal(t, int64(15), asn.Free.Int64())
+		require.Equal(t, float64(25), asn.UsedPercentage)
+		require.Len(t, asn.Blocks, 2)
+		require.Equal(t, "100-109", asn.Blocks[0].Range)
+		require.Equal(t, float64(50), asn.Blocks[0].UsedPercentage)
+		require.Len(t, asn.FreeBlocks, 2)
+		require.Equal(t, "110-119", asn.FreeBlocks[0].Range)
+		require.Equal(t, "100-109", asn.FreeBlocks[1].Range) // partially used blocks are included
+		require.Len(t, asn.Consumers, 2)
+		require.Equal(t, ObjectId("bp1"), asn.Consumers[0].BlueprintId)
+		require.Equal(t, "two", asn.Consumers[1].BlueprintLabel)
+
+		ip := report.Pool(ResourceTypeIp4Pool, "ip4")
+		require.NotNil(t, ip)
+		require.Len(t, ip.FreeBlocks, 3)
+		require.Equal(t, []string{"10.0.2.0/28", "10.0.1.0/29", "10.0.0.0/28"}, []string{ip.FreeBlocks[0].Range, ip.FreeBlocks[1].Range, ip.FreeBlocks[2].Range})
+		require.Len(t, ip.Consumers, 1)
+		require.Len(t, ip.Consumers[0].ResourceGroups, 2)
+
+		require.Nil(t, report.Pool(ResourceTypeIp4Pool, "asn1"))
+	})
+
+	type testCase struct {
+		blueprintId ObjectId
+		demand      ResourceDemand
+		expErr      string
+		check       func(t *testing.T, forecast *PoolForecast)
+	}
+
+	testCases := map[string]testCase{
+		"fits": {
+			blueprintId: "bp1",
+			demand:      ResourceDemand{ResourceGroupNameLeafAsn: 4, ResourceGroupNameLeafIp4: 10},
+			check: func(t *testing.T, forecast *PoolForecast) {
+				require.False(t, forecast.Exhausted())
+				require.Len(t, forecast.Pools, 3)
+
+				// asn2 has one free ASN, the remaining three come from asn1
+				require.Equal(t, ObjectId("asn2"), forecast.Pools[0].Id)
+				require.Equal(t, int64(1), forecast.Pools[0].Demand.Int64())
+				require.Equal(t, int64(0), forecast.Pools[0].Remaining.Int64())
+				require.Equal(t, ObjectId("asn1"), forecast.Pools[1].Id)
+				require.Equal(t, int64(3), forecast.Pools[1].Demand.Int64())
+				require.Equal(t, int64(12), forecast.Pools[1].Remaining.Int64())
+				require.Equal(t, int64(20), forecast.Pools[2].Remaining.Int64())
+			},
+		},
+		"shared_pool_exhausted": {
+			blueprintId: "bp1",
+			demand: ResourceDemand{
+				ResourceGroupNameLeafIp4:    20,
+				ResourceGroupNameVtepIp4:    20,
+				ResourceGroupNameGenericAsn: 1,
+				ResourceGroupNameLeafIp6:    0,
+			},
+			check: func(t *testing.T, forecast *PoolForecast) {
+				require.True(t, forecast.Exhausted())
+				require.Len(t, forecast.Pools, 1)
+				require.True(t, forecast.Pools[0].Exhausted)
+				require.Equal(t, big.NewInt(-10), forecast.Pools[0].Remaining)
+				require.Equal(t, []ResourceGroupName{ResourceGroupNameLeafIp4, ResourceGroupNameVtepIp4}, forecast.Pools[0].ResourceGroups)
+				require.Equal(t, []ResourceGroupName{ResourceGroupNameGenericAsn}, forecast.Unassigned)
+			},
+		},
+		"unknown_blueprint": {
+			blueprintId: "bogus",
+			expErr:      `blueprint "bogus" not found`,
+		},
+		"unknown_pool": {
+			blueprintId: "bp1",
+			demand:      ResourceDemand{ResourceGroupNameSpineLeafIp4: 1},
+			expErr:      `unknown`,
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run("forecast_"+tName, func(t *testing.T) {
+			forecast, err := report.Forecast(tCase.blueprintId, tCase.demand)
+			if tCase.expErr != "" {
+				require.ErrorContains(t, err, tCase.expErr)
+				return
+			}
+			require.NoError(t, err)
+			tCase.check(t, forecast)
+		})
+	}
+}
+
+func TestRackTypeResourceDemand(t *testing.T) {
+	rackType := design.RackType{
+		LeafSwitches: []design.RackTypeLeafSwitch{
+			{RedundancyProtocol: enum.LeafRedundancyProtocolESI, LinkPerSpineCount: pointer.To(2)},
+			{RedundancyProtocol: enum.LeafRedundancyProtocolNone},
+		},
+		GenericSystems: []design.RackTypeGenericSystem{
+			{Count: 4, Loopback: &enum.FeatureSwitchEnabled, ASNDomain: &enum.FeatureSwitchEnabled},
+			{Count: 8},
+		},
+	}
+
+	require.Equal(t, ResourceDemand{
+		ResourceGroupNameLeafAsn:      3,
+		ResourceGroupNameLeafIp4:      3,
+		ResourceGroupNameVtepIp4:      3,
+		ResourceGroupNameSpineLeafIp4: 2*2*2*2 + 1*2*1*2,
+		ResourceGroupNameGenericAsn:   4,
+		ResourceGroupNameGenericIp4:   4,
+	}, RackTypeResourceDemand(rackType, RackResourceDemandOptions{SpineCount: 2}))
+
+	demand := RackTypeResourceDemand(rackType, RackResourceDemandOptions{SpineCount: 2, IPv6: true})
+	require.Equal(t, uint64(3), demand[ResourceGroupNameLeafIp6])
+	require.Equal(t, uint64(20), demand[ResourceGroupNameSpineLeafIp6])
+	require.Equal(t, uint64(4), demand[ResourceGroupNameGenericIp6])
+
+	scaled := demand.Scale(4)
+	require.Equal(t, uint64(12), scaled[ResourceGroupNameLeafAsn])
+	require.Equal(t, uint64(3), demand[ResourceGroupNameLeafAsn])
+
+	demand.Add(ResourceDemand{ResourceGroupNameLeafAsn: 1})
+	require.Equal(t, uint64(4), demand[ResourceGroupNameLeafAsn])
+}
@@ -0,0 +1,246 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// AgentCredentialRotationRequest describes new credentials for an Agent
+// Profile. Apstra never reveals stored credentials, so rollback is possible
+// only when the previous credentials are supplied.
+type AgentCredentialRotationRequest struct {
+	ProfileId ObjectId
+	Username  *string // nil leaves the username unchanged
+	Password  *string // nil leaves the password unchanged
+
+	PreviousUsername *string // required for rollback when Username is set
+	PreviousPassword *string // required for rollback when Password is set
+
+	Concurrency       int  // agents checked in parallel, default 1
+	RollbackOnFailure bool // restore the previous credentials if any agent fails its check
+}
+
+func (o AgentCredentialRotationRequest) validate() error {
+	if o.Username == nil && o.Password == nil {
+		return errors.New("credential rotation requires a new username and/or password")
+	}
+	if o.RollbackOnFailure {
+		return o.validateRollback()
+	}
+	return nil
+}
+
+func (o AgentCredentialRotationRequest) validateRollback() error {
+	if o.Username != nil && o.PreviousUsername == nil {
+		return errors.New("rollback requires the previous username")
+	}
+	if o.Password != nil && o.PreviousPassword == nil {
+		return errors.New("rollback requires the previous password")
+	}
+	return nil
+}
+
+// AgentCredentialCheck is the result of a check job run against one agent.
+type AgentCredentialCheck struct {
+	AgentId      ObjectId
+	Label        string
+	ManagementIp string
+	SystemId     SystemId
+	JobStatus    *AgentJobStatus
+	Err          error
+}
+
+// Failed returns true when the check job could not be run or did not succeed.
+func (o AgentCredentialCheck) Failed() bool {
+	return o.Err != nil || o.JobStatus == nil || o.JobStatus.State != AgentJobStateSuccess
+}
+
+// AgentCredentialRotationResult reports the check results of every agent
+// using the rotated profile. When the rotation was rolled back, RollbackChecks
+// holds the results of the checks run with the restored credentials.
+type AgentCredentialRotationResult struct {
+	ProfileId      ObjectId
+	Checks         []AgentCredentialCheck
+	RolledBack     bool
+	RollbackChecks []AgentCredentialCheck
+}
+
+// Failed returns the failed checks run with the new credentials.
+func (o AgentCredentialRotationResult) Failed() []AgentCredentialCheck {
+	return failedAgentChecks(o.Checks)
+}
+
+func failedAgentChecks(checks []AgentCredentialCheck) []AgentCredentialCheck {
+	var result []AgentCredentialCheck
+	for _, c := range checks {
+		if c.Failed() {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// systemAgentsUsingProfile returns the agents configured with profileId.
+func systemAgentsUsingProfile(agents []SystemAgent, profileId ObjectId) []SystemAgent {
+	var result []SystemAgent
+	for _, a := range agents {
+		if a.Config.Profile == profileId {
+			result = append(result, a)
+		}
+	}
+	return result
+}
+
+// runAgentChecks runs check against each agent, up to concurrency at a time.
+// Results are returned in the order of agents.
+func runAgentChecks(ctx context.Context, agents []SystemAgent, concurrency int, check func(context.Context, ObjectId) (*AgentJobStatus, error)) []AgentCredentialCheck {
+	result := make([]AgentCredentialCheck, len(agents))
+	sem := make(chan struct{}, max(concurrency, 1))
+
+	var wg sync.WaitGroup
+	for i, agent := range agents {
+		result[i] = AgentCredentialCheck{
+			AgentId:      agent.Id,
+			Label:        agent.Config.Label,
+			ManagementIp: agent.Config.ManagementIp,
+			SystemId:     agent.Status.SystemId,
+		}
+
+		wg.Add(1)
+		go func(r *AgentCredentialCheck) {
+			defer wg.Done()
+			select {
+			case <-ctx.Done():
+				r.Err = ctx.Err()
+				return
+			case sem <- struct{}{}:
+			}
+			defer func() { <-sem }()
+			r.JobStatus, r.Err = check(ctx, r.AgentId)
+		}(&result[i])
+	}
+	wg.Wait()
+
+	return result
+}
+
+// GetSystemAgentsByProfile returns every SystemAgent configured with the
+// specified Agent Profile.
+func (o *Client) GetSystemAgentsByProfile(ctx context.Context, profileId ObjectId) ([]SystemAgent, error) {
+	agents, err := o.getAllSystemAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return systemAgentsUsingProfile(agents, profileId), nil
+}
+
+// applyAgentCredentials updates the profile, re-assigns it to the agents so
+// that they pick up the new credentials, and runs a check job on each agent.
+func (o *Client) applyAgentCredentials(ctx context.Context, profileId ObjectId, username, password *string, agents []SystemAgent, concurrency int) ([]AgentCredentialCheck, error) {
+	err := o.updateAgentProfile(ctx, profileId, &AgentProfileConfig{Username: username, Password: password})
+	if err != nil {
+		return nil, fmt.Errorf("failed updating credentials of agent profile %q - %w", profileId, err)
+	}
+
+	if len(agents) == 0 {
+		return nil, nil
+	}
+
+	agentIds := make([]ObjectId, len(agents))
+	for i, a := range agents {
+		agentIds[i] = a.Id
+	}
+	err = o.assignAgentProfile(ctx, &AssignAgentProfileRequest{SystemAgents: agentIds, ProfileId: profileId})
+	if err != nil {
+		return nil, fmt.Errorf("failed re-assigning agent profile %q - %w", profileId, err)
+	}
+
+	return runAgentChecks(ctx, agents, concurrency, func(ctx context.Context, id ObjectId) (*AgentJobStatus, error) {
+		return o.SystemAgentRunJob(ctx, id, AgentJobTypeCheck)
+	}), nil
+}
+
+// RotateAgentCredentials sets new credentials on an Agent Profile, applies
+// them to every SystemAgent using the profile, and re-validates each agent's
+// connectivity with a check job. The result reports every agent's check.
+// When any check fails an error is returned along with the result and, if
+// req.RollbackOnFailure is set, the previous credentials are restored and
+// checked again.
+func (o *Client) RotateAgentCredentials(ctx context.Context, req AgentCredentialRotationRequest) (*AgentCredentialRotationResult, error) {
+	err := req.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = o.getAgentProfile(ctx, req.ProfileId)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching agent profile %q - %w", req.ProfileId, err)
+	}
+
+	agents, err := o.GetSystemAgentsByProfile(ctx, req.ProfileId)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching system agents - %w", err)
+	}
+
+	result := AgentCredentialRotationResult{ProfileId: req.ProfileId}
+	result.Checks, err = o.applyAgentCredentials(ctx, req.ProfileId, req.Username, req.Password, agents, req.Concurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	failed := result.Failed()
+	if len(failed) == 0 {
+		return &result, nil
+	}
+	err = fmt.Errorf("%d of %d agents using profile %q failed credential check", len(failed), len(result.Checks), req.ProfileId)
+
+	if !req.RollbackOnFailure {
+		return &result, err
+	}
+
+	result.RollbackChecks, err = o.applyAgentCredentials(ctx, req.ProfileId, req.PreviousUsername, req.PreviousPassword, agents, req.Concurrency)
+	if err != nil {
+		return &result, fmt.Errorf("credential rotation failed, then rollback failed - %w", err)
+	}
+	result.RolledBack = true
+
+	if rbFailed := failedAgentChecks(result.RollbackChecks); len(rbFailed) > 0 {
+		return &result, fmt.Errorf("credential rotation rolled back, but %d agents failed credential check with the previous credentials", len(rbFailed))
+	}
+
+	return &result, fmt.Errorf("credential rotation rolled back: %d of %d agents failed credential check", len(failed), len(result.Checks))
+}
+
+// RollbackAgentCredentials restores the previous credentials described by a
+// completed rotation request, and re-validates each agent using the profile.
+func (o *Client) RollbackAgentCredentials(ctx context.Context, req AgentCredentialRotationRequest) ([]AgentCredentialCheck, error) {
+	err := req.validateRollback()
+	if err != nil {
+		return nil, err
+	}
+	if req.PreviousUsername == nil && req.PreviousPassword == nil {
+		return nil, errors.New("rollback requires the previous username and/or password")
+	}
+
+	agents, err := o.GetSystemAgentsByProfile(ctx, req.ProfileId)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching system agents - %w", err)
+	}
+
+	checks, err := o.applyAgentCredentials(ctx, req.ProfileId, req.PreviousUsername, req.PreviousPassword, agents, req.Concurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	if failed := failedAgentChecks(checks); len(failed) > 0 {
+		return checks, fmt.Errorf("%d of %d agents using profile %q failed credential check", len(failed), len(checks), req.ProfileId)
+	}
+
+	return checks, nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentCredentialRotationRequestValidate(t *testing.T) {
+	type testCase struct {
+		req    AgentCredentialRotationRequest
+		expErr bool
+	}
+
+	testCases := map[string]testCase{
+		"password_only": {
+			req: AgentCredentialRotationRequest{Password: pointer.To("new")},
+		},
+		"nothing_to_rotate": {
+			req:    AgentCredentialRotationRequest{},
+			expErr: true,
+		},
+		"rollback_with_previous_password": {
+			req: AgentCredentialRotationRequest{Password: pointer.To("new"), PreviousPassword: pointer.To("old"), RollbackOnFailure: true},
+		},
+		"rollback_without_previous_password": {
+			req:    AgentCredentialRotationRequest{Password: pointer.To("new"), RollbackOnFailure: true},
+			expErr: true,
+		},
+		"rollback_without_previous_username": {
+			req:    AgentCredentialRotationRequest{Username: pointer.To("new"), Password: pointer.To("new"), PreviousPassword: pointer.To("old"), RollbackOnFailure: true},
+			expErr: true,
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			err := tCase.req.validate()
+			if tCase.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRunAgentChecks(t *testing.T) {
+	agents := []SystemAgent{
+		{Id: "a1", Config: SystemAgentConfig{Profile: "p1", Label: "leaf1"}},
+		{Id: "a2", Config: SystemAgentConfig{Profile: "p2", Label: "leaf2"}},
+		{Id: "a3", Config: SystemAgentConfig{Profile: "p1", Label: "leaf3"}},
+		{Id: "a4", Config: SystemAgentConfig{Profile: "p1", Label: "leaf4"}},
+	}
+
+	using := systemAgentsUsingProfile(agents, "p1")
+	require.Len(t, using, 3)
+
+	var running, maxRunning atomic.Int32
+	checks := runAgentChecks(t.Context(), using, 2, func(_ context.Context, id ObjectId) (*AgentJobStatus, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+
+		switch id {
+		case "a3":
+			return &AgentJobStatus{State: AgentJobStateFailed, Error: "auth failed"}, nil
+		case "a4":
+			return nil, errors.New("boom")
+		}
+		return &AgentJobStatus{State: AgentJobStateSuccess}, nil
+	})
+
+	require.LessOrEqual(t, maxRunning.Load(), int32(2))
+	require.Len(t, checks, 3)
+	require.Equal(t, ObjectId("a1"), checks[0].AgentId)
+	require.Equal(t, "leaf3", checks[1].Label)
+	require.False(t, checks[0].Failed())
+	require.True(t, checks[1].Failed())
+	require.True(t, checks[2].Failed())
+
+	result := AgentCredentialRotationResult{Checks: checks}
+	failed := result.Failed()
+	require.Len(t, failed, 2)
+	require.Equal(t, ObjectId("a3"), failed[0].AgentId)
+	require.Equal(t, ObjectId("a4"), failed[1].AgentId)
+}
@@ -20,7 +20,10 @@ const (
 	apiUrlSystemAgentCheck      = apiUrlSystemAgentsPrefix + "%s" + "/check"
 	apiUrlSystemAgentInstall    = apiUrlSystemAgentsPrefix + "%s" + "/install-agent"
 	apiUrlSystemAgentUninstall  = apiUrlSystemAgentsPrefix + "%s" + "/uninstall-agent"
+	apiUrlSystemAgentUpgrade    = apiUrlSystemAgentsPrefix + "%s" + "/upgrade"
 	apiUrlSystemAgentJobHistory = apiUrlSystemAgentsPrefix + "%s" + "/job-history"
+	apiUrlSystemAgentJobLog     = apiUrlSystemAgentsPrefix + "%s" + "/jobs/%d/log"
+	apiUrlSystemAgentJobError   = apiUrlSystemAgentsPrefix + "%s" + "/jobs/%d/error"
 
 	offBox = "offbox"
 	onBox  = "onbox"
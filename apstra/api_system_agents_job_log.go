@@ -0,0 +1,152 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (o *Client) getSystemAgentJobLog(ctx context.Context, agentId ObjectId, jobId JobId) (string, error) {
+	var response struct {
+		Log string `json:"log"`
+	}
+	err := o.talkToApstra(ctx, &talkToApstraIn{
+		method:      http.MethodGet,
+		urlStr:      fmt.Sprintf(apiUrlSystemAgentJobLog, agentId, jobId),
+		apiResponse: &response,
+	})
+	if err != nil {
+		return "", convertTtaeToAceWherePossible(err)
+	}
+	return response.Log, nil
+}
+
+func (o *Client) getSystemAgentJobError(ctx context.Context, agentId ObjectId, jobId JobId) (string, error) {
+	var response struct {
+		Error string `json:"error"`
+	}
+	err := o.talkToApstra(ctx, &talkToApstraIn{
+		method:      http.MethodGet,
+		urlStr:      fmt.Sprintf(apiUrlSystemAgentJobError, agentId, jobId),
+		apiResponse: &response,
+	})
+	if err != nil {
+		return "", convertTtaeToAceWherePossible(err)
+	}
+	return response.Error, nil
+}
+
+// GetSystemAgentJobLog returns the full log of the specified agent job.
+func (o *Client) GetSystemAgentJobLog(ctx context.Context, agentId ObjectId, jobId JobId) (string, error) {
+	return o.getSystemAgentJobLog(ctx, agentId, jobId)
+}
+
+// GetSystemAgentJobError returns the error output of the specified agent job.
+// Jobs which have not failed generally produce an empty string.
+func (o *Client) GetSystemAgentJobError(ctx context.Context, agentId ObjectId, jobId JobId) (string, error) {
+	return o.getSystemAgentJobError(ctx, agentId, jobId)
+}
+
+// AgentJobEvent is produced by FollowJob. Each event carries either a new log
+// line or a job status (whenever the job state changes).
+type AgentJobEvent struct {
+	LogLine     string
+	Status      *AgentJobStatus
+	ErrorOutput string // error output of a failed job, in the final event only
+}
+
+// newLogLines returns the complete lines found in log beyond offset, along with
+// the offset of the first unconsumed byte. A trailing partial line is held back
+// until final is true. A log shorter than offset is assumed to have been
+// replaced, and is read again from the beginning.
+func newLogLines(log string, offset int, final bool) ([]string, int) {
+	if len(log) < offset {
+		offset = 0
+	}
+
+	remaining := log[offset:]
+	end := strings.LastIndexByte(remaining, '\n') + 1
+	if final {
+		end = len(remaining)
+	}
+	if end == 0 {
+		return nil, offset
+	}
+
+	return strings.Split(strings.TrimSuffix(remaining[:end], "\n"), "\n"), offset + end
+}
+
+// FollowJob polls the specified agent job, yielding its log one line at a time
+// as lines appear, and its status whenever the state changes, in the manner of
+// `tail -f`. Iteration ends after the job exits (AgentJobState.HasExited()),
+// when an error is yielded, or when the caller stops consuming events. The job
+// need not exist when FollowJob is called.
+func (o *Client) FollowJob(ctx context.Context, agentId ObjectId, jobId JobId) iter.Seq2[AgentJobEvent, error] {
+	return func(yield func(AgentJobEvent, error) bool) {
+		var offset int
+		var lastState *AgentJobState
+		for {
+			status, err := o.getSystemAgentJobStatus(ctx, agentId, jobId)
+			var ace ClientErr
+			switch {
+			case errors.As(err, &ace) && ace.Type() == ErrNotfound:
+				status = nil // job not started yet
+			case err != nil:
+				yield(AgentJobEvent{}, err)
+				return
+			}
+
+			if status != nil {
+				exited := status.State.HasExited()
+
+				log, err := o.getSystemAgentJobLog(ctx, agentId, jobId)
+				if err != nil && !(errors.As(err, &ace) && ace.Type() == ErrNotfound) {
+					yield(AgentJobEvent{}, fmt.Errorf("failed fetching log of agent %q job %d - %w", agentId, jobId, err))
+					return
+				}
+
+				var lines []string
+				lines, offset = newLogLines(log, offset, exited)
+				for _, line := range lines {
+					if !yield(AgentJobEvent{LogLine: line}, nil) {
+						return
+					}
+				}
+
+				if lastState == nil || *lastState != status.State {
+					lastState = &status.State
+					event := AgentJobEvent{Status: status}
+					if status.State == AgentJobStateFailed {
+						event.ErrorOutput, err = o.getSystemAgentJobError(ctx, agentId, jobId)
+						if err != nil {
+							yield(AgentJobEvent{}, fmt.Errorf("failed fetching error output of agent %q job %d - %w", agentId, jobId, err))
+							return
+						}
+					}
+					if !yield(event, nil) {
+						return
+					}
+				}
+
+				if exited {
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				yield(AgentJobEvent{}, ctx.Err())
+				return
+			case <-time.After(clientPollingIntervalMs * time.Millisecond):
+			}
+		}
+	}
+}
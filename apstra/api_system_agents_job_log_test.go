@@ -0,0 +1,71 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLogLines(t *testing.T) {
+	type testCase struct {
+		log       string
+		offset    int
+		final     bool
+		expLines  []string
+		expOffset int
+	}
+
+	testCases := map[string]testCase{
+		"empty": {},
+		"partial_line_held": {
+			log:      "downloading",
+			expLines: nil,
+		},
+		"partial_line_final": {
+			log:       "downloading",
+			final:     true,
+			expLines:  []string{"downloading"},
+			expOffset: 11,
+		},
+		"complete_lines": {
+			log:       "one\ntwo\nthr",
+			expLines:  []string{"one", "two"},
+			expOffset: 8,
+		},
+		"continue": {
+			log:       "one\ntwo\nthree\nfour\n",
+			offset:    8,
+			expLines:  []string{"three", "four"},
+			expOffset: 19,
+		},
+		"nothing_new": {
+			log:       "one\n",
+			offset:    4,
+			final:     true,
+			expOffset: 4,
+		},
+		"replaced": {
+			log:       "new\n",
+			offset:    8,
+			expLines:  []string{"new"},
+			expOffset: 4,
+		},
+		"blank_lines": {
+			log:       "a\n\nb\n",
+			expLines:  []string{"a", "", "b"},
+			expOffset: 5,
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			lines, offset := newLogLines(tCase.log, tCase.offset, tCase.final)
+			require.Equal(t, tCase.expLines, lines)
+			require.Equal(t, tCase.expOffset, offset)
+		})
+	}
+}
@@ -0,0 +1,507 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+const onboardingConnectionTimeoutDefault = 30 * time.Minute
+
+// onboardingSteps is the order in which onboarding steps run.
+var onboardingSteps = []enum.OnboardingStep{
+	enum.OnboardingStepCreateAgent,
+	enum.OnboardingStepInstallAgent,
+	enum.OnboardingStepAwaitConnection,
+	enum.OnboardingStepAcknowledgeSystem,
+	enum.OnboardingStepAssignSystem,
+	enum.OnboardingStepSetDeployMode,
+}
+
+// OnboardingDevice describes a single device to be onboarded.
+type OnboardingDevice struct {
+	ManagementIp   string
+	Label          string           // agent label, optional
+	SystemNodeId   ObjectId         // blueprint system node to which the device is assigned, optional
+	InterfaceMapId ObjectId         // interface map assigned to SystemNodeId
+	DeployMode     *enum.DeployMode // deploy mode set on SystemNodeId, optional
+}
+
+// steps returns the onboarding steps which apply to the device.
+func (o OnboardingDevice) steps() []enum.OnboardingStep {
+	result := slices.Clone(onboardingSteps)
+	if o.SystemNodeId == "" {
+		result = slices.DeleteFunc(result, func(s enum.OnboardingStep) bool {
+			return s == enum.OnboardingStepAssignSystem || s == enum.OnboardingStepSetDeployMode
+		})
+	}
+	if o.DeployMode == nil {
+		result = slices.DeleteFunc(result, func(s enum.OnboardingStep) bool { return s == enum.OnboardingStepSetDeployMode })
+	}
+	return result
+}
+
+// OnboardingRequest describes a batch of devices to be onboarded.
+type OnboardingRequest struct {
+	Devices []OnboardingDevice
+
+	// Agent is the template used to create each device's agent. ManagementIp
+	// and Label are set from the OnboardingDevice. JobOnCreate is ignored:
+	// agent installation is a separate step.
+	Agent SystemAgentRequest
+
+	BlueprintId       ObjectId // required when any device has a SystemNodeId
+	Concurrency       int      // devices onboarded in parallel, default 1
+	StateFile         string   // progress is persisted here, and read back on start, when set
+	RollbackOnFailure bool     // undo the blueprint assignment of devices which fail, and delete agents (and their systems) created by the run
+
+	// ConnectionTimeout limits the per-device wait for the agent to connect
+	// and report its system following installation. Default 30 minutes.
+	ConnectionTimeout time.Duration
+
+	// Progress, when set, is called after each step completes or fails. Calls
+	// are serialized.
+	Progress func(OnboardingEvent)
+}
+
+// OnboardingEvent reports the outcome of one onboarding step for one device.
+type OnboardingEvent struct {
+	ManagementIp string
+	Step         enum.OnboardingStep
+	Err          error
+	RolledBack   bool
+}
+
+// OnboardingDeviceState is the persisted progress of a single device.
+type OnboardingDeviceState struct {
+	AgentId      ObjectId              `json:"agent_id,omitempty"`
+	CreatedAgent bool                  `json:"created_agent,omitempty"` // the agent was created by onboarding, rather than found
+	SystemId     SystemId              `json:"system_id,omitempty"`
+	Completed    []enum.OnboardingStep `json:"completed,omitempty"`
+	Error        string                `json:"error,omitempty"`
+	RolledBack   bool                  `json:"rolled_back,omitempty"`
+}
+
+func (o *OnboardingDeviceState) done(step enum.OnboardingStep) bool {
+	return slices.Contains(o.Completed, step)
+}
+
+// OnboardingState is the persisted progress of an onboarding run, keyed by
+// management IP.
+type OnboardingState struct {
+	Devices map[string]*OnboardingDeviceState `json:"devices"`
+}
+
+// Complete returns true when every step which applies to device has finished.
+func (o *OnboardingState) Complete(device OnboardingDevice) bool {
+	ds, ok := o.Devices[device.ManagementIp]
+	if !ok {
+		return false
+	}
+	for _, step := range device.steps() {
+		if !ds.done(step) {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadOnboardingState reads an OnboardingState from path. A missing file
+// produces an empty state.
+func LoadOnboardingState(path string) (*OnboardingState, error) {
+	result := OnboardingState{Devices: make(map[string]*OnboardingDeviceState)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &result, nil
+		}
+		return nil, fmt.Errorf("failed reading onboarding state file %q - %w", path, err)
+	}
+
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing onboarding state file %q - %w", path, err)
+	}
+	if result.Devices == nil {
+		result.Devices = make(map[string]*OnboardingDeviceState)
+	}
+
+	return &result, nil
+}
+
+// Save writes the state to path. The file is replaced atomically, so an
+// interruption leaves either the old or the new state in place.
+func (o *OnboardingState) Save(path string) error {
+	data, err := json.MarshalIndent(o, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed marshaling onboarding state - %w", err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed creating temporary onboarding state file - %w", err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }() // no-op after a successful rename
+
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Close()
+	} else {
+		_ = f.Close()
+	}
+	if err != nil {
+		return fmt.Errorf("failed writing onboarding state file %q - %w", f.Name(), err)
+	}
+
+	err = os.Rename(f.Name(), path)
+	if err != nil {
+		return fmt.Errorf("failed replacing onboarding state file %q - %w", path, err)
+	}
+
+	return nil
+}
+
+// onboarder coordinates concurrent access to the shared state, state file and
+// progress callback.
+type onboarder struct {
+	client   *Client
+	bpClient *TwoStageL3ClosClient
+	req      *OnboardingRequest
+	state    *OnboardingState
+	mutex    sync.Mutex
+}
+
+// update applies f to the device state, persists the result and reports the
+// event.
+func (o *onboarder) update(ip string, f func(*OnboardingDeviceState), event *OnboardingEvent) error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	f(o.state.Devices[ip])
+
+	var err error
+	if o.req.StateFile != "" {
+		err = o.state.Save(o.req.StateFile)
+	}
+
+	if event != nil && o.req.Progress != nil {
+		o.req.Progress(*event)
+	}
+
+	return err
+}
+
+// snapshot returns a copy of the device state.
+func (o *onboarder) snapshot(ip string) OnboardingDeviceState {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	result := *o.state.Devices[ip]
+	result.Completed = slices.Clone(result.Completed)
+	return result
+}
+
+func (o *onboarder) onboard(ctx context.Context, device OnboardingDevice) error {
+	ip := device.ManagementIp
+
+	err := o.update(ip, func(ds *OnboardingDeviceState) { ds.Error = ""; ds.RolledBack = false }, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, step := range device.steps() {
+		ds := o.snapshot(ip)
+		if ds.done(step) {
+			continue
+		}
+
+		err = o.runStep(ctx, device, step, &ds)
+		if err != nil {
+			err = fmt.Errorf("onboarding step %q failed for device %q - %w", step, ip, err)
+			event := OnboardingEvent{ManagementIp: ip, Step: step, Err: err}
+			if o.req.RollbackOnFailure {
+				rbErr := o.rollback(ctx, device, ds, step)
+				if rbErr != nil {
+					err = errors.Join(err, rbErr)
+				}
+				event.RolledBack = rbErr == nil
+			}
+			return errors.Join(err, o.update(ip, func(s *OnboardingDeviceState) {
+				if event.RolledBack {
+					*s = OnboardingDeviceState{RolledBack: true}
+				} else {
+					s.AgentId, s.CreatedAgent, s.SystemId = ds.AgentId, ds.CreatedAgent, ds.SystemId
+				}
+				s.Error = err.Error()
+			}, &event))
+		}
+
+		err = o.update(ip, func(s *OnboardingDeviceState) {
+			s.AgentId, s.CreatedAgent, s.SystemId = ds.AgentId, ds.CreatedAgent, ds.SystemId
+			s.Completed = append(s.Completed, step)
+		}, &OnboardingEvent{ManagementIp: ip, Step: step})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *onboarder) runStep(ctx context.Context, device OnboardingDevice, step enum.OnboardingStep, ds *OnboardingDeviceState) error {
+	switch step {
+	case enum.OnboardingStepCreateAgent:
+		// use an existing agent. It may have been created by an earlier,
+		// interrupted run which didn't record its ID, but it may also belong
+		// to an already-managed device, so it is never deleted by rollback.
+		agent, err := o.client.GetSystemAgentByManagementIp(ctx, device.ManagementIp)
+		if err == nil {
+			ds.AgentId = agent.Id
+			ds.CreatedAgent = false
+			return nil
+		}
+		var ace ClientErr
+		if !(errors.As(err, &ace) && ace.Type() == ErrNotfound) {
+			return err
+		}
+
+		request := o.req.Agent
+		request.ManagementIp = device.ManagementIp
+		request.Label = device.Label
+		request.JobOnCreate = AgentJobTypeNone
+		ds.AgentId, err = o.client.CreateSystemAgent(ctx, &request)
+		ds.CreatedAgent = err == nil
+		return err
+
+	case enum.OnboardingStepInstallAgent:
+		// the job is run without SystemAgentRunJob, which waits for the agent
+		// to connect following installation. That wait belongs to the
+		// (time-limited) AwaitConnection step, and would never end were the
+		// installation to fail.
+		jobId, err := o.client.systemAgentStartJob(ctx, ds.AgentId, AgentJobTypeInstall)
+		if err != nil {
+			return fmt.Errorf("failed starting install job on agent %q - %w", ds.AgentId, err)
+		}
+		err = o.client.systemAgentWaitForJobToExist(ctx, ds.AgentId, jobId)
+		if err != nil {
+			return err
+		}
+		err = o.client.systemAgentWaitForJobTermination(ctx, ds.AgentId, jobId)
+		if err != nil {
+			return err
+		}
+		status, err := o.client.getSystemAgentJobStatus(ctx, ds.AgentId, jobId)
+		if err != nil {
+			return err
+		}
+		if status.State != AgentJobStateSuccess {
+			return fmt.Errorf("agent %q install job %d finished with state %q: %s", ds.AgentId, status.JobId, status.State, status.Error)
+		}
+		return nil
+
+	case enum.OnboardingStepAwaitConnection:
+		ctx, cancel := context.WithTimeout(ctx, cmp.Or(o.req.ConnectionTimeout, onboardingConnectionTimeoutDefault))
+		defer cancel()
+
+		err := o.client.systemAgentWaitForConnection(ctx, ds.AgentId)
+		if err != nil {
+			return fmt.Errorf("agent %q did not connect - %w", ds.AgentId, err)
+		}
+		for {
+			agent, err := o.client.GetSystemAgent(ctx, ds.AgentId)
+			if err != nil {
+				return err
+			}
+			if agent.Status.SystemId != "" {
+				ds.SystemId = agent.Status.SystemId
+				return nil
+			}
+
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("agent %q did not report a system ID - %w", ds.AgentId, ctx.Err())
+			case <-time.After(clientPollingIntervalMs * time.Millisecond):
+			}
+		}
+
+	case enum.OnboardingStepAcknowledgeSystem:
+		info, err := o.client.GetSystemInfo(ctx, ds.SystemId)
+		if err != nil {
+			return err
+		}
+		cfg := info.UserConfig
+		cfg.AdminState = SystemAdminStateNormal
+		return o.client.UpdateSystem(ctx, ds.SystemId, &cfg)
+
+	case enum.OnboardingStepAssignSystem:
+		err := o.bpClient.SetInterfaceMapAssignments(ctx, SystemIdToInterfaceMapAssignment{device.SystemNodeId.String(): device.InterfaceMapId})
+		if err != nil {
+			return fmt.Errorf("failed assigning interface map %q to node %q - %w", device.InterfaceMapId, device.SystemNodeId, err)
+		}
+		err = o.bpClient.PatchNode(ctx, device.SystemNodeId, &struct {
+			SystemId SystemId `json:"system_id"`
+		}{SystemId: ds.SystemId}, nil)
+		if err != nil {
+			return fmt.Errorf("failed assigning system %q to node %q - %w", ds.SystemId, device.SystemNodeId, err)
+		}
+		return nil
+
+	case enum.OnboardingStepSetDeployMode:
+		return o.bpClient.PatchNode(ctx, device.SystemNodeId, &struct {
+			DeployMode enum.DeployMode `json:"deploy_mode"`
+		}{DeployMode: *device.DeployMode}, nil)
+	}
+
+	return fmt.Errorf("unhandled onboarding step %q", step)
+}
+
+// onboardingRollback describes the undo actions for a failed device.
+type onboardingRollback struct {
+	unassignSystem    bool
+	clearInterfaceMap bool
+	deleteAgent       bool
+	deleteSystem      bool
+}
+
+// newOnboardingRollback determines which of the device's changes must be
+// undone after failedStep. The interface map is cleared whenever the assign
+// step was attempted, because the step may have failed after assigning it.
+// Agents and systems are deleted only when the agent was created by
+// onboarding: a pre-existing agent belongs to an already-managed device.
+func newOnboardingRollback(ds OnboardingDeviceState, failedStep enum.OnboardingStep) onboardingRollback {
+	assigned := ds.done(enum.OnboardingStepAssignSystem)
+	return onboardingRollback{
+		unassignSystem:    assigned,
+		clearInterfaceMap: assigned || failedStep == enum.OnboardingStepAssignSystem,
+		deleteAgent:       ds.CreatedAgent && ds.AgentId != "",
+		deleteSystem:      ds.CreatedAgent && ds.SystemId != "",
+	}
+}
+
+// rollback undoes the work of a failed onboarding: the device is removed from
+// its blueprint node, then the agent and system are deleted if onboarding
+// created them. Objects which are already gone are ignored.
+func (o *onboarder) rollback(ctx context.Context, device OnboardingDevice, ds OnboardingDeviceState, failedStep enum.OnboardingStep) error {
+	ignoreNotFound := func(err error) error {
+		var ace ClientErr
+		if errors.As(err, &ace) && ace.Type() == ErrNotfound {
+			return nil
+		}
+		return err
+	}
+
+	rb := newOnboardingRollback(ds, failedStep)
+
+	var errs []error
+	if o.bpClient != nil && rb.unassignSystem {
+		err := o.bpClient.PatchNode(ctx, device.SystemNodeId, &struct {
+			SystemId *SystemId `json:"system_id"`
+		}{}, nil)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed unassigning system from node %q - %w", device.SystemNodeId, err))
+		}
+	}
+
+	if o.bpClient != nil && rb.clearInterfaceMap {
+		err := o.bpClient.SetInterfaceMapAssignments(ctx, SystemIdToInterfaceMapAssignment{device.SystemNodeId.String(): nil})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed clearing interface map from node %q - %w", device.SystemNodeId, err))
+		}
+	}
+
+	if rb.deleteAgent {
+		err := ignoreNotFound(o.client.DeleteSystemAgent(ctx, ds.AgentId))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed deleting agent %q - %w", ds.AgentId, err))
+		}
+	}
+
+	if rb.deleteSystem {
+		err := ignoreNotFound(o.client.DeleteSystem(ctx, ds.SystemId))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed deleting system %q - %w", ds.SystemId, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// OnboardDevices runs the onboarding pipeline (create agent, install agent,
+// await connection, acknowledge system, assign system and interface map to a
+// blueprint node, set deploy mode) for each device in the request. Devices
+// are onboarded concurrently, up to req.Concurrency at a time. When
+// req.StateFile is set, progress is persisted after every step and completed
+// steps are skipped when the same request is run again. The returned state
+// reflects every device; the returned error joins the failures of all devices.
+func (o *Client) OnboardDevices(ctx context.Context, req *OnboardingRequest) (*OnboardingState, error) {
+	state := &OnboardingState{Devices: make(map[string]*OnboardingDeviceState)}
+	if req.StateFile != "" {
+		var err error
+		if state, err = LoadOnboardingState(req.StateFile); err != nil {
+			return nil, err
+		}
+	}
+
+	ob := onboarder{client: o, req: req, state: state}
+
+	for i, device := range req.Devices {
+		if device.ManagementIp == "" {
+			return nil, fmt.Errorf("device %d has no management IP", i)
+		}
+		if slices.ContainsFunc(req.Devices[:i], func(d OnboardingDevice) bool { return d.ManagementIp == device.ManagementIp }) {
+			return nil, fmt.Errorf("management IP %q appears more than once", device.ManagementIp)
+		}
+		if device.SystemNodeId != "" && ob.bpClient == nil {
+			if req.BlueprintId == "" {
+				return nil, fmt.Errorf("device %q is to be assigned to a blueprint node, but no blueprint was specified", device.ManagementIp)
+			}
+			var err error
+			if ob.bpClient, err = o.NewTwoStageL3ClosClient(ctx, req.BlueprintId); err != nil {
+				return nil, err
+			}
+		}
+		if _, ok := state.Devices[device.ManagementIp]; !ok {
+			state.Devices[device.ManagementIp] = new(OnboardingDeviceState)
+		}
+	}
+
+	concurrency := max(req.Concurrency, 1)
+	sem := make(chan struct{}, concurrency)
+	errs := make([]error, len(req.Devices))
+	var wg sync.WaitGroup
+	for i, device := range req.Devices {
+		if state.Complete(device) {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			errs[i] = ob.onboard(ctx, device)
+		}()
+	}
+	wg.Wait()
+
+	return state, errors.Join(errs...)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnboardingDevice_Steps(t *testing.T) {
+	require.Equal(t, []enum.OnboardingStep{
+		enum.OnboardingStepCreateAgent,
+		enum.OnboardingStepInstallAgent,
+		enum.OnboardingStepAwaitConnection,
+		enum.OnboardingStepAcknowledgeSystem,
+	}, OnboardingDevice{ManagementIp: "192.0.2.1", DeployMode: &enum.DeployModeDeploy}.steps())
+
+	require.Equal(t, onboardingSteps[:5], OnboardingDevice{ManagementIp: "192.0.2.1", SystemNodeId: "leaf1"}.steps())
+	require.Equal(t, onboardingSteps, OnboardingDevice{ManagementIp: "192.0.2.1", SystemNodeId: "leaf1", DeployMode: &enum.DeployModeDeploy}.steps())
+}
+
+func TestOnboardingState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "onboarding.json")
+
+	state, err := LoadOnboardingState(path)
+	require.NoError(t, err)
+	require.Empty(t, state.Devices)
+
+	device := OnboardingDevice{ManagementIp: "192.0.2.1"}
+	require.False(t, state.Complete(device))
+
+	state.Devices[device.ManagementIp] = &OnboardingDeviceState{
+		AgentId:      "agent1",
+		CreatedAgent: true,
+		SystemId:     "525400ABCDEF",
+		Completed:    onboardingSteps[:4],
+	}
+	state.Devices["192.0.2.2"] = &OnboardingDeviceState{Error: "boom", RolledBack: true}
+	require.NoError(t, state.Save(path))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, entries, 1) // no temporary files left behind
+
+	loaded, err := LoadOnboardingState(path)
+	require.NoError(t, err)
+	require.Equal(t, state, loaded)
+	require.True(t, loaded.Complete(device))
+	require.False(t, loaded.Complete(OnboardingDevice{ManagementIp: "192.0.2.1", SystemNodeId: "leaf1"}))
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, err = LoadOnboardingState(path)
+	require.ErrorContains(t, err, "failed parsing onboarding state file")
+}
+
+func TestNewOnboardingRollback(t *testing.T) {
+	type testCase struct {
+		ds         OnboardingDeviceState
+		failedStep enum.OnboardingStep
+		exp        onboardingRollback
+	}
+
+	testCases := map[string]testCase{
+		"created_agent_install_failed": {
+			ds:         OnboardingDeviceState{AgentId: "agent1", CreatedAgent: true, Completed: onboardingSteps[:1]},
+			failedStep: enum.OnboardingStepInstallAgent,
+			exp:        onboardingRollback{deleteAgent: true},
+		},
+		"existing_agent_install_failed": {
+			ds:         OnboardingDeviceState{AgentId: "agent1", Completed: onboardingSteps[:1]},
+			failedStep: enum.OnboardingStepInstallAgent,
+			exp:        onboardingRollback{},
+		},
+		"existing_agent_assign_failed": {
+			ds:         OnboardingDeviceState{AgentId: "agent1", SystemId: "525400ABCDEF", Completed: onboardingSteps[:4]},
+			failedStep: enum.OnboardingStepAssignSystem,
+			exp:        onboardingRollback{clearInterfaceMap: true},
+		},
+		"created_agent_assign_failed": {
+			ds:         OnboardingDeviceState{AgentId: "agent1", CreatedAgent: true, SystemId: "525400ABCDEF", Completed: onboardingSteps[:4]},
+			failedStep: enum.OnboardingStepAssignSystem,
+			exp:        onboardingRollback{clearInterfaceMap: true, deleteAgent: true, deleteSystem: true},
+		},
+		"created_agent_deploy_mode_failed": {
+			ds:         OnboardingDeviceState{AgentId: "agent1", CreatedAgent: true, SystemId: "525400ABCDEF", Completed: onboardingSteps[:5]},
+			failedStep: enum.OnboardingStepSetDeployMode,
+			exp:        onboardingRollback{unassignSystem: true, clearInterfaceMap: true, deleteAgent: true, deleteSystem: true},
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			require.Equal(t, tCase.exp, newOnboardingRollback(tCase.ds, tCase.failedStep))
+		})
+	}
+}
+
+func TestOnboardDevices_Validation(t *testing.T) {
+	client := new(Client)
+
+	_, err := client.OnboardDevices(t.Context(), &OnboardingRequest{Devices: []OnboardingDevice{{}}})
+	require.ErrorContains(t, err, "device 0 has no management IP")
+
+	_, err = client.OnboardDevices(t.Context(), &OnboardingRequest{Devices: []OnboardingDevice{{ManagementIp: "192.0.2.1"}, {ManagementIp: "192.0.2.1"}}})
+	require.ErrorContains(t, err, `management IP "192.0.2.1" appears more than once`)
+
+	_, err = client.OnboardDevices(t.Context(), &OnboardingRequest{Devices: []OnboardingDevice{{ManagementIp: "192.0.2.1", SystemNodeId: "leaf1"}}})
+	require.ErrorContains(t, err, "no blueprint was specified")
+
+	// nothing to do when the state file shows every step complete
+	path := filepath.Join(t.TempDir(), "onboarding.json")
+	state := OnboardingState{Devices: map[string]*OnboardingDeviceState{"192.0.2.1": {Completed: onboardingSteps}}}
+	require.NoError(t, state.Save(path))
+	result, err := client.OnboardDevices(t.Context(), &OnboardingRequest{Devices: []OnboardingDevice{{ManagementIp: "192.0.2.1"}}, StateFile: path})
+	require.NoError(t, err)
+	require.Equal(t, onboardingSteps, result.Devices["192.0.2.1"].Completed)
+}
@@ -166,6 +166,7 @@ type SystemStatus struct {
 	CommState       string                `json:"comm_state"`
 	DeviceStartTime time.Time             `json:"device_start_time"`
 	ErrorMessage    string                `json:"error_message"`
+	Hostname        string                `json:"hostname"`
 	IsAcknowledged  bool                  `json:"is_acknowledged"`
 	OperationMode   SystemManagementLevel `json:"operation_mode"`
 	State           string                `json:"state"`
@@ -176,6 +177,7 @@ type rawSystemStatus struct {
 	CommState       string                `json:"comm_state"`
 	DeviceStartTime time.Time             `json:"device_start_time"`
 	ErrorMessage    string                `json:"error_message"`
+	Hostname        string                `json:"hostname"`
 	IsAcknowledged  bool                  `json:"is_acknowledged"`
 	OperationMode   systemManagementLevel `json:"operation_mode"`
 	State           string                `json:"state"`
@@ -193,6 +195,7 @@ func (o *rawSystemStatus) polish() (*SystemStatus, error) {
 		CommState:       o.CommState,
 		DeviceStartTime: o.DeviceStartTime,
 		ErrorMessage:    o.ErrorMessage,
+		Hostname:        o.Hostname,
 		IsAcknowledged:  o.IsAcknowledged,
 		OperationMode:   SystemManagementLevel(operationMode),
 		State:           o.State,
@@ -0,0 +1,325 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+// inventoryColumns are the CSV column headings, in order.
+var inventoryColumns = []string{
+	"hostname", "serial_number", "management_ip", "vendor", "hw_model", "os_family", "os_version",
+	"system_id", "agent_id", "agent_connection_state",
+}
+
+// InventoryRecord describes one managed device. Records exported from Apstra
+// have every field populated where possible. Records in an expected inventory
+// need only a hostname or serial number; empty fields are not compared.
+type InventoryRecord struct {
+	Hostname             string   `json:"hostname,omitempty"`
+	SerialNumber         string   `json:"serial_number,omitempty"`
+	ManagementIp         string   `json:"management_ip,omitempty"`
+	Vendor               string   `json:"vendor,omitempty"`
+	HwModel              string   `json:"hw_model,omitempty"`
+	OsFamily             string   `json:"os_family,omitempty"`
+	OsVersion            string   `json:"os_version,omitempty"`
+	SystemId             SystemId `json:"system_id,omitempty"`
+	AgentId              ObjectId `json:"agent_id,omitempty"`
+	AgentConnectionState string   `json:"agent_connection_state,omitempty"`
+}
+
+func (o InventoryRecord) values() []string {
+	return []string{
+		o.Hostname, o.SerialNumber, o.ManagementIp, o.Vendor, o.HwModel, o.OsFamily, o.OsVersion,
+		string(o.SystemId), string(o.AgentId), o.AgentConnectionState,
+	}
+}
+
+func (o *InventoryRecord) setValue(column, value string) {
+	switch column {
+	case "hostname":
+		o.Hostname = value
+	case "serial_number":
+		o.SerialNumber = value
+	case "management_ip":
+		o.ManagementIp = value
+	case "vendor":
+		o.Vendor = value
+	case "hw_model":
+		o.HwModel = value
+	case "os_family":
+		o.OsFamily = value
+	case "os_version":
+		o.OsVersion = value
+	case "system_id":
+		o.SystemId = SystemId(value)
+	case "agent_id":
+		o.AgentId = ObjectId(value)
+	case "agent_connection_state":
+		o.AgentConnectionState = value
+	}
+}
+
+func (o InventoryRecord) String() string {
+	switch {
+	case o.Hostname != "" && o.SerialNumber != "":
+		return fmt.Sprintf("%q (serial %s)", o.Hostname, o.SerialNumber)
+	case o.Hostname != "":
+		return fmt.Sprintf("%q", o.Hostname)
+	case o.SerialNumber != "":
+		return "serial " + o.SerialNumber
+	}
+	return "management IP " + o.ManagementIp
+}
+
+// Inventory is a list of managed devices.
+type Inventory []InventoryRecord
+
+// NewInventory joins systems with the agents which manage them. Agents which
+// have not (yet) produced a system are included with agent fields only.
+// Records are sorted by hostname, then serial number.
+func NewInventory(systems []ManagedSystemInfo, agents []SystemAgent) Inventory {
+	agentBySystem := make(map[SystemId]SystemAgent, len(agents))
+	for _, agent := range agents {
+		if agent.Status.SystemId != "" {
+			agentBySystem[agent.Status.SystemId] = agent
+		}
+	}
+
+	result := make(Inventory, 0, len(systems))
+	for _, system := range systems {
+		record := InventoryRecord{
+			Hostname:     system.Status.Hostname,
+			SerialNumber: system.Facts.SerialNumber,
+			ManagementIp: system.Facts.MgmtIpaddr,
+			Vendor:       system.Facts.Vendor,
+			HwModel:      system.Facts.HwModel,
+			OsFamily:     system.Facts.OsFamily,
+			OsVersion:    system.Facts.OsVersion,
+			SystemId:     system.Id,
+		}
+		if record.SerialNumber == "" {
+			record.SerialNumber = string(system.Id)
+		}
+		if agent, ok := agentBySystem[system.Id]; ok {
+			record.AgentId = agent.Id
+			record.AgentConnectionState = agent.Status.ConnectionState.String()
+			record.Hostname = cmp.Or(record.Hostname, agent.Config.Label)
+			record.ManagementIp = cmp.Or(record.ManagementIp, agent.Config.ManagementIp)
+			delete(agentBySystem, system.Id)
+		}
+		result = append(result, record)
+	}
+
+	for _, agent := range agents {
+		if _, ok := agentBySystem[agent.Status.SystemId]; agent.Status.SystemId != "" && !ok {
+			continue // already joined to a system
+		}
+		result = append(result, InventoryRecord{
+			Hostname:             agent.Config.Label,
+			ManagementIp:         agent.Config.ManagementIp,
+			SystemId:             agent.Status.SystemId,
+			AgentId:              agent.Id,
+			AgentConnectionState: agent.Status.ConnectionState.String(),
+		})
+	}
+
+	slices.SortStableFunc(result, func(a, b InventoryRecord) int {
+		return cmp.Or(strings.Compare(a.Hostname, b.Hostname), strings.Compare(a.SerialNumber, b.SerialNumber))
+	})
+
+	return result
+}
+
+// WriteCSV writes the inventory as CSV with a heading row.
+func (o Inventory) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	err := cw.Write(inventoryColumns)
+	if err != nil {
+		return err
+	}
+	for _, record := range o {
+		err = cw.Write(record.values())
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the inventory as a JSON array.
+func (o Inventory) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(o)
+}
+
+// ReadInventoryCSV reads an inventory from CSV. The first row must name the
+// columns (see InventoryRecord JSON tags for column names); columns may appear
+// in any order and unknown columns are ignored.
+func ReadInventoryCSV(r io.Reader) (Inventory, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	heading, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed reading inventory heading - %w", err)
+	}
+	for i := range heading {
+		heading[i] = strings.ToLower(strings.TrimSpace(heading[i]))
+	}
+	if !slices.Contains(heading, "hostname") && !slices.Contains(heading, "serial_number") {
+		return nil, errors.New("inventory must have a hostname or serial_number column")
+	}
+
+	var result Inventory
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed reading inventory - %w", err)
+		}
+
+		var record InventoryRecord
+		for i, value := range row {
+			record.setValue(heading[i], strings.TrimSpace(value))
+		}
+		result = append(result, record)
+	}
+}
+
+// ReadInventoryJSON reads an inventory from a JSON array.
+func ReadInventoryJSON(r io.Reader) (Inventory, error) {
+	var result Inventory
+	err := json.NewDecoder(r).Decode(&result)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing inventory - %w", err)
+	}
+	return result, nil
+}
+
+// InventoryFinding describes one difference between the expected and actual
+// inventories.
+type InventoryFinding struct {
+	Issue    enum.InventoryIssue
+	Record   InventoryRecord // the actual record, or the expected record when missing
+	Expected string
+	Actual   string
+}
+
+func (o InventoryFinding) String() string {
+	switch o.Issue {
+	case enum.InventoryIssueMissing:
+		return fmt.Sprintf("device %s is missing", o.Record)
+	case enum.InventoryIssueUnexpected:
+		return fmt.Sprintf("device %s is not in the expected inventory", o.Record)
+	case enum.InventoryIssueOsVersionMismatch:
+		return fmt.Sprintf("device %s runs OS version %q, expected %q", o.Record, o.Actual, o.Expected)
+	case enum.InventoryIssueHostnameMismatch:
+		return fmt.Sprintf("serial %s is attached to hostname %q, expected %q", o.Record.SerialNumber, o.Actual, o.Expected)
+	case enum.InventoryIssueAgentState:
+		return fmt.Sprintf("agent %q of device %s is %s", o.Record.AgentId, o.Record, o.Actual)
+	}
+	return fmt.Sprintf("device %s: %s", o.Record, o.Issue)
+}
+
+// ReconcileInventory compares the actual inventory with the expected one.
+// Expected records are matched to actual records by serial number, or by
+// hostname (then management IP) when the expected record has no serial number.
+// Agents whose connection state is neither connected nor empty (onbox agents
+// do not connect) are reported regardless of the expected inventory.
+func ReconcileInventory(expected, actual Inventory) []InventoryFinding {
+	var result []InventoryFinding
+	matched := make([]bool, len(actual))
+
+	find := func(match func(InventoryRecord) bool) int {
+		for i, a := range actual {
+			if !matched[i] && match(a) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for _, e := range expected {
+		i := -1
+		switch {
+		case e.SerialNumber != "":
+			i = find(func(a InventoryRecord) bool { return strings.EqualFold(a.SerialNumber, e.SerialNumber) })
+		case e.Hostname != "":
+			i = find(func(a InventoryRecord) bool { return a.Hostname == e.Hostname })
+		case e.ManagementIp != "":
+			i = find(func(a InventoryRecord) bool { return a.ManagementIp == e.ManagementIp })
+		}
+		if i < 0 {
+			result = append(result, InventoryFinding{Issue: enum.InventoryIssueMissing, Record: e})
+			continue
+		}
+		matched[i] = true
+		a := actual[i]
+
+		if e.SerialNumber != "" && e.Hostname != "" && a.Hostname != e.Hostname {
+			result = append(result, InventoryFinding{Issue: enum.InventoryIssueHostnameMismatch, Record: a, Expected: e.Hostname, Actual: a.Hostname})
+		}
+		if e.OsVersion != "" && a.OsVersion != e.OsVersion {
+			result = append(result, InventoryFinding{Issue: enum.InventoryIssueOsVersionMismatch, Record: a, Expected: e.OsVersion, Actual: a.OsVersion})
+		}
+	}
+
+	for i, a := range actual {
+		if !matched[i] {
+			result = append(result, InventoryFinding{Issue: enum.InventoryIssueUnexpected, Record: a})
+		}
+	}
+
+	for _, a := range actual {
+		switch a.AgentConnectionState {
+		case "", AgentCxnStateConnected.String():
+		default:
+			result = append(result, InventoryFinding{Issue: enum.InventoryIssueAgentState, Record: a, Actual: a.AgentConnectionState})
+		}
+	}
+
+	return result
+}
+
+// GetInventory returns every managed system joined with its agent.
+func (o *Client) GetInventory(ctx context.Context) (Inventory, error) {
+	systems, err := o.GetAllSystemsInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching systems - %w", err)
+	}
+
+	agents, err := o.GetAllSystemAgents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching system agents - %w", err)
+	}
+
+	return NewInventory(systems, agents), nil
+}
+
+// ReconcileInventory compares the managed systems and agents in Apstra with
+// an expected inventory.
+func (o *Client) ReconcileInventory(ctx context.Context, expected Inventory) ([]InventoryFinding, error) {
+	actual, err := o.GetInventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReconcileInventory(expected, actual), nil
+}
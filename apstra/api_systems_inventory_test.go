@@ -0,0 +1,109 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInventory(t *testing.T) {
+	systems := []ManagedSystemInfo{
+		{
+			Id:     "SN1",
+			Facts:  SystemFacts{SerialNumber: "SN1", MgmtIpaddr: "10.0.0.1", OsVersion: "22.4R1", Vendor: "Juniper"},
+			Status: SystemStatus{Hostname: "leaf1"},
+		},
+		{
+			Id:    "SN2",
+			Facts: SystemFacts{SerialNumber: "SN2", OsVersion: "22.4R1"},
+		},
+	}
+	agents := []SystemAgent{
+		{
+			Id:     "agent1",
+			Config: SystemAgentConfig{Label: "ignored", ManagementIp: "10.0.0.1"},
+			Status: AgentStatus{SystemId: "SN1", ConnectionState: AgentCxnStateConnected},
+		},
+		{
+			Id:     "agent2",
+			Config: SystemAgentConfig{Label: "leaf2", ManagementIp: "10.0.0.2"},
+			Status: AgentStatus{SystemId: "SN2", ConnectionState: AgentCxnStateDisconnected},
+		},
+		{
+			Id:     "agent3",
+			Config: SystemAgentConfig{Label: "leaf3", ManagementIp: "10.0.0.3"},
+			Status: AgentStatus{ConnectionState: AgentCxnStateAuthFail},
+		},
+	}
+
+	expected := Inventory{
+		{Hostname: "leaf1", SerialNumber: "SN1", ManagementIp: "10.0.0.1", Vendor: "Juniper", OsVersion: "22.4R1", SystemId: "SN1", AgentId: "agent1", AgentConnectionState: "connected"},
+		{Hostname: "leaf2", SerialNumber: "SN2", ManagementIp: "10.0.0.2", OsVersion: "22.4R1", SystemId: "SN2", AgentId: "agent2", AgentConnectionState: "disconnected"},
+		{Hostname: "leaf3", ManagementIp: "10.0.0.3", AgentId: "agent3", AgentConnectionState: "auth_failed"},
+	}
+
+	require.Equal(t, expected, NewInventory(systems, agents))
+}
+
+func TestInventoryCSVAndJSON(t *testing.T) {
+	inventory := Inventory{
+		{Hostname: "leaf1", SerialNumber: "SN1", ManagementIp: "10.0.0.1", OsVersion: "22.4R1", SystemId: "SN1", AgentId: "agent1", AgentConnectionState: "connected"},
+		{Hostname: "leaf2", SerialNumber: "SN2"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, inventory.WriteCSV(&buf))
+	result, err := ReadInventoryCSV(&buf)
+	require.NoError(t, err)
+	require.Equal(t, inventory, result)
+
+	buf.Reset()
+	require.NoError(t, inventory.WriteJSON(&buf))
+	result, err = ReadInventoryJSON(&buf)
+	require.NoError(t, err)
+	require.Equal(t, inventory, result)
+
+	// hand-written files may reorder, omit and add columns
+	result, err = ReadInventoryCSV(strings.NewReader("Serial_Number, site, hostname\nSN1, east, leaf1\n"))
+	require.NoError(t, err)
+	require.Equal(t, Inventory{{Hostname: "leaf1", SerialNumber: "SN1"}}, result)
+
+	_, err = ReadInventoryCSV(strings.NewReader("site,os_version\neast,22.4R1\n"))
+	require.Error(t, err)
+}
+
+func TestReconcileInventory(t *testing.T) {
+	expected := Inventory{
+		{Hostname: "leaf1", SerialNumber: "SN1", OsVersion: "22.4R1"}, // matches
+		{Hostname: "leaf2", SerialNumber: "SN2", OsVersion: "22.4R1"}, // wrong hostname and OS version
+		{Hostname: "leaf3"},                      // matched by hostname, agent disconnected
+		{Hostname: "leaf4", SerialNumber: "SN4"}, // missing
+		{ManagementIp: "10.0.0.5"},               // matched by management IP
+	}
+	actual := Inventory{
+		{Hostname: "leaf1", SerialNumber: "sn1", OsVersion: "22.4R1", AgentConnectionState: "connected"},
+		{Hostname: "leaf9", SerialNumber: "SN2", OsVersion: "23.2R1", AgentConnectionState: "connected"},
+		{Hostname: "leaf3", SerialNumber: "SN3", AgentId: "agent3", AgentConnectionState: "disconnected"},
+		{Hostname: "leaf5", SerialNumber: "SN5", ManagementIp: "10.0.0.5"},
+		{Hostname: "leaf6", SerialNumber: "SN6", AgentConnectionState: "connected"}, // unexpected
+	}
+
+	result := ReconcileInventory(expected, actual)
+	require.Equal(t, []InventoryFinding{
+		{Issue: enum.InventoryIssueHostnameMismatch, Record: actual[1], Expected: "leaf2", Actual: "leaf9"},
+		{Issue: enum.InventoryIssueOsVersionMismatch, Record: actual[1], Expected: "22.4R1", Actual: "23.2R1"},
+		{Issue: enum.InventoryIssueMissing, Record: expected[3]},
+		{Issue: enum.InventoryIssueUnexpected, Record: actual[4]},
+		{Issue: enum.InventoryIssueAgentState, Record: actual[2], Actual: "disconnected"},
+	}, result)
+
+	require.Equal(t, `serial SN2 is attached to hostname "leaf9", expected "leaf2"`, result[0].String())
+	require.Equal(t, `device "leaf4" (serial SN4) is missing`, result[2].String())
+}
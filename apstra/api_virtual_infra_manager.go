@@ -6,12 +6,17 @@ package apstra
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
 )
 
 const (
-	apiUrlVirtualInfraManagers = "/api/virtual-infra-managers"
+	apiUrlVirtualInfraManagers       = "/api/virtual-infra-managers"
+	apiUrlVirtualInfraManagersPrefix = apiUrlVirtualInfraManagers + apiUrlPathDelim
+	apiUrlVirtualInfraManagerById    = apiUrlVirtualInfraManagersPrefix + "%s"
 )
 
 type virtualInfraMgrsResponse struct {
@@ -28,6 +33,15 @@ type VirtualInfraMgrInfo struct {
 	VirtualInfraType             string    `json:"virtual_infra_type"`
 }
 
+// VirtualInfraMgrRequest is used to create or update a Virtual Infrastructure
+// Manager (vCenter or NSX-T). Password is write-only: Apstra never returns it.
+type VirtualInfraMgrRequest struct {
+	ManagementIp     string                `json:"management_ip"`
+	VirtualInfraType enum.VirtualInfraType `json:"virtual_infra_type"`
+	Username         string                `json:"username"`
+	Password         string                `json:"password"`
+}
+
 func (o *Client) getVirtualInfraMgrs(ctx context.Context) ([]VirtualInfraMgrInfo, error) {
 	response := &virtualInfraMgrsResponse{}
 	err := o.talkToApstra(ctx, &talkToApstraIn{
@@ -36,8 +50,62 @@ func (o *Client) getVirtualInfraMgrs(ctx context.Context) ([]VirtualInfraMgrInfo
 		apiResponse: response,
 	})
 	if err != nil {
-		return nil, err
+		return nil, convertTtaeToAceWherePossible(err)
 	}
 
 	return response.Items, err
 }
+
+func (o *Client) getVirtualInfraMgr(ctx context.Context, id ObjectId) (*VirtualInfraMgrInfo, error) {
+	response := &VirtualInfraMgrInfo{}
+	err := o.talkToApstra(ctx, &talkToApstraIn{
+		method:      http.MethodGet,
+		urlStr:      fmt.Sprintf(apiUrlVirtualInfraManagerById, id),
+		apiResponse: response,
+	})
+	if err != nil {
+		return nil, convertTtaeToAceWherePossible(err)
+	}
+
+	return response, nil
+}
+
+func (o *Client) createVirtualInfraMgr(ctx context.Context, in *VirtualInfraMgrRequest) (ObjectId, error) {
+	response := &objectIdResponse{}
+	err := o.talkToApstra(ctx, &talkToApstraIn{
+		method:      http.MethodPost,
+		urlStr:      apiUrlVirtualInfraManagers,
+		apiInput:    in,
+		apiResponse: response,
+	})
+	if err != nil {
+		return "", convertTtaeToAceWherePossible(err)
+	}
+
+	return response.Id, nil
+}
+
+func (o *Client) updateVirtualInfraMgr(ctx context.Context, id ObjectId, in *VirtualInfraMgrRequest) error {
+	err := o.talkToApstra(ctx, &talkToApstraIn{
+		method:   http.MethodPut,
+		urlStr:   fmt.Sprintf(apiUrlVirtualInfraManagerById, id),
+		apiInput: in,
+	})
+	if err != nil {
+		return convertTtaeToAceWherePossible(err)
+	}
+
+	return nil
+}
+
+func (o *Client) deleteVirtualInfraMgr(ctx context.Context, id ObjectId) error {
+	err := o.talkToApstra(ctx, &talkToApstraIn{
+		method: http.MethodDelete,
+		urlStr: fmt.Sprintf(apiUrlVirtualInfraManagerById, id),
+	})
+	if err != nil {
+		return convertTtaeToAceWherePossible(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Juniper/apstra-go-sdk/design"
+	"github.com/Juniper/apstra-go-sdk/device"
+)
+
+// catalogCacheURLs are the collections eligible for read-through caching.
+// Each collection caches its own responses and those of the objects within.
+var catalogCacheURLs = []string{
+	design.ConfigTemplatesURL,
+	design.ConfigletsURL,
+	design.InterfaceMapDigestsURL,
+	design.InterfaceMapsURL,
+	design.LogicalDevicesURL,
+	design.RackTypesURL,
+	design.TagsURL,
+	design.TemplatesURL,
+	device.ProfilesURL,
+}
+
+// catalogCacheDependents maps collection URLs to the other collections which
+// are derived from them. Writes to the key collection invalidate the others.
+var catalogCacheDependents = map[string][]string{
+	design.InterfaceMapsURL:  {design.InterfaceMapDigestsURL},
+	design.LogicalDevicesURL: {design.InterfaceMapDigestsURL},
+	device.ProfilesURL:       {design.InterfaceMapDigestsURL},
+}
+
+// CatalogCacheCfg configures the opt-in read-through cache for design catalog
+// objects and device profiles. See Client.EnableCatalogCache.
+type CatalogCacheCfg struct {
+	TTL          time.Duration            // zero means entries do not expire
+	MaxEntries   int                      // zero means no limit; least-recently-used entries are evicted first
+	TTLOverrides map[string]time.Duration // per-collection TTL keyed by collection URL, e.g. device.ProfilesURL
+}
+
+// CatalogCacheStats are the counters maintained by the catalog cache.
+type CatalogCacheStats struct {
+	Hits          int
+	Misses        int
+	Evictions     int // entries removed to honor MaxEntries
+	Expirations   int // entries found to have outlived their TTL
+	Invalidations int // entries removed by writes or by explicit invalidation
+	Entries       int // current entry count
+	ByURL         map[string]CatalogCacheStats
+}
+
+type catalogCacheEntry struct {
+	key        string
+	collection string
+	data       json.RawMessage
+	expires    time.Time // zero value means no expiration
+}
+
+type catalogCache struct {
+	cfg     CatalogCacheCfg
+	lock    sync.Mutex
+	entries map[string]*list.Element // values are *catalogCacheEntry
+	lru     *list.List               // front is most recently used
+	stats   map[string]*CatalogCacheStats
+	now     func() time.Time
+}
+
+func newCatalogCache(cfg CatalogCacheCfg) *catalogCache {
+	result := catalogCache{
+		cfg:     cfg,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		stats:   make(map[string]*CatalogCacheStats, len(catalogCacheURLs)),
+		now:     time.Now,
+	}
+
+	for _, u := range catalogCacheURLs {
+		result.stats[u] = new(CatalogCacheStats)
+	}
+
+	return &result
+}
+
+// EnableCatalogCache turns on read-through caching of design catalog objects
+// (logical devices, rack types, templates, interface maps and their digests,
+// tags, configlets, config templates) and device profiles. Cached objects are
+// invalidated whenever this Client creates, updates or deletes an object of
+// the same type. Changes made by other clients are not detected, so choose a
+// TTL accordingly. Any previously cached data is discarded.
+//
+// EnableCatalogCache should be called before the Client is shared between
+// goroutines.
+func (o *Client) EnableCatalogCache(cfg CatalogCacheCfg) {
+	o.catalogCache = newCatalogCache(cfg)
+}
+
+// DisableCatalogCache turns off catalog caching and discards cached data.
+func (o *Client) DisableCatalogCache() {
+	o.catalogCache = nil
+}
+
+// InvalidateCatalogCache discards cached data for the given collection URLs
+// (e.g. design.LogicalDevicesURL), or for all collections when none are
+// specified. It is a no-op when the cache is not enabled.
+func (o *Client) InvalidateCatalogCache(collectionURLs ...string) {
+	if o.catalogCache == nil {
+		return
+	}
+
+	if len(collectionURLs) == 0 {
+		collectionURLs = catalogCacheURLs
+	}
+
+	o.catalogCache.invalidate(collectionURLs...)
+}
+
+// CatalogCacheStats returns the catalog cache counters. The zero value is
+// returned when the cache is not enabled.
+func (o *Client) CatalogCacheStats() CatalogCacheStats {
+	if o.catalogCache == nil {
+		return CatalogCacheStats{}
+	}
+
+	return o.catalogCache.getStats()
+}
+
+// talkToApstra serves cacheable reads from the cache, populates the cache on
+// misses, and invalidates cached data when a write touches a cached
+// collection. Everything else is passed through to next.
+func (o *catalogCache) talkToApstra(ctx context.Context, in *talkToApstraIn, next func(context.Context, *talkToApstraIn) error) error {
+	if in.httpBodyWriter != nil {
+		return next(ctx, in)
+	}
+
+	u := in.url
+	if u == nil {
+		var err error
+		u, err = url.Parse(in.urlStr)
+		if err != nil {
+			return next(ctx, in) // let the normal path deal with the bad URL
+		}
+	}
+
+	collection := catalogCacheCollection(u.Path)
+	if collection == "" {
+		return next(ctx, in)
+	}
+
+	switch in.method {
+	case http.MethodGet, http.MethodOptions:
+	default:
+		// a write - invalidate affected collections once it's done
+		defer o.invalidate(append([]string{collection}, catalogCacheDependents[collection]...)...)
+		return next(ctx, in)
+	}
+
+	if in.apiResponse == nil {
+		return next(ctx, in)
+	}
+
+	key := in.method + " " + u.Path
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+
+	if data, ok := o.get(key, collection); ok {
+		return json.Unmarshal(data, in.apiResponse)
+	}
+
+	// miss - collect the raw response on behalf of the caller
+	var raw json.RawMessage
+	apiResponse := in.apiResponse
+	in.apiResponse = &raw
+	err := next(ctx, in)
+	in.apiResponse = apiResponse
+	if err != nil {
+		return err
+	}
+
+	o.put(key, collection, raw)
+
+	return json.Unmarshal(raw, in.apiResponse)
+}
+
+// catalogCacheCollection returns the cacheable collection URL which contains
+// path, or an empty string.
+func catalogCacheCollection(path string) string {
+	path = strings.TrimSuffix(path, "/")
+	for _, u := range catalogCacheURLs {
+		if path == u || strings.HasPrefix(path, u+"/") {
+			return u
+		}
+	}
+	return ""
+}
+
+func (o *catalogCache) get(key, collection string) (json.RawMessage, bool) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	stats := o.stats[collection]
+
+	element, ok := o.entries[key]
+	if !ok {
+		stats.Misses++
+		return nil, false
+	}
+
+	entry := element.Value.(*catalogCacheEntry)
+	if !entry.expires.IsZero() && !o.now().Before(entry.expires) {
+		o.remove(element)
+		stats.Expirations++
+		stats.Misses++
+		return nil, false
+	}
+
+	o.lru.MoveToFront(element)
+	stats.Hits++
+	return entry.data, true
+}
+
+func (o *catalogCache) put(key, collection string, data json.RawMessage) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	ttl := o.cfg.TTL
+	if override, ok := o.cfg.TTLOverrides[collection]; ok {
+		ttl = override
+	}
+
+	entry := catalogCacheEntry{
+		key:        key,
+		collection: collection,
+		data:       data,
+	}
+	if ttl > 0 {
+		entry.expires = o.now().Add(ttl)
+	}
+
+	if element, ok := o.entries[key]; ok {
+		element.Value = &entry
+		o.lru.MoveToFront(element)
+		return
+	}
+
+	o.entries[key] = o.lru.PushFront(&entry)
+	o.stats[collection].Entries++
+
+	for o.cfg.MaxEntries > 0 && o.lru.Len() > o.cfg.MaxEntries {
+		oldest := o.lru.Back()
+		o.stats[oldest.Value.(*catalogCacheEntry).collection].Evictions++
+		o.remove(oldest)
+	}
+}
+
+func (o *catalogCache) invalidate(collections ...string) {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	invalid := make(map[string]bool, len(collections))
+	for _, collection := range collections {
+		invalid[collection] = true
+	}
+
+	var next *list.Element
+	for element := o.lru.Front(); element != nil; element = next {
+		next = element.Next()
+		entry := element.Value.(*catalogCacheEntry)
+		if invalid[entry.collection] {
+			o.stats[entry.collection].Invalidations++
+			o.remove(element)
+		}
+	}
+}
+
+// remove drops an entry from the cache. The caller must hold the lock.
+func (o *catalogCache) remove(element *list.Element) {
+	entry := o.lru.Remove(element).(*catalogCacheEntry)
+	delete(o.entries, entry.key)
+	o.stats[entry.collection].Entries--
+}
+
+func (o *catalogCache) getStats() CatalogCacheStats {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+
+	result := CatalogCacheStats{ByURL: make(map[string]CatalogCacheStats, len(o.stats))}
+	for collection, stats := range o.stats {
+		result.ByURL[collection] = *stats
+		result.Hits += stats.Hits
+		result.Misses += stats.Misses
+		result.Evictions += stats.Evictions
+		result.Expirations += stats.Expirations
+		result.Invalidations += stats.Invalidations
+		result.Entries += stats.Entries
+	}
+
+	return result
+}
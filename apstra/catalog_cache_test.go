@@ -0,0 +1,188 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Juniper/apstra-go-sdk/design"
+	"github.com/Juniper/apstra-go-sdk/device"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCatalogServer answers every request with a JSON object containing the
+// request URL and a serial number, so cached responses are recognizable.
+type fakeCatalogServer struct {
+	calls int
+}
+
+func (o *fakeCatalogServer) talkToApstra(_ context.Context, in *talkToApstraIn) error {
+	o.calls++
+	if in.apiResponse == nil {
+		return nil
+	}
+	return json.Unmarshal([]byte(fmt.Sprintf(`{"url":%q,"serial":%d}`, in.urlStr, o.calls)), in.apiResponse)
+}
+
+type fakeCatalogResponse struct {
+	URL    string `json:"url"`
+	Serial int    `json:"serial"`
+}
+
+func TestCatalogCache(t *testing.T) {
+	ctx := context.Background()
+	ldURL := fmt.Sprintf(design.LogicalDeviceURLByID, "ld1")
+	dpURL := fmt.Sprintf(device.ProfileURLByID, "dp1")
+
+	get := func(t *testing.T, cache *catalogCache, server *fakeCatalogServer, urlStr string) int {
+		t.Helper()
+		var response fakeCatalogResponse
+		err := cache.talkToApstra(ctx, &talkToApstraIn{method: http.MethodGet, urlStr: urlStr, apiResponse: &response}, server.talkToApstra)
+		require.NoError(t, err)
+		require.Equal(t, urlStr, response.URL)
+		return response.Serial
+	}
+
+	write := func(t *testing.T, cache *catalogCache, server *fakeCatalogServer, method, urlStr string) {
+		t.Helper()
+		err := cache.talkToApstra(ctx, &talkToApstraIn{method: method, urlStr: urlStr}, server.talkToApstra)
+		require.NoError(t, err)
+	}
+
+	t.Run("hit_and_miss", func(t *testing.T) {
+		t.Parallel()
+		cache := newCatalogCache(CatalogCacheCfg{})
+		server := new(fakeCatalogServer)
+
+		require.Equal(t, 1, get(t, cache, server, ldURL))
+		require.Equal(t, 1, get(t, cache, server, ldURL))
+		require.Equal(t, 2, get(t, cache, server, design.LogicalDevicesURL))
+		require.Equal(t, 2, server.calls)
+
+		stats := cache.getStats()
+		require.Equal(t, 1, stats.Hits)
+		require.Equal(t, 2, stats.Misses)
+		require.Equal(t, 2, stats.Entries)
+		require.Equal(t, 2, stats.ByURL[design.LogicalDevicesURL].Entries)
+		require.Equal(t, 0, stats.ByURL[design.TagsURL].Entries)
+	})
+
+	t.Run("uncacheable_url", func(t *testing.T) {
+		t.Parallel()
+		cache := newCatalogCache(CatalogCacheCfg{})
+		server := new(fakeCatalogServer)
+
+		require.Equal(t, 1, get(t, cache, server, "/api/blueprints"))
+		require.Equal(t, 2, get(t, cache, server, "/api/blueprints"))
+		require.Equal(t, 0, cache.getStats().Misses)
+	})
+
+	t.Run("write_invalidates_collection_and_dependents", func(t *testing.T) {
+		t.Parallel()
+		cache := newCatalogCache(CatalogCacheCfg{})
+		server := new(fakeCatalogServer)
+
+		get(t, cache, server, ldURL)
+		get(t, cache, server, design.InterfaceMapDigestsURL)
+		get(t, cache, server, design.TagsURL)
+		require.Equal(t, 3, server.calls)
+
+		write(t, cache, server, http.MethodPut, fmt.Sprintf(design.LogicalDeviceURLByID, "ld2"))
+		require.Equal(t, 4, server.calls)
+
+		require.Equal(t, 5, get(t, cache, server, ldURL))                         // invalidated
+		require.Equal(t, 6, get(t, cache, server, design.InterfaceMapDigestsURL)) // dependent invalidated
+		require.Equal(t, 3, get(t, cache, server, design.TagsURL))                // untouched
+
+		stats := cache.getStats()
+		require.Equal(t, 2, stats.Invalidations)
+	})
+
+	t.Run("ttl", func(t *testing.T) {
+		t.Parallel()
+		cache := newCatalogCache(CatalogCacheCfg{
+			TTL:          time.Minute,
+			TTLOverrides: map[string]time.Duration{device.ProfilesURL: time.Hour},
+		})
+		server := new(fakeCatalogServer)
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+
+		require.Equal(t, 1, get(t, cache, server, ldURL))
+		require.Equal(t, 2, get(t, cache, server, dpURL))
+
+		now = now.Add(2 * time.Minute)
+		require.Equal(t, 3, get(t, cache, server, ldURL)) // expired
+		require.Equal(t, 2, get(t, cache, server, dpURL)) // override keeps it fresh
+
+		now = now.Add(2 * time.Hour)
+		require.Equal(t, 4, get(t, cache, server, dpURL)) // finally expired
+
+		require.Equal(t, 2, cache.getStats().Expirations)
+	})
+
+	t.Run("max_entries", func(t *testing.T) {
+		t.Parallel()
+		cache := newCatalogCache(CatalogCacheCfg{MaxEntries: 2})
+		server := new(fakeCatalogServer)
+
+		tag := func(id string) string { return fmt.Sprintf(design.TagURLByID, id) }
+
+		require.Equal(t, 1, get(t, cache, server, tag("a")))
+		require.Equal(t, 2, get(t, cache, server, tag("b")))
+		require.Equal(t, 1, get(t, cache, server, tag("a"))) // "a" is now most recently used
+		require.Equal(t, 3, get(t, cache, server, tag("c"))) // evicts "b"
+		require.Equal(t, 1, get(t, cache, server, tag("a")))
+		require.Equal(t, 4, get(t, cache, server, tag("b")))
+
+		stats := cache.getStats()
+		require.Equal(t, 2, stats.Entries)
+		require.Equal(t, 2, stats.Evictions)
+	})
+
+	t.Run("explicit_invalidation", func(t *testing.T) {
+		t.Parallel()
+		client := Client{}
+		client.EnableCatalogCache(CatalogCacheCfg{})
+		server := new(fakeCatalogServer)
+
+		get(t, client.catalogCache, server, ldURL)
+		get(t, client.catalogCache, server, dpURL)
+
+		client.InvalidateCatalogCache(device.ProfilesURL)
+		require.Equal(t, 1, client.CatalogCacheStats().Entries)
+
+		client.InvalidateCatalogCache()
+		require.Equal(t, 0, client.CatalogCacheStats().Entries)
+
+		client.DisableCatalogCache()
+		require.Equal(t, CatalogCacheStats{}, client.CatalogCacheStats())
+	})
+}
+
+func TestCatalogCacheCollection(t *testing.T) {
+	testCases := map[string]string{
+		design.InterfaceMapsURL:                               design.InterfaceMapsURL,
+		design.InterfaceMapsURL + "/":                         design.InterfaceMapsURL,
+		fmt.Sprintf(design.InterfaceMapURLByID, "x"):          design.InterfaceMapsURL,
+		fmt.Sprintf(design.InterfaceMapDigestURLByID, "x"):    design.InterfaceMapDigestsURL,
+		fmt.Sprintf(device.ProfileURLByID, "x"):               device.ProfilesURL,
+		"/api/design/interface-maps-and-then-some":            "",
+		"/api/blueprints/x/nodes":                             "",
+		fmt.Sprintf(design.TemplateURLByID, "x") + "/subpath": design.TemplatesURL,
+	}
+
+	for path, expected := range testCases {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, expected, catalogCacheCollection(path))
+		})
+	}
+}
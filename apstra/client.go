@@ -166,6 +166,8 @@ type Client struct {
 	mutexMap    mutexmap.MutexMap        // some client operations are not concurrency safe. Their mutexes live here.
 	features    map[enum.ApiFeature]bool // true/false indicate feature enabled/disabled status
 	skipGzip    bool                     // prevents setting 'Accept-Encoding: gzip' - only implemented for api-ops proxy
+
+	catalogCache *catalogCache // opt-in read-through cache, nil when disabled
 }
 
 // GetTuningParam returns a named timer value from the client configuration if one has been configured.
@@ -505,6 +507,29 @@ func (o *Client) GetVirtualInfraMgrs(ctx context.Context) ([]VirtualInfraMgrInfo
 	return o.getVirtualInfraMgrs(ctx)
 }
 
+// GetVirtualInfraMgr returns the Virtual Infrastructure Manager identified by
+// id (its SystemId)
+func (o *Client) GetVirtualInfraMgr(ctx context.Context, id ObjectId) (*VirtualInfraMgrInfo, error) {
+	return o.getVirtualInfraMgr(ctx, id)
+}
+
+// CreateVirtualInfraMgr adds a vCenter or NSX-T Virtual Infrastructure Manager
+// to Apstra and returns its ID
+func (o *Client) CreateVirtualInfraMgr(ctx context.Context, in *VirtualInfraMgrRequest) (ObjectId, error) {
+	return o.createVirtualInfraMgr(ctx, in)
+}
+
+// UpdateVirtualInfraMgr updates the address and credentials of the specified
+// Virtual Infrastructure Manager
+func (o *Client) UpdateVirtualInfraMgr(ctx context.Context, id ObjectId, in *VirtualInfraMgrRequest) error {
+	return o.updateVirtualInfraMgr(ctx, id, in)
+}
+
+// DeleteVirtualInfraMgr deletes the specified Virtual Infrastructure Manager
+func (o *Client) DeleteVirtualInfraMgr(ctx context.Context, id ObjectId) error {
+	return o.deleteVirtualInfraMgr(ctx, id)
+}
+
 // GetMetricdbMetrics returns []MetricdbMetric representing the various metricdb
 // application/namespace/name paths available to be queried from Apstra
 func (o *Client) GetMetricdbMetrics(ctx context.Context) ([]MetricdbMetric, error) {
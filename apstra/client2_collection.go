@@ -0,0 +1,369 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"reflect"
+
+	"github.com/Juniper/apstra-go-sdk/design"
+	"github.com/Juniper/apstra-go-sdk/device"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	sdkerrors "github.com/Juniper/apstra-go-sdk/errors"
+	"github.com/Juniper/apstra-go-sdk/internal"
+)
+
+// ReadOnlyCollection provides uniform read access to a catalog of objects of
+// type T found at a single API URL. Objects within the collection are found at
+// "<url>/<id>". T must have an exported string field named "Label".
+//
+// When T is a design.Template, the collection is limited to templates of
+// T's template type.
+type ReadOnlyCollection[T internal.IDer] struct {
+	client       *Client
+	url          string
+	templateType *enum.TemplateType
+}
+
+// NewReadOnlyCollection returns a ReadOnlyCollection of objects of type T
+// found at urlStr.
+func NewReadOnlyCollection[T internal.IDer](client *Client, urlStr string) ReadOnlyCollection[T] {
+	result := ReadOnlyCollection[T]{
+		client: client,
+		url:    urlStr,
+	}
+
+	if t, ok := any(result.zero()).(design.Template); ok {
+		templateType := t.TemplateType()
+		result.templateType = &templateType
+	}
+
+	return result
+}
+
+// Collection extends ReadOnlyCollection with Create, Update and Delete.
+type Collection[T internal.IDer] struct {
+	ReadOnlyCollection[T]
+}
+
+// NewCollection returns a Collection of objects of type T found at urlStr.
+func NewCollection[T internal.IDer](client *Client, urlStr string) Collection[T] {
+	return Collection[T]{ReadOnlyCollection: NewReadOnlyCollection[T](client, urlStr)}
+}
+
+func (c *Client) ConfigTemplateCollection() Collection[design.ConfigTemplate] {
+	return NewCollection[design.ConfigTemplate](c, design.ConfigTemplatesURL)
+}
+
+func (c *Client) ConfigletCollection() Collection[design.Configlet] {
+	return NewCollection[design.Configlet](c, design.ConfigletsURL)
+}
+
+func (c *Client) DeviceProfileCollection() Collection[device.Profile] {
+	return NewCollection[device.Profile](c, device.ProfilesURL)
+}
+
+func (c *Client) InterfaceMapCollection() Collection[design.InterfaceMap] {
+	return NewCollection[design.InterfaceMap](c, design.InterfaceMapsURL)
+}
+
+func (c *Client) InterfaceMapDigestCollection() ReadOnlyCollection[design.InterfaceMapDigest] {
+	return NewReadOnlyCollection[design.InterfaceMapDigest](c, design.InterfaceMapDigestsURL)
+}
+
+func (c *Client) LogicalDeviceCollection() Collection[design.LogicalDevice] {
+	return NewCollection[design.LogicalDevice](c, design.LogicalDevicesURL)
+}
+
+func (c *Client) RackTypeCollection() Collection[design.RackType] {
+	return NewCollection[design.RackType](c, design.RackTypesURL)
+}
+
+func (c *Client) TagCollection() Collection[design.Tag] {
+	return NewCollection[design.Tag](c, design.TagsURL)
+}
+
+func (c *Client) TemplateL3CollapsedCollection() Collection[design.TemplateL3Collapsed] {
+	return NewCollection[design.TemplateL3Collapsed](c, design.TemplatesURL)
+}
+
+func (c *Client) TemplatePodBasedCollection() Collection[design.TemplatePodBased] {
+	return NewCollection[design.TemplatePodBased](c, design.TemplatesURL)
+}
+
+func (c *Client) TemplateRackBasedCollection() Collection[design.TemplateRackBased] {
+	return NewCollection[design.TemplateRackBased](c, design.TemplatesURL)
+}
+
+func (c *Client) TemplateRailCollapsedCollection() Collection[design.TemplateRailCollapsed] {
+	return NewCollection[design.TemplateRailCollapsed](c, design.TemplatesURL)
+}
+
+// URL returns the API URL of the collection.
+func (c ReadOnlyCollection[T]) URL() string {
+	return c.url
+}
+
+func (c ReadOnlyCollection[T]) urlByID(id string) string {
+	return c.url + "/" + id
+}
+
+func (c ReadOnlyCollection[T]) zero() T {
+	var result T
+	return result
+}
+
+// List returns the IDs of all objects in the collection.
+func (c ReadOnlyCollection[T]) List(ctx context.Context) ([]string, error) {
+	if c.templateType != nil {
+		// the API's ID list includes every template type - filter by fetching everything
+		all, err := c.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]string, len(all))
+		for i, item := range all {
+			if item.ID() == nil {
+				return nil, fmt.Errorf("%T at index %d has nil id", item, i)
+			}
+			result[i] = *item.ID()
+		}
+
+		return result, nil
+	}
+
+	var response struct {
+		Items []string `json:"items"`
+	}
+
+	err := c.client.talkToApstra(ctx, &talkToApstraIn{
+		method:      http.MethodOptions,
+		urlStr:      c.url,
+		apiResponse: &response,
+	})
+	if err != nil {
+		return nil, convertTtaeToAceWherePossible(err)
+	}
+
+	return response.Items, nil
+}
+
+// Get returns the object with the given ID.
+func (c ReadOnlyCollection[T]) Get(ctx context.Context, id string) (T, error) {
+	var response json.RawMessage
+	err := c.client.talkToApstra(ctx, &talkToApstraIn{
+		method:      http.MethodGet,
+		urlStr:      c.urlByID(id),
+		apiResponse: &response,
+	})
+	if err != nil {
+		return c.zero(), convertTtaeToAceWherePossible(err)
+	}
+
+	result, ok, err := c.decode(response)
+	if err != nil {
+		return c.zero(), err
+	}
+	if !ok {
+		return c.zero(), sdkerrors.WrongType(fmt.Sprintf("object with id %q is not a %q template", id, *c.templateType))
+	}
+
+	return result, nil
+}
+
+// GetAll returns every object in the collection.
+func (c ReadOnlyCollection[T]) GetAll(ctx context.Context) ([]T, error) {
+	var response struct {
+		Items []json.RawMessage `json:"items"`
+	}
+
+	err := c.client.talkToApstra(ctx, &talkToApstraIn{
+		method:      http.MethodGet,
+		urlStr:      c.url,
+		apiResponse: &response,
+	})
+	if err != nil {
+		return nil, convertTtaeToAceWherePossible(err)
+	}
+
+	result := make([]T, 0, len(response.Items))
+	for i, item := range response.Items {
+		v, ok, err := c.decode(item)
+		if err != nil {
+			return nil, fmt.Errorf("decoding item at index %d: %w", i, err)
+		}
+		if ok {
+			result = append(result, v)
+		}
+	}
+
+	return result, nil
+}
+
+// GetByLabel returns the single object in the collection with the given label.
+func (c ReadOnlyCollection[T]) GetByLabel(ctx context.Context, label string) (T, error) {
+	all, err := c.GetAll(ctx)
+	if err != nil {
+		return c.zero(), fmt.Errorf("failed getting all %T candidates: %w", c.zero(), err)
+	}
+
+	var result []T
+	for _, item := range all {
+		itemLabel, err := labelOf(item)
+		if err != nil {
+			return c.zero(), err
+		}
+		if itemLabel == label {
+			result = append(result, item)
+		}
+	}
+
+	switch len(result) {
+	case 0:
+		return c.zero(), ClientErr{
+			errType: ErrNotfound,
+			err:     fmt.Errorf("%T with label %s not found", c.zero(), label),
+		}
+	case 1:
+		return result[0], nil
+	default: // len(result) > 1
+		return c.zero(), ClientErr{
+			errType: ErrMultipleMatch,
+			err:     fmt.Errorf("found multiple candidate %T with label %s", c.zero(), label),
+		}
+	}
+}
+
+// Create creates v and returns its ID. v must not have an ID.
+func (c Collection[T]) Create(ctx context.Context, v T) (string, error) {
+	if v.ID() != nil {
+		return "", fmt.Errorf("id must be nil when creating %T", v)
+	}
+
+	var response struct {
+		ID string `json:"id"`
+	}
+
+	err := c.client.talkToApstra(ctx, &talkToApstraIn{
+		method:      http.MethodPost,
+		urlStr:      c.url,
+		apiInput:    v,
+		apiResponse: &response,
+	})
+	if err != nil {
+		return "", convertTtaeToAceWherePossible(err)
+	}
+
+	return response.ID, nil
+}
+
+// Update replaces the object identified by v's ID with v.
+func (c Collection[T]) Update(ctx context.Context, v T) error {
+	if v.ID() == nil {
+		return fmt.Errorf("id is required when updating %T", v)
+	}
+
+	err := c.client.talkToApstra(ctx, &talkToApstraIn{
+		method:   http.MethodPut,
+		urlStr:   c.urlByID(*v.ID()),
+		apiInput: v,
+	})
+	if err != nil {
+		return convertTtaeToAceWherePossible(err)
+	}
+
+	return nil
+}
+
+// Delete deletes the object with the given ID.
+func (c Collection[T]) Delete(ctx context.Context, id string) error {
+	err := c.client.talkToApstra(ctx, &talkToApstraIn{
+		method: http.MethodDelete,
+		urlStr: c.urlByID(id),
+	})
+	if err != nil {
+		return convertTtaeToAceWherePossible(err)
+	}
+
+	return nil
+}
+
+// Iterate yields each object in the collection. IDs are listed up front and
+// objects are then fetched one at a time. Note that List fetches every object
+// for some types (templates), so this is not a memory-saving alternative to
+// GetAll for those. Errors are yielded along with a zero value; iteration
+// stops when the caller stops consuming values.
+func (c ReadOnlyCollection[T]) Iterate(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		ids, err := c.List(ctx)
+		if err != nil {
+			yield(c.zero(), err)
+			return
+		}
+
+		for _, id := range ids {
+			v, err := c.Get(ctx, id)
+			if err != nil {
+				var ace ClientErr
+				if errors.As(err, &ace) && ace.Type() == ErrNotfound {
+					continue // object deleted since we listed it
+				}
+			}
+			if !yield(v, err) {
+				return
+			}
+		}
+	}
+}
+
+// decode unmarshals raw into a T. The returned bool is false when the object
+// is a template of the wrong type.
+func (c ReadOnlyCollection[T]) decode(raw json.RawMessage) (T, bool, error) {
+	var result T
+
+	if c.templateType != nil {
+		var rawType struct {
+			Type enum.TemplateType `json:"type"`
+		}
+		err := json.Unmarshal(raw, &rawType)
+		if err != nil {
+			return result, false, fmt.Errorf("unmarshaling template type: %w", err)
+		}
+		if rawType.Type != *c.templateType {
+			return result, false, nil
+		}
+	}
+
+	err := json.Unmarshal(raw, &result)
+	if err != nil {
+		return result, false, fmt.Errorf("unmarshaling %T: %w", result, err)
+	}
+
+	return result, true, nil
+}
+
+// labelOf returns the value of v's "Label" field.
+func labelOf(v any) (string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return "", fmt.Errorf("cannot determine label of non-struct type %T", v)
+	}
+
+	field := rv.FieldByName("Label")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return "", fmt.Errorf("type %T has no string field named Label", v)
+	}
+
+	return field.String(), nil
+}
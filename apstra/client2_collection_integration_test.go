@@ -0,0 +1,107 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build integration
+
+package apstra_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/apstra"
+	"github.com/Juniper/apstra-go-sdk/design"
+	testutils "github.com/Juniper/apstra-go-sdk/internal/test_utils"
+	testclient "github.com/Juniper/apstra-go-sdk/internal/test_utils/test_client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollection_Tag(t *testing.T) {
+	ctx := testutils.ContextWithTestID(context.Background(), t)
+	clients := testclient.GetTestClients(t, ctx)
+
+	for _, client := range clients {
+		t.Run(client.Name(), func(t *testing.T) {
+			t.Parallel()
+			ctx := testutils.ContextWithTestID(ctx, t)
+
+			collection := client.Client.TagCollection()
+			create := design.Tag{Label: testutils.RandString(6, "hex"), Description: testutils.RandString(6, "hex")}
+
+			id, err := collection.Create(ctx, create)
+			require.NoError(t, err)
+
+			testutils.CleanupWithFreshContext(t, 10, func(ctx context.Context) error {
+				_ = collection.Delete(ctx, id)
+				return nil
+			})
+
+			obj, err := collection.Get(ctx, id)
+			require.NoError(t, err)
+			require.NotNil(t, obj.ID())
+			require.Equal(t, id, *obj.ID())
+			require.Equal(t, create, obj.Replicate())
+
+			obj, err = collection.GetByLabel(ctx, create.Label)
+			require.NoError(t, err)
+			require.Equal(t, id, *obj.ID())
+
+			ids, err := collection.List(ctx)
+			require.NoError(t, err)
+			require.Contains(t, ids, id)
+
+			var found bool
+			for v, err := range collection.Iterate(ctx) {
+				require.NoError(t, err)
+				if v.ID() != nil && *v.ID() == id {
+					found = true
+				}
+			}
+			require.True(t, found)
+
+			obj.Description = testutils.RandString(6, "hex")
+			require.NoError(t, collection.Update(ctx, obj))
+
+			updated, err := collection.Get(ctx, id)
+			require.NoError(t, err)
+			require.Equal(t, obj.Description, updated.Description)
+
+			require.NoError(t, collection.Delete(ctx, id))
+
+			var ace apstra.ClientErr
+			_, err = collection.Get(ctx, id)
+			require.ErrorAs(t, err, &ace)
+			require.Equal(t, apstra.ErrNotfound, ace.Type())
+		})
+	}
+}
+
+func TestCollection_TemplateTypeFilter(t *testing.T) {
+	ctx := testutils.ContextWithTestID(context.Background(), t)
+	clients := testclient.GetTestClients(t, ctx)
+
+	for _, client := range clients {
+		t.Run(client.Name(), func(t *testing.T) {
+			t.Parallel()
+			ctx := testutils.ContextWithTestID(ctx, t)
+
+			all, err := client.Client.GetTemplates2(ctx)
+			require.NoError(t, err)
+
+			rackBased, err := client.Client.TemplateRackBasedCollection().List(ctx)
+			require.NoError(t, err)
+
+			podBased, err := client.Client.TemplatePodBasedCollection().List(ctx)
+			require.NoError(t, err)
+
+			l3Collapsed, err := client.Client.TemplateL3CollapsedCollection().List(ctx)
+			require.NoError(t, err)
+
+			railCollapsed, err := client.Client.TemplateRailCollapsedCollection().List(ctx)
+			require.NoError(t, err)
+
+			require.Equal(t, len(all), len(rackBased)+len(podBased)+len(l3Collapsed)+len(railCollapsed))
+		})
+	}
+}
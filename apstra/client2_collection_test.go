@@ -0,0 +1,97 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/design"
+	"github.com/Juniper/apstra-go-sdk/device"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCollection_templateType(t *testing.T) {
+	var c Client
+
+	require.Nil(t, c.TagCollection().templateType)
+	require.Nil(t, c.DeviceProfileCollection().templateType)
+	require.Equal(t, &enum.TemplateTypeRackBased, c.TemplateRackBasedCollection().templateType)
+	require.Equal(t, &enum.TemplateTypePodBased, c.TemplatePodBasedCollection().templateType)
+	require.Equal(t, &enum.TemplateTypeL3Collapsed, c.TemplateL3CollapsedCollection().templateType)
+	require.Equal(t, &enum.TemplateTypeRailCollapsed, c.TemplateRailCollapsedCollection().templateType)
+	require.Equal(t, design.TagsURL+"/abc", c.TagCollection().urlByID("abc"))
+	require.Equal(t, design.InterfaceMapDigestsURL, c.InterfaceMapDigestCollection().URL())
+}
+
+func TestCollection_decode(t *testing.T) {
+	var c Client
+
+	t.Run("tag", func(t *testing.T) {
+		t.Parallel()
+
+		tag, ok, err := c.TagCollection().decode(json.RawMessage(`{"id":"abc","label":"foo","description":"bar"}`))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.NotNil(t, tag.ID())
+		require.Equal(t, "abc", *tag.ID())
+		require.Equal(t, design.Tag{Label: "foo", Description: "bar"}, tag.Replicate())
+	})
+
+	t.Run("template_type_mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok, err := c.TemplateRackBasedCollection().decode(json.RawMessage(`{"id":"abc","type":"pod_based","display_name":"foo"}`))
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+
+	t.Run("template_type_match", func(t *testing.T) {
+		t.Parallel()
+
+		template, ok, err := c.TemplateL3CollapsedCollection().decode(json.RawMessage(`{"id":"abc","type":"l3_collapsed","display_name":"foo"}`))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, "foo", template.Label)
+	})
+
+	t.Run("garbage", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := c.TagCollection().decode(json.RawMessage(`[]`))
+		require.Error(t, err)
+	})
+}
+
+func TestLabelOf(t *testing.T) {
+	type testCase struct {
+		v      any
+		exp    string
+		expErr bool
+	}
+
+	testCases := map[string]testCase{
+		"tag":            {v: design.Tag{Label: "tag"}, exp: "tag"},
+		"tag_pointer":    {v: &design.Tag{Label: "tag"}, exp: "tag"},
+		"device_profile": {v: device.Profile{Label: "profile"}, exp: "profile"},
+		"no_label_field": {v: struct{ Name string }{Name: "foo"}, expErr: true},
+		"not_a_struct":   {v: "foo", expErr: true},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := labelOf(tCase.v)
+			if tCase.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tCase.exp, result)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Juniper/apstra-go-sdk/design"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal"
+)
+
+// EnsureResult describes the outcome of an Ensure*2 call: the ID of the
+// object which matches the caller's intent and the action taken to get there.
+type EnsureResult struct {
+	ID     string
+	Action enum.EnsureAction
+}
+
+func (c Client) EnsureConfigTemplate2(ctx context.Context, v design.ConfigTemplate) (EnsureResult, error) {
+	return ensure2(ctx, v.Label, v, c.GetConfigTemplateByLabel2, c.CreateConfigTemplate2, c.UpdateConfigTemplate2)
+}
+
+func (c Client) EnsureConfiglet2(ctx context.Context, v design.Configlet) (EnsureResult, error) {
+	return ensure2(ctx, v.Label, v, c.GetConfigletByLabel2, c.CreateConfiglet2, c.UpdateConfiglet2)
+}
+
+func (c Client) EnsureInterfaceMap2(ctx context.Context, v design.InterfaceMap) (EnsureResult, error) {
+	return ensure2(ctx, v.Label, v, c.GetInterfaceMapByLabel2, c.CreateInterfaceMap2, c.UpdateInterfaceMap2)
+}
+
+func (c Client) EnsureLogicalDevice2(ctx context.Context, v design.LogicalDevice) (EnsureResult, error) {
+	return ensure2(ctx, v.Label, v, c.GetLogicalDeviceByLabel2, c.CreateLogicalDevice2, c.UpdateLogicalDevice2)
+}
+
+func (c Client) EnsureRackType2(ctx context.Context, v design.RackType) (EnsureResult, error) {
+	return ensure2(ctx, v.Label, v, c.GetRackTypeByLabel2, c.CreateRackType2, c.UpdateRackType2)
+}
+
+func (c Client) EnsureTag2(ctx context.Context, v design.Tag) (EnsureResult, error) {
+	return ensure2(ctx, v.Label, v, c.GetTagByLabel2, c.CreateTag2, c.UpdateTag2)
+}
+
+func (c Client) EnsureTemplateL3Collapsed2(ctx context.Context, v design.TemplateL3Collapsed) (EnsureResult, error) {
+	return ensure2(ctx, v.Label, v, c.GetTemplateL3CollapsedByLabel2, c.CreateTemplateL3Collapsed2, c.UpdateTemplateL3Collapsed2)
+}
+
+func (c Client) EnsureTemplatePodBased2(ctx context.Context, v design.TemplatePodBased) (EnsureResult, error) {
+	return ensure2(ctx, v.Label, v, c.GetTemplatePodBasedByLabel2, c.CreateTemplatePodBased2, c.UpdateTemplatePodBased2)
+}
+
+func (c Client) EnsureTemplateRackBased2(ctx context.Context, v design.TemplateRackBased) (EnsureResult, error) {
+	return ensure2(ctx, v.Label, v, c.GetTemplateRackBasedByLabel2, c.CreateTemplateRackBased2, c.UpdateTemplateRackBased2)
+}
+
+func (c Client) EnsureTemplateRailCollapsed2(ctx context.Context, v design.TemplateRailCollapsed) (EnsureResult, error) {
+	return ensure2(ctx, v.Label, v, c.GetTemplateRailCollapsedByLabel2, c.CreateTemplateRailCollapsed2, c.UpdateTemplateRailCollapsed2)
+}
+
+// ensure2 looks up an object by label. When no object is found, v is created.
+// When the object found differs from v (ignoring metadata), it is updated to
+// match v. Otherwise, no action is taken.
+func ensure2[T internal.IDer](
+	ctx context.Context,
+	label string,
+	v T,
+	getByLabel func(context.Context, string) (T, error),
+	create func(context.Context, T) (string, error),
+	update func(context.Context, T) error,
+) (EnsureResult, error) {
+	if v.ID() != nil {
+		return EnsureResult{}, fmt.Errorf("id must be nil when ensuring %T", v)
+	}
+
+	existing, err := getByLabel(ctx, label)
+	if err != nil {
+		var ace ClientErr
+		if !errors.As(err, &ace) || ace.Type() != ErrNotfound {
+			return EnsureResult{}, fmt.Errorf("looking up %T with label %q: %w", v, label, err)
+		}
+
+		// not found - create it
+		id, err := create(ctx, v)
+		if err != nil {
+			return EnsureResult{}, fmt.Errorf("creating %T with label %q: %w", v, label, err)
+		}
+
+		return EnsureResult{ID: id, Action: enum.EnsureActionCreated}, nil
+	}
+
+	if existing.ID() == nil {
+		return EnsureResult{}, fmt.Errorf("%T with label %q has no ID", existing, label)
+	}
+	id := *existing.ID()
+
+	equal, err := design.ContentEqual(existing, v)
+	if err != nil {
+		return EnsureResult{}, fmt.Errorf("comparing %T with label %q: %w", v, label, err)
+	}
+	if equal {
+		return EnsureResult{ID: id, Action: enum.EnsureActionNone}, nil
+	}
+
+	v, err = withID(v, id)
+	if err != nil {
+		return EnsureResult{}, err
+	}
+
+	err = update(ctx, v)
+	if err != nil {
+		return EnsureResult{}, fmt.Errorf("updating %T with id %q: %w", v, id, err)
+	}
+
+	return EnsureResult{ID: id, Action: enum.EnsureActionUpdated}, nil
+}
+
+// withID returns a copy of v with its ID set. Design objects do not permit
+// callers to set the ID directly, so the copy is made by round-tripping v
+// through its JSON representation with the "id" element injected.
+func withID[T internal.IDer](v T, id string) (T, error) {
+	var result T
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return result, fmt.Errorf("marshaling %T: %w", v, err)
+	}
+
+	var m map[string]json.RawMessage
+	err = json.Unmarshal(b, &m)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling %T: %w", v, err)
+	}
+
+	m["id"], _ = json.Marshal(id) // marshaling a string cannot error
+
+	b, err = json.Marshal(m)
+	if err != nil {
+		return result, fmt.Errorf("marshaling %T with id: %w", v, err)
+	}
+
+	err = json.Unmarshal(b, &result)
+	if err != nil {
+		return result, fmt.Errorf("unmarshaling %T with id: %w", v, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/design"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/speed"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithID(t *testing.T) {
+	ld := design.LogicalDevice{
+		Label: "ld",
+		Panels: []design.LogicalDevicePanel{
+			{
+				PanelLayout: design.LogicalDevicePanelLayout{RowCount: 1, ColumnCount: 2},
+				PortGroups: []design.LogicalDevicePanelPortGroup{
+					{Count: 2, Speed: speed.Speed("10G"), Roles: design.LogicalDevicePortRoles{enum.PortRoleGeneric}},
+				},
+				PortIndexing: enum.DesignLogicalDevicePanelPortIndexingTBLR,
+			},
+		},
+	}
+	rackType := design.RackType{
+		Label:                    "rack",
+		FabricConnectivityDesign: enum.FabricConnectivityDesignL3Clos,
+		LeafSwitches:             []design.RackTypeLeafSwitch{{Label: "leaf", LogicalDevice: ld, Tags: []design.Tag{{Label: "a", Description: "b"}}}},
+	}
+
+	t.Run("tag", func(t *testing.T) {
+		t.Parallel()
+		v := design.Tag{Label: "label", Description: "description"}
+		result, err := withID(v, "abc")
+		require.NoError(t, err)
+		require.NotNil(t, result.ID())
+		require.Equal(t, "abc", *result.ID())
+		require.Equal(t, v, result.Replicate())
+	})
+
+	t.Run("logical_device", func(t *testing.T) {
+		t.Parallel()
+		result, err := withID(ld, "abc")
+		require.NoError(t, err)
+		require.NotNil(t, result.ID())
+		require.Equal(t, "abc", *result.ID())
+		equal, err := design.ContentEqual(ld, result)
+		require.NoError(t, err)
+		require.True(t, equal)
+	})
+
+	t.Run("rack_type", func(t *testing.T) {
+		t.Parallel()
+		result, err := withID(rackType, "abc")
+		require.NoError(t, err)
+		require.NotNil(t, result.ID())
+		require.Equal(t, "abc", *result.ID())
+		equal, err := design.ContentEqual(rackType, result)
+		require.NoError(t, err)
+		require.True(t, equal)
+	})
+
+	t.Run("template_rail_collapsed", func(t *testing.T) {
+		t.Parallel()
+		v := design.TemplateRailCollapsed{Label: "template", Racks: []design.RackTypeWithCount{{Count: 1, RackType: rackType}}}
+		result, err := withID(v, "abc")
+		require.NoError(t, err)
+		require.NotNil(t, result.ID())
+		require.Equal(t, "abc", *result.ID())
+		equal, err := design.ContentEqual(v, result)
+		require.NoError(t, err)
+		require.True(t, equal)
+	})
+}
+
+func TestEnsure2(t *testing.T) {
+	notFound := ClientErr{errType: ErrNotfound, err: errors.New("not found")}
+	existing, err := withID(design.Tag{Label: "label", Description: "old"}, "existing_id")
+	require.NoError(t, err)
+
+	type testCase struct {
+		v          design.Tag
+		found      *design.Tag
+		getErr     error
+		expResult  EnsureResult
+		expCreated bool
+		expUpdated *design.Tag
+		expErr     bool
+	}
+
+	testCases := map[string]testCase{
+		"create": {
+			v:          design.Tag{Label: "label", Description: "new"},
+			getErr:     notFound,
+			expResult:  EnsureResult{ID: "created_id", Action: enum.EnsureActionCreated},
+			expCreated: true,
+		},
+		"update": {
+			v:          design.Tag{Label: "label", Description: "new"},
+			found:      &existing,
+			expResult:  EnsureResult{ID: "existing_id", Action: enum.EnsureActionUpdated},
+			expUpdated: &design.Tag{Label: "label", Description: "new"},
+		},
+		"no_op": {
+			v:         design.Tag{Label: "label", Description: "old"},
+			found:     &existing,
+			expResult: EnsureResult{ID: "existing_id", Action: enum.EnsureActionNone},
+		},
+		"lookup_error": {
+			v:      design.Tag{Label: "label"},
+			getErr: ClientErr{errType: ErrMultipleMatch, err: errors.New("multiple")},
+			expErr: true,
+		},
+		"id_must_be_nil": {
+			v:      existing,
+			found:  &existing,
+			expErr: true,
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			t.Parallel()
+
+			var created bool
+			var updated *design.Tag
+
+			getByLabel := func(_ context.Context, label string) (design.Tag, error) {
+				require.Equal(t, tCase.v.Label, label)
+				if tCase.getErr != nil {
+					return design.Tag{}, tCase.getErr
+				}
+				return *tCase.found, nil
+			}
+			create := func(_ context.Context, v design.Tag) (string, error) {
+				created = true
+				return "created_id", nil
+			}
+			update := func(_ context.Context, v design.Tag) error {
+				require.NotNil(t, v.ID())
+				require.Equal(t, *tCase.found.ID(), *v.ID())
+				updated = &v
+				return nil
+			}
+
+			result, err := ensure2(context.Background(), tCase.v.Label, tCase.v, getByLabel, create, update)
+			if tCase.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tCase.expResult, result)
+			require.Equal(t, tCase.expCreated, created)
+			if tCase.expUpdated == nil {
+				require.Nil(t, updated)
+			} else {
+				require.NotNil(t, updated)
+				require.Equal(t, *tCase.expUpdated, updated.Replicate())
+			}
+		})
+	}
+}
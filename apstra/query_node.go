@@ -17,6 +17,7 @@ const (
 	NodeTypeEvpnInterconnectGroup
 	NodeTypeFabricAddressingPolicy
 	NodeTypeFabricPolicy
+	NodeTypeHypervisor
 	NodeTypeInterface
 	NodeTypeInterfaceMap
 	NodeTypeLink
@@ -34,9 +35,12 @@ const (
 	NodeTypeSecurityZonePolicy
 	NodeTypeSystem
 	NodeTypeTag
+	NodeTypeVirtualInfra
 	NodeTypeVirtualNetwork
 	NodeTypeVirtualNetworkInstance
 	NodeTypeVirtualNetworkPolicy
+	NodeTypeVm
+	NodeTypeVnet
 	NodeTypeUnknown = "unknown node type %s"
 
 	nodeTypeNone                   = nodeType("")
@@ -49,6 +53,7 @@ const (
 	nodeTypeEvpnInterconnectGroup  = nodeType("evpn_interconnect_group")
 	nodeTypeFabricAddressingPolicy = nodeType("fabric_addressing_policy")
 	nodeTypeFabricPolicy           = nodeType("fabric_policy")
+	nodeTypeHypervisor             = nodeType("hypervisor")
 	nodeTypeInterface              = nodeType("interface")
 	nodeTypeInterfaceMap           = nodeType("interface_map")
 	nodeTypeLink                   = nodeType("link")
@@ -66,9 +71,12 @@ const (
 	nodeTypeSecurityZonePolicy     = nodeType("security_zone_policy")
 	nodeTypeSystem                 = nodeType("system")
 	nodeTypeTag                    = nodeType("tag")
+	nodeTypeVirtualInfra           = nodeType("virtual_infra")
 	nodeTypeVirtualNetwork         = nodeType("virtual_network")
 	nodeTypeVirtualNetworkInstance = nodeType("vn_instance")
 	nodeTypeVirtualNetworkPolicy   = nodeType("virtual_network_policy")
+	nodeTypeVm                     = nodeType("vm")
+	nodeTypeVnet                   = nodeType("vnet")
 	nodeTypeUnknown                = "unknown node type %d"
 )
 
@@ -99,6 +107,8 @@ func (o NodeType) String() string {
 		return string(nodeTypeFabricAddressingPolicy)
 	case NodeTypeFabricPolicy:
 		return string(nodeTypeFabricPolicy)
+	case NodeTypeHypervisor:
+		return string(nodeTypeHypervisor)
 	case NodeTypeInterface:
 		return string(nodeTypeInterface)
 	case NodeTypeInterfaceMap:
@@ -133,12 +143,18 @@ func (o NodeType) String() string {
 		return string(nodeTypeSystem)
 	case NodeTypeTag:
 		return string(nodeTypeTag)
+	case NodeTypeVirtualInfra:
+		return string(nodeTypeVirtualInfra)
 	case NodeTypeVirtualNetwork:
 		return string(nodeTypeVirtualNetwork)
 	case NodeTypeVirtualNetworkInstance:
 		return string(nodeTypeVirtualNetworkInstance)
 	case NodeTypeVirtualNetworkPolicy:
 		return string(nodeTypeVirtualNetworkPolicy)
+	case NodeTypeVm:
+		return string(nodeTypeVm)
+	case NodeTypeVnet:
+		return string(nodeTypeVnet)
 	default:
 		return fmt.Sprintf(nodeTypeUnknown, o)
 	}
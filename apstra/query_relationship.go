@@ -21,6 +21,7 @@ const (
 	RelationshipTypeEvpnInterconnectPeer
 	RelationshipTypeHostedInterfaces
 	RelationshipTypeHostedVnInstances
+	RelationshipTypeHostedVms
 	RelationshipTypeInterfaceMap
 	RelationshipTypeInstantiatedBy
 	RelationshipTypeInstantiates
@@ -28,6 +29,7 @@ const (
 	RelationshipTypeLogicalDevice
 	RelationshipTypeMemberInterfaces
 	RelationshipTypeMemberVNs
+	RelationshipTypeMemberVms
 	RelationshipTypePartOfRack
 	RelationshipTypePartOfRedundancyGroup
 	RelationshipTypePolicy
@@ -51,6 +53,7 @@ const (
 	relationshipTypeEvpnInterconnectPeer  = relationshipType("evpn_interconnect_peer")
 	relationshipTypeHostedInterfaces      = relationshipType("hosted_interfaces")
 	relationshipTypeHostedVnInstances     = relationshipType("hosted_vn_instances")
+	relationshipTypeHostedVms             = relationshipType("hosted_vms")
 	relationshipTypeInterfaceMap          = relationshipType("interface_map")
 	relationshipTypeInstantiatedBy        = relationshipType("instantiated_by")
 	relationshipTypeInstantiates          = relationshipType("instantiates")
@@ -58,6 +61,7 @@ const (
 	relationshipTypeLogicalDevice         = relationshipType("logical_device")
 	relationshipTypeMemberInterfaces      = relationshipType("member_interfaces")
 	relationshipTypeMemberVNs             = relationshipType("member_vns")
+	relationshipTypeMemberVms             = relationshipType("member_vms")
 	relationshipTypePartOfRack            = relationshipType("part_of_rack")
 	relationshipTypePartOfRedundancyGroup = relationshipType("part_of_redundancy_group")
 	relationshipTypePolicy                = relationshipType("policy")
@@ -103,6 +107,8 @@ func (o RelationshipType) String() string {
 		return string(relationshipTypeHostedInterfaces)
 	case RelationshipTypeHostedVnInstances:
 		return string(relationshipTypeHostedVnInstances)
+	case RelationshipTypeHostedVms:
+		return string(relationshipTypeHostedVms)
 	case RelationshipTypeInterfaceMap:
 		return string(relationshipTypeInterfaceMap)
 	case RelationshipTypeInstantiatedBy:
@@ -117,6 +123,8 @@ func (o RelationshipType) String() string {
 		return string(relationshipTypeMemberInterfaces)
 	case RelationshipTypeMemberVNs:
 		return string(relationshipTypeMemberVNs)
+	case RelationshipTypeMemberVms:
+		return string(relationshipTypeMemberVms)
 	case RelationshipTypePartOfRack:
 		return string(relationshipTypePartOfRack)
 	case RelationshipTypePartOfRedundancyGroup:
@@ -0,0 +1,368 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/netip"
+	"slices"
+)
+
+// intSpan is an inclusive range of integers. uint64 is used so that Last+1
+// cannot overflow at the top of the uint32 space.
+type intSpan struct {
+	first, last uint64
+	used        uint64
+}
+
+func (o intSpan) size() uint64 {
+	return o.last - o.first + 1
+}
+
+func (o intSpan) intRange() IntRange {
+	return IntRange{
+		First: uint32(o.first),
+		Last:  uint32(o.last),
+		Total: uint32(o.size()),
+		Used:  uint32(o.used),
+	}
+}
+
+// spans returns the ranges in o sorted, with overlapping and adjacent ranges
+// merged.
+func (o IntRanges) spans() []intSpan {
+	in := make([]intSpan, len(o))
+	for i, r := range o {
+		first, last := uint64(r.First), uint64(r.Last)
+		if first > last {
+			first, last = last, first
+		}
+		in[i] = intSpan{first: first, last: last, used: uint64(r.Used)}
+	}
+	slices.SortFunc(in, func(a, b intSpan) int { return cmp.Compare(a.first, b.first) })
+
+	var result []intSpan
+	for _, s := range in {
+		if n := len(result); n > 0 && s.first <= result[n-1].last+1 {
+			result[n-1].last = max(result[n-1].last, s.last)
+			result[n-1].used += s.used
+			continue
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+func intRangesFromSpans(spans []intSpan) IntRanges {
+	if len(spans) == 0 {
+		return nil
+	}
+	result := make(IntRanges, len(spans))
+	for i, s := range spans {
+		result[i] = s.intRange()
+	}
+	return result
+}
+
+// Coalesce returns the ranges sorted, with overlapping and adjacent ranges
+// merged. Used counts of merged ranges are summed. Status is not retained.
+func (o IntRanges) Coalesce() IntRanges {
+	return intRangesFromSpans(o.spans())
+}
+
+// Union returns every value found in either o or b as coalesced ranges.
+func (o IntRanges) Union(b IntRanges) IntRanges {
+	result := intRangesFromSpans(append(slices.Clone(o), b...).spans())
+	for i := range result {
+		result[i].Used = 0
+	}
+	return result
+}
+
+// Intersection returns the values found in both o and b as coalesced ranges.
+func (o IntRanges) Intersection(b IntRanges) IntRanges {
+	as, bs := o.spans(), b.spans()
+
+	var result []intSpan
+	for i, j := 0, 0; i < len(as) && j < len(bs); {
+		first, last := max(as[i].first, bs[j].first), min(as[i].last, bs[j].last)
+		if first <= last {
+			result = append(result, intSpan{first: first, last: last})
+		}
+		if as[i].last < bs[j].last {
+			i++
+		} else {
+			j++
+		}
+	}
+	return intRangesFromSpans(result)
+}
+
+// Difference returns the values found in o but not in b as coalesced ranges.
+func (o IntRanges) Difference(b IntRanges) IntRanges {
+	bs := b.spans()
+
+	var result []intSpan
+	for _, a := range o.spans() {
+		next := a.first
+		for _, s := range bs {
+			if s.last < next || s.first > a.last {
+				continue
+			}
+			if s.first > next {
+				result = append(result, intSpan{first: next, last: s.first - 1})
+			}
+			next = s.last + 1
+		}
+		if next <= a.last {
+			result = append(result, intSpan{first: next, last: a.last})
+		}
+	}
+	return intRangesFromSpans(result)
+}
+
+// Split divides the values in o into n parts, lowest values first. Part sizes
+// differ by at most one; larger parts come first.
+func (o IntRanges) Split(n int) ([]IntRanges, error) {
+	spans := o.spans()
+
+	var total uint64
+	for _, s := range spans {
+		total += s.size()
+	}
+	if n < 1 || uint64(n) > total {
+		return nil, fmt.Errorf("cannot split %d values into %d parts", total, n)
+	}
+
+	result := make([]IntRanges, n)
+	size, extra := total/uint64(n), total%uint64(n)
+	var i int
+	for part := range result {
+		want := size
+		if uint64(part) < extra {
+			want++
+		}
+		for want > 0 {
+			take := min(want, spans[i].size())
+			result[part] = append(result[part], intSpan{first: spans[i].first, last: spans[i].first + take - 1}.intRange())
+			spans[i].first += take
+			want -= take
+			if spans[i].first > spans[i].last {
+				i++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// PrefixSet is a set of IPv4 and/or IPv6 addresses expressed as CIDR prefixes.
+// Results of set operations are in canonical form: the smallest possible list
+// of prefixes, sorted by address.
+type PrefixSet []netip.Prefix
+
+// NewPrefixSetFromIpSubnets returns the subnets of an IP pool as a PrefixSet.
+func NewPrefixSetFromIpSubnets(in []IpSubnet) (PrefixSet, error) {
+	result := make(PrefixSet, len(in))
+	for i, s := range in {
+		var err error
+		if result[i], err = prefixFromIPNet(s.Network); err != nil {
+			return nil, fmt.Errorf("failed parsing subnet %d - %w", i, err)
+		}
+	}
+	return result, nil
+}
+
+// addrSpan is an inclusive range of addresses within a single address family.
+type addrSpan struct {
+	first, last netip.Addr
+}
+
+// spans returns the prefixes in o as sorted address ranges, with overlapping
+// and adjacent ranges merged.
+func (o PrefixSet) spans() []addrSpan {
+	in := make([]addrSpan, 0, len(o))
+	for _, p := range o {
+		if !p.IsValid() {
+			continue
+		}
+		p = p.Masked()
+		in = append(in, addrSpan{first: p.Addr(), last: lastAddr(p)})
+	}
+	slices.SortFunc(in, func(a, b addrSpan) int { return a.first.Compare(b.first) })
+
+	var result []addrSpan
+	for _, s := range in {
+		// Next() of the last address of a family is invalid, so spans never
+		// merge across address families.
+		if n := len(result); n > 0 && (s.first.Compare(result[n-1].last) <= 0 || result[n-1].last.Next() == s.first) {
+			if s.last.Compare(result[n-1].last) > 0 {
+				result[n-1].last = s.last
+			}
+			continue
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+// prefixes returns the smallest list of prefixes which exactly covers o.
+func (o addrSpan) prefixes() []netip.Prefix {
+	var result []netip.Prefix
+	for start := o.first; ; {
+		var p netip.Prefix
+		for bits := 0; bits <= start.BitLen(); bits++ {
+			p = netip.PrefixFrom(start, bits).Masked()
+			if p.Addr() == start && lastAddr(p).Compare(o.last) <= 0 {
+				break
+			}
+		}
+		result = append(result, p)
+
+		end := lastAddr(p)
+		if end == o.last {
+			return result
+		}
+		start = end.Next()
+	}
+}
+
+func prefixSetFromSpans(spans []addrSpan) PrefixSet {
+	var result PrefixSet
+	for _, s := range spans {
+		result = append(result, s.prefixes()...)
+	}
+	return result
+}
+
+// Coalesce returns o in canonical form: overlapping prefixes are removed and
+// adjacent prefixes are aggregated wherever alignment permits.
+func (o PrefixSet) Coalesce() PrefixSet {
+	return prefixSetFromSpans(o.spans())
+}
+
+// Union returns every address found in either o or b.
+func (o PrefixSet) Union(b PrefixSet) PrefixSet {
+	return append(slices.Clone(o), b...).Coalesce()
+}
+
+// Intersection returns the addresses found in both o and b.
+func (o PrefixSet) Intersection(b PrefixSet) PrefixSet {
+	as, bs := o.spans(), b.spans()
+
+	var result []addrSpan
+	for i, j := 0, 0; i < len(as) && j < len(bs); {
+		first, last := as[i].first, as[i].last
+		if bs[j].first.Compare(first) > 0 {
+			first = bs[j].first
+		}
+		if bs[j].last.Compare(last) < 0 {
+			last = bs[j].last
+		}
+		if first.BitLen() == last.BitLen() && first.Compare(last) <= 0 {
+			result = append(result, addrSpan{first: first, last: last})
+		}
+		if as[i].last.Compare(bs[j].last) < 0 {
+			i++
+		} else {
+			j++
+		}
+	}
+	return prefixSetFromSpans(result)
+}
+
+// Difference returns the addresses found in o but not in b.
+func (o PrefixSet) Difference(b PrefixSet) PrefixSet {
+	bs := b.spans()
+
+	var result []addrSpan
+	for _, a := range o.spans() {
+		next := a.first
+		for _, s := range bs {
+			if !next.IsValid() {
+				break // previous span reached the end of the address family
+			}
+			if s.last.Compare(next) < 0 || s.first.Compare(a.last) > 0 {
+				continue
+			}
+			if s.first.Compare(next) > 0 {
+				result = append(result, addrSpan{first: next, last: s.first.Prev()})
+			}
+			next = s.last.Next()
+		}
+		if next.IsValid() && next.Compare(a.last) <= 0 {
+			result = append(result, addrSpan{first: next, last: a.last})
+		}
+	}
+	return prefixSetFromSpans(result)
+}
+
+// Split divides the addresses in o into n parts, lowest addresses first. Part
+// sizes differ by at most one address; choose n so that the size of o is a
+// multiple of n to get equally sized, aligned parts.
+func (o PrefixSet) Split(n int) ([]PrefixSet, error) {
+	spans := o.spans()
+
+	total := new(big.Int)
+	for _, s := range spans {
+		total.Add(total, addrSpanSize(s))
+	}
+	if n < 1 || total.Cmp(big.NewInt(int64(n))) < 0 {
+		return nil, fmt.Errorf("cannot split %s addresses into %d parts", total, n)
+	}
+
+	size, extra := new(big.Int).DivMod(total, big.NewInt(int64(n)), new(big.Int))
+	one := big.NewInt(1)
+
+	result := make([]PrefixSet, n)
+	var i int
+	for part := range result {
+		want := new(big.Int).Set(size)
+		if extra.Cmp(big.NewInt(int64(part))) > 0 {
+			want.Add(want, one)
+		}
+		for want.Sign() > 0 {
+			first := addrToInt(spans[i].first)
+			take := addrSpanSize(spans[i])
+			if want.Cmp(take) < 0 {
+				take.Set(want)
+			}
+			last, err := intToAddr(new(big.Int).Sub(new(big.Int).Add(first, take), one), spans[i].first.BitLen())
+			if err != nil {
+				return nil, err
+			}
+			result[part] = append(result[part], addrSpan{first: spans[i].first, last: last}.prefixes()...)
+
+			want.Sub(want, take)
+			if last == spans[i].last {
+				i++
+			} else {
+				spans[i].first = last.Next()
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func addrSpanSize(s addrSpan) *big.Int {
+	result := new(big.Int).Sub(addrToInt(s.last), addrToInt(s.first))
+	return result.Add(result, big.NewInt(1))
+}
+
+func addrToInt(a netip.Addr) *big.Int {
+	return new(big.Int).SetBytes(a.AsSlice())
+}
+
+func intToAddr(i *big.Int, bitLen int) (netip.Addr, error) {
+	if i.Sign() < 0 || i.BitLen() > bitLen {
+		return netip.Addr{}, errors.New("address out of range")
+	}
+	result, _ := netip.AddrFromSlice(i.FillBytes(make([]byte, bitLen/8)))
+	return result, nil
+}
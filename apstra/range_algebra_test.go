@@ -0,0 +1,104 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"fmt"
+	"math"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testIntRanges(in ...uint32) IntRanges {
+	result := make(IntRanges, len(in)/2)
+	for i := range result {
+		result[i] = IntRange{First: in[2*i], Last: in[2*i+1]}
+	}
+	return result
+}
+
+func intRangeStrings(in IntRanges) []string {
+	result := make([]string, len(in))
+	for i, r := range in {
+		result[i] = fmt.Sprintf("%d-%d", r.First, r.Last)
+	}
+	return result
+}
+
+func TestIntRanges_Algebra(t *testing.T) {
+	a := testIntRanges(20, 29, 1, 5, 6, 10, 8, 12, 40, 49)
+	b := testIntRanges(3, 21, 45, 60)
+
+	coalesced := a.Coalesce()
+	require.Equal(t, []string{"1-12", "20-29", "40-49"}, intRangeStrings(coalesced))
+	require.Equal(t, uint32(12), coalesced[0].Total)
+
+	require.Equal(t, []string{"1-29", "40-60"}, intRangeStrings(a.Union(b)))
+	require.Equal(t, []string{"3-12", "20-21", "45-49"}, intRangeStrings(a.Intersection(b)))
+	require.Equal(t, []string{"1-2", "22-29", "40-44"}, intRangeStrings(a.Difference(b)))
+	require.Equal(t, []string{"13-19", "50-60"}, intRangeStrings(b.Difference(a)))
+	require.Empty(t, a.Difference(a))
+
+	top := testIntRanges(math.MaxUint32-1, math.MaxUint32, 0, 0)
+	require.Equal(t, []string{"1-4294967293"}, intRangeStrings(testIntRanges(0, math.MaxUint32).Difference(top)))
+
+	parts, err := a.Split(3)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1-11"}, intRangeStrings(parts[0]))
+	require.Equal(t, []string{"12-12", "20-29"}, intRangeStrings(parts[1]))
+	require.Equal(t, []string{"40-49"}, intRangeStrings(parts[2]))
+
+	_, err = testIntRanges(1, 2).Split(3)
+	require.Error(t, err)
+	_, err = a.Split(0)
+	require.Error(t, err)
+}
+
+func testPrefixSet(in ...string) PrefixSet {
+	result := make(PrefixSet, len(in))
+	for i, s := range in {
+		result[i] = netip.MustParsePrefix(s)
+	}
+	return result
+}
+
+func prefixStrings(in PrefixSet) []string {
+	result := make([]string, len(in))
+	for i, p := range in {
+		result[i] = p.String()
+	}
+	return result
+}
+
+func TestPrefixSet_Algebra(t *testing.T) {
+	a := testPrefixSet("10.0.1.0/25", "10.0.0.0/24", "10.0.1.128/25", "10.0.3.0/24", "2001:db8::/33", "2001:db8:8000::/33")
+	b := testPrefixSet("10.0.1.0/24", "10.0.2.0/23", "2001:db8::/48")
+
+	require.Equal(t, []string{"10.0.0.0/23", "10.0.3.0/24", "2001:db8::/32"}, prefixStrings(a.Coalesce()))
+	require.Equal(t, []string{"10.0.0.0/22", "2001:db8::/32"}, prefixStrings(a.Union(b)))
+	require.Equal(t, []string{"10.0.1.0/24", "10.0.3.0/24", "2001:db8::/48"}, prefixStrings(a.Intersection(b)))
+	require.Equal(t, []string{"10.0.0.0/24", "2001:db8:1::/48", "2001:db8:2::/47", "2001:db8:4::/46"}, prefixStrings(a.Difference(b))[:4])
+	require.Equal(t, []string{"10.0.2.0/24"}, prefixStrings(b.Difference(a)))
+	require.Empty(t, a.Difference(a))
+
+	require.Equal(t, []string{"0.0.0.0/0"}, prefixStrings(testPrefixSet("0.0.0.0/1", "128.0.0.0/1").Coalesce()))
+	require.Equal(t, []string{"255.255.255.254/32"}, prefixStrings(testPrefixSet("255.255.255.254/31").Difference(testPrefixSet("255.255.255.255/32"))))
+
+	parts, err := testPrefixSet("10.0.0.0/24").Split(4)
+	require.NoError(t, err)
+	require.Len(t, parts, 4)
+	require.Equal(t, []string{"10.0.0.192/26"}, prefixStrings(parts[3]))
+
+	parts, err = testPrefixSet("10.0.0.0/30", "10.0.1.0/31").Split(3)
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.0/31"}, prefixStrings(parts[0]))
+	require.Equal(t, []string{"10.0.0.2/31"}, prefixStrings(parts[1]))
+	require.Equal(t, []string{"10.0.1.0/31"}, prefixStrings(parts[2]))
+
+	_, err = testPrefixSet("10.0.0.0/32").Split(2)
+	require.Error(t, err)
+}
@@ -194,6 +194,15 @@ func (o *Client) craftUrl(in *talkToApstraIn) (*url.URL, error) {
 // not nil, it JSON-encodes that data structure and sends it. In case the
 // in.apiResponse is not nil, the server response is extracted into it.
 func (o *Client) talkToApstra(ctx context.Context, in *talkToApstraIn) error {
+	if o.catalogCache != nil {
+		return o.catalogCache.talkToApstra(ctx, in, o.talkToApstraUncached)
+	}
+
+	return o.talkToApstraUncached(ctx, in)
+}
+
+// talkToApstraUncached is talkToApstra without the catalog cache.
+func (o *Client) talkToApstraUncached(ctx context.Context, in *talkToApstraIn) error {
 	if o.cfg.APIOpsDCID != nil {
 		return o.talkToApiOps(ctx, in)
 	}
@@ -0,0 +1,512 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+// ApplicationPointInfo describes a switch interface facing a non-switch
+// system (generic system, external router, etc...) to which connectivity
+// templates may be assigned. Ethernet interfaces which are members of a LAG
+// are represented by their port-channel interface.
+type ApplicationPointInfo struct {
+	Id                ObjectId
+	IfName            string
+	Lag               bool
+	LagMemberIds      []ObjectId // member interfaces when Lag is true
+	SystemId          ObjectId
+	SystemLabel       string
+	SystemRole        enum.SystemNodeRole
+	SystemTags        []string
+	InterfaceTags     []string
+	LinkRoles         []enum.LinkRole
+	PeerSystemIds     []ObjectId
+	PeerSystemLabels  []string
+	PeerSystemRoles   []enum.SystemNodeRole
+	PeerSystemTags    []string   // union of the tags of all peer systems
+	VirtualNetworkIds []ObjectId // bound by connectivity templates currently in use on the application point
+}
+
+// ApplicationPointSelector selects application points by their attributes.
+// An application point is selected when it satisfies every non-empty field.
+// Label and name fields are glob patterns (see path.Match), any one of which
+// may match. Tag fields list tags which must all be present. Other list
+// fields are satisfied when any listed value matches.
+type ApplicationPointSelector struct {
+	SystemLabels      []string
+	SystemRoles       []enum.SystemNodeRole
+	SystemTags        []string
+	InterfaceNames    []string
+	InterfaceTags     []string
+	PeerSystemLabels  []string
+	PeerSystemRoles   []enum.SystemNodeRole
+	PeerSystemTags    []string
+	LinkRoles         []enum.LinkRole
+	Lag               *bool // when set, select only LAG (true) or only non-LAG (false) application points
+	VirtualNetworkIds []ObjectId
+}
+
+// Validate checks the selector's glob patterns.
+func (o ApplicationPointSelector) Validate() error {
+	for _, patterns := range [][]string{o.SystemLabels, o.InterfaceNames, o.PeerSystemLabels} {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid pattern %q - %w", pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Matches returns true when ap satisfies the selector.
+func (o ApplicationPointSelector) Matches(ap ApplicationPointInfo) bool {
+	globs := func(patterns []string, values ...string) bool {
+		if len(patterns) == 0 {
+			return true
+		}
+		for _, pattern := range patterns {
+			for _, value := range values {
+				if ok, _ := path.Match(pattern, value); ok {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	allOf := func(want, have []string) bool {
+		for _, w := range want {
+			if !slices.Contains(have, w) {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch {
+	case !globs(o.SystemLabels, ap.SystemLabel):
+		return false
+	case len(o.SystemRoles) > 0 && !slices.Contains(o.SystemRoles, ap.SystemRole):
+		return false
+	case !allOf(o.SystemTags, ap.SystemTags):
+		return false
+	case !globs(o.InterfaceNames, ap.IfName):
+		return false
+	case !allOf(o.InterfaceTags, ap.InterfaceTags):
+		return false
+	case !globs(o.PeerSystemLabels, ap.PeerSystemLabels...):
+		return false
+	case len(o.PeerSystemRoles) > 0 && !slices.ContainsFunc(o.PeerSystemRoles, func(r enum.SystemNodeRole) bool { return slices.Contains(ap.PeerSystemRoles, r) }):
+		return false
+	case !allOf(o.PeerSystemTags, ap.PeerSystemTags):
+		return false
+	case len(o.LinkRoles) > 0 && !slices.ContainsFunc(o.LinkRoles, func(r enum.LinkRole) bool { return slices.Contains(ap.LinkRoles, r) }):
+		return false
+	case o.Lag != nil && *o.Lag != ap.Lag:
+		return false
+	case len(o.VirtualNetworkIds) > 0 && !slices.ContainsFunc(o.VirtualNetworkIds, func(id ObjectId) bool { return slices.Contains(ap.VirtualNetworkIds, id) }):
+		return false
+	}
+
+	return true
+}
+
+// SelectApplicationPoints returns the members of in which satisfy selector.
+func SelectApplicationPoints(in []ApplicationPointInfo, selector ApplicationPointSelector) []ApplicationPointInfo {
+	var result []ApplicationPointInfo
+	for _, ap := range in {
+		if selector.Matches(ap) {
+			result = append(result, ap)
+		}
+	}
+	return result
+}
+
+type applicationPointLinkRow struct {
+	System struct {
+		Id    ObjectId `json:"id"`
+		Label string   `json:"label"`
+		Role  string   `json:"role"`
+	} `json:"n_system"`
+	Interface struct {
+		Id     ObjectId `json:"id"`
+		IfName string   `json:"if_name"`
+	} `json:"n_interface"`
+	Link struct {
+		Id   ObjectId `json:"id"`
+		Role string   `json:"role"`
+	} `json:"n_link"`
+	PeerInterface struct {
+		Id ObjectId `json:"id"`
+	} `json:"n_peer_interface"`
+	PeerSystem struct {
+		Id         ObjectId `json:"id"`
+		Label      string   `json:"label"`
+		Role       string   `json:"role"`
+		SystemType string   `json:"system_type"`
+	} `json:"n_peer_system"`
+}
+
+type applicationPointLagRow struct {
+	Lag struct {
+		Id     ObjectId `json:"id"`
+		IfName string   `json:"if_name"`
+	} `json:"n_lag"`
+	Member struct {
+		Id ObjectId `json:"id"`
+	} `json:"n_member"`
+}
+
+// newApplicationPointInventory assembles ApplicationPointInfo from graph query
+// results. tags and vnIds are keyed by graph node ID and application point ID
+// respectively.
+func newApplicationPointInventory(links []applicationPointLinkRow, lags []applicationPointLagRow, tags map[ObjectId][]string, vnIds map[ObjectId][]ObjectId) []ApplicationPointInfo {
+	lagByMember := make(map[ObjectId]applicationPointLagRow, len(lags))
+	for _, lag := range lags {
+		lagByMember[lag.Member.Id] = lag
+	}
+
+	appendUnique := func(s []ObjectId, id ObjectId) []ObjectId {
+		if slices.Contains(s, id) {
+			return s
+		}
+		return append(s, id)
+	}
+
+	aps := make(map[ObjectId]*ApplicationPointInfo)
+	for _, link := range links {
+		if link.Interface.Id == link.PeerInterface.Id || link.PeerSystem.SystemType == systemTypeSwitch.string() {
+			continue // same end of the link, or a fabric link
+		}
+
+		id, ifName := link.Interface.Id, link.Interface.IfName
+		lag, isMember := lagByMember[id]
+		if isMember {
+			id, ifName = lag.Lag.Id, lag.Lag.IfName
+		}
+
+		ap, ok := aps[id]
+		if !ok {
+			ap = &ApplicationPointInfo{
+				Id:          id,
+				IfName:      ifName,
+				Lag:         isMember,
+				SystemId:    link.System.Id,
+				SystemLabel: link.System.Label,
+				SystemRole:  enum.SystemNodeRole{Value: link.System.Role},
+			}
+			aps[id] = ap
+		}
+
+		if isMember {
+			ap.LagMemberIds = appendUnique(ap.LagMemberIds, link.Interface.Id)
+		}
+
+		if linkRole := (enum.LinkRole{Value: link.Link.Role}); !slices.Contains(ap.LinkRoles, linkRole) {
+			ap.LinkRoles = append(ap.LinkRoles, linkRole)
+		}
+
+		if !slices.Contains(ap.PeerSystemIds, link.PeerSystem.Id) {
+			ap.PeerSystemIds = append(ap.PeerSystemIds, link.PeerSystem.Id)
+			ap.PeerSystemLabels = append(ap.PeerSystemLabels, link.PeerSystem.Label)
+			if peerRole := (enum.SystemNodeRole{Value: link.PeerSystem.Role}); !slices.Contains(ap.PeerSystemRoles, peerRole) {
+				ap.PeerSystemRoles = append(ap.PeerSystemRoles, peerRole)
+			}
+		}
+	}
+
+	result := make([]ApplicationPointInfo, 0, len(aps))
+	for _, ap := range aps {
+		ap.SystemTags = tags[ap.SystemId]
+		ap.InterfaceTags = tags[ap.Id]
+		for _, peerId := range ap.PeerSystemIds {
+			for _, tag := range tags[peerId] {
+				if !slices.Contains(ap.PeerSystemTags, tag) {
+					ap.PeerSystemTags = append(ap.PeerSystemTags, tag)
+				}
+			}
+		}
+		ap.VirtualNetworkIds = vnIds[ap.Id]
+		result = append(result, *ap)
+	}
+
+	slices.SortFunc(result, func(a, b ApplicationPointInfo) int {
+		return cmp.Or(cmp.Compare(a.SystemLabel, b.SystemLabel), cmp.Compare(a.IfName, b.IfName), cmp.Compare(a.Id, b.Id))
+	})
+
+	return result
+}
+
+// GetApplicationPointInventory uses graph queries to find the switch
+// interfaces which face non-switch systems, along with the attributes used by
+// ApplicationPointSelector.
+func (o *TwoStageL3ClosClient) GetApplicationPointInventory(ctx context.Context) ([]ApplicationPointInfo, error) {
+	linkQuery := new(PathQuery).
+		SetBlueprintId(o.blueprintId).
+		SetBlueprintType(BlueprintTypeStaging).
+		SetClient(o.client).
+		Node([]QEEAttribute{
+			NodeTypeSystem.QEEAttribute(),
+			{Key: "system_type", Value: QEStringVal(systemTypeSwitch.string())},
+			{Key: "name", Value: QEStringVal("n_system")},
+		}).
+		Out([]QEEAttribute{RelationshipTypeHostedInterfaces.QEEAttribute()}).
+		Node([]QEEAttribute{
+			NodeTypeInterface.QEEAttribute(),
+			{Key: "if_type", Value: QEStringVal("ethernet")},
+			{Key: "name", Value: QEStringVal("n_interface")},
+		}).
+		Out([]QEEAttribute{RelationshipTypeLink.QEEAttribute()}).
+		Node([]QEEAttribute{NodeTypeLink.QEEAttribute(), {Key: "name", Value: QEStringVal("n_link")}}).
+		In([]QEEAttribute{RelationshipTypeLink.QEEAttribute()}).
+		Node([]QEEAttribute{NodeTypeInterface.QEEAttribute(), {Key: "name", Value: QEStringVal("n_peer_interface")}}).
+		In([]QEEAttribute{RelationshipTypeHostedInterfaces.QEEAttribute()}).
+		Node([]QEEAttribute{NodeTypeSystem.QEEAttribute(), {Key: "name", Value: QEStringVal("n_peer_system")}})
+
+	var linkResult struct {
+		Items []applicationPointLinkRow `json:"items"`
+	}
+	err := linkQuery.Do(ctx, &linkResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying switch links in blueprint %q - %w", o.blueprintId, err)
+	}
+
+	lagQuery := new(PathQuery).
+		SetBlueprintId(o.blueprintId).
+		SetBlueprintType(BlueprintTypeStaging).
+		SetClient(o.client).
+		Node([]QEEAttribute{
+			NodeTypeInterface.QEEAttribute(),
+			{Key: "if_type", Value: QEStringVal("port_channel")},
+			{Key: "name", Value: QEStringVal("n_lag")},
+		}).
+		Out([]QEEAttribute{RelationshipTypeComposedOf.QEEAttribute()}).
+		Node([]QEEAttribute{NodeTypeInterface.QEEAttribute(), {Key: "name", Value: QEStringVal("n_member")}})
+
+	var lagResult struct {
+		Items []applicationPointLagRow `json:"items"`
+	}
+	err = lagQuery.Do(ctx, &lagResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying LAG membership in blueprint %q - %w", o.blueprintId, err)
+	}
+
+	tags, err := o.getNodeTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vnIds, err := o.applicationPointVirtualNetworkIds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newApplicationPointInventory(linkResult.Items, lagResult.Items, tags, vnIds), nil
+}
+
+// getNodeTags returns the labels of the tags applied to each tagged graph
+// node, keyed by node ID.
+func (o *TwoStageL3ClosClient) getNodeTags(ctx context.Context) (map[ObjectId][]string, error) {
+	query := new(PathQuery).
+		SetBlueprintId(o.blueprintId).
+		SetBlueprintType(BlueprintTypeStaging).
+		SetClient(o.client).
+		Node([]QEEAttribute{NodeTypeTag.QEEAttribute(), {Key: "name", Value: QEStringVal("n_tag")}}).
+		Out([]QEEAttribute{RelationshipTypeTag.QEEAttribute()}).
+		Node([]QEEAttribute{{Key: "name", Value: QEStringVal("n_node")}})
+
+	var queryResult struct {
+		Items []struct {
+			Tag struct {
+				Label string `json:"label"`
+			} `json:"n_tag"`
+			Node struct {
+				Id ObjectId `json:"id"`
+			} `json:"n_node"`
+		} `json:"items"`
+	}
+	err := query.Do(ctx, &queryResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying tags in blueprint %q - %w", o.blueprintId, err)
+	}
+
+	result := make(map[ObjectId][]string)
+	for _, item := range queryResult.Items {
+		result[item.Node.Id] = append(result[item.Node.Id], item.Tag.Label)
+	}
+
+	return result, nil
+}
+
+// applicationPointVirtualNetworkIds returns the IDs of virtual networks bound
+// to each application point by the connectivity templates in use there.
+func (o *TwoStageL3ClosClient) applicationPointVirtualNetworkIds(ctx context.Context) (map[ObjectId][]ObjectId, error) {
+	cts, err := o.GetAllConnectivityTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching connectivity templates from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	ctVnIds := make(map[ObjectId][]ObjectId, len(cts))
+	for _, ct := range cts {
+		if ct.Id != nil {
+			ctVnIds[*ct.Id] = connectivityTemplateVirtualNetworkIds(ct.Subpolicies)
+		}
+	}
+
+	assignments, err := o.GetAllApplicationPointsConnectivityTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching connectivity template assignments from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	result := make(map[ObjectId][]ObjectId)
+	for apId, ctMap := range assignments {
+		for ctId, used := range ctMap {
+			if !used {
+				continue
+			}
+			for _, vnId := range ctVnIds[ctId] {
+				if !slices.Contains(result[apId], vnId) {
+					result[apId] = append(result[apId], vnId)
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// connectivityTemplateVirtualNetworkIds returns the IDs of virtual networks
+// referenced by single and multiple VLAN primitives within the tree.
+func connectivityTemplateVirtualNetworkIds(primitives []*ConnectivityTemplatePrimitive) []ObjectId {
+	var result []ObjectId
+	for _, primitive := range primitives {
+		switch a := primitive.Attributes.(type) {
+		case *ConnectivityTemplatePrimitiveAttributesAttachSingleVlan:
+			if a.VnNodeId != nil {
+				result = append(result, *a.VnNodeId)
+			}
+		case *ConnectivityTemplatePrimitiveAttributesAttachMultipleVlan:
+			if a.UntaggedVnNodeId != nil {
+				result = append(result, *a.UntaggedVnNodeId)
+			}
+			result = append(result, a.TaggedVnNodeIds...)
+		}
+		result = append(result, connectivityTemplateVirtualNetworkIds(primitive.Subpolicies)...)
+	}
+	return result
+}
+
+// SelectApplicationPoints returns the application points which satisfy selector.
+func (o *TwoStageL3ClosClient) SelectApplicationPoints(ctx context.Context, selector ApplicationPointSelector) ([]ApplicationPointInfo, error) {
+	err := selector.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	inventory, err := o.GetApplicationPointInventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return SelectApplicationPoints(inventory, selector), nil
+}
+
+// ApplicationPointAssignmentPlan describes changes to connectivity template
+// assignments. Both maps are keyed by application point ID and list
+// connectivity template IDs.
+type ApplicationPointAssignmentPlan struct {
+	Add    map[ObjectId][]ObjectId
+	Remove map[ObjectId][]ObjectId
+}
+
+// Empty returns true when the plan makes no changes.
+func (o *ApplicationPointAssignmentPlan) Empty() bool {
+	return len(o.Add) == 0 && len(o.Remove) == 0
+}
+
+// PlanApplicationPointAssignments compares the current assignments (as
+// returned by GetAllApplicationPointsConnectivityTemplates) with the desired
+// assignment of ctIds to apIds. When exclusive is true, ctIds are also removed
+// from application points not listed in apIds.
+func PlanApplicationPointAssignments(current map[ObjectId]map[ObjectId]bool, apIds, ctIds []ObjectId, exclusive bool) *ApplicationPointAssignmentPlan {
+	result := ApplicationPointAssignmentPlan{
+		Add:    make(map[ObjectId][]ObjectId),
+		Remove: make(map[ObjectId][]ObjectId),
+	}
+
+	for _, apId := range apIds {
+		for _, ctId := range ctIds {
+			if !current[apId][ctId] && !slices.Contains(result.Add[apId], ctId) {
+				result.Add[apId] = append(result.Add[apId], ctId)
+			}
+		}
+	}
+
+	if exclusive {
+		for apId, ctMap := range current {
+			if slices.Contains(apIds, apId) {
+				continue
+			}
+			for _, ctId := range ctIds {
+				if ctMap[ctId] && !slices.Contains(result.Remove[apId], ctId) {
+					result.Remove[apId] = append(result.Remove[apId], ctId)
+				}
+			}
+		}
+	}
+
+	return &result
+}
+
+// PlanConnectivityTemplateAssignments previews assigning ctIds to the
+// application points matched by selector. When exclusive is true, the plan
+// also removes ctIds from application points which are not matched. Use
+// ApplyApplicationPointAssignmentPlan to make the changes.
+func (o *TwoStageL3ClosClient) PlanConnectivityTemplateAssignments(ctx context.Context, selector ApplicationPointSelector, ctIds []ObjectId, exclusive bool) (*ApplicationPointAssignmentPlan, error) {
+	aps, err := o.SelectApplicationPoints(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := o.GetAllApplicationPointsConnectivityTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching connectivity template assignments from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	apIds := make([]ObjectId, len(aps))
+	for i, ap := range aps {
+		apIds[i] = ap.Id
+	}
+
+	return PlanApplicationPointAssignments(current, apIds, ctIds, exclusive), nil
+}
+
+// ApplyApplicationPointAssignmentPlan makes the changes described by plan.
+func (o *TwoStageL3ClosClient) ApplyApplicationPointAssignmentPlan(ctx context.Context, plan *ApplicationPointAssignmentPlan) error {
+	if plan.Empty() {
+		return nil
+	}
+
+	assignments := make(map[ObjectId]map[ObjectId]bool)
+	for used, m := range map[bool]map[ObjectId][]ObjectId{true: plan.Add, false: plan.Remove} {
+		for apId, ctIds := range m {
+			if assignments[apId] == nil {
+				assignments[apId] = make(map[ObjectId]bool)
+			}
+			for _, ctId := range ctIds {
+				assignments[apId][ctId] = used
+			}
+		}
+	}
+
+	return o.SetApplicationPointsConnectivityTemplates(ctx, assignments)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplicationPointSelector(t *testing.T) {
+	link := func(sysId, sysLabel, sysRole, ifId, ifName, linkRole, peerId, peerLabel, peerRole, peerType string) applicationPointLinkRow {
+		var row applicationPointLinkRow
+		row.System.Id, row.System.Label, row.System.Role = ObjectId(sysId), sysLabel, sysRole
+		row.Interface.Id, row.Interface.IfName = ObjectId(ifId), ifName
+		row.Link.Id, row.Link.Role = ObjectId("link_"+ifId), linkRole
+		row.PeerInterface.Id = ObjectId("peer_" + ifId)
+		row.PeerSystem.Id, row.PeerSystem.Label, row.PeerSystem.Role, row.PeerSystem.SystemType = ObjectId(peerId), peerLabel, peerRole, peerType
+		return row
+	}
+
+	lag := func(lagId, lagName, memberId string) applicationPointLagRow {
+		var row applicationPointLagRow
+		row.Lag.Id, row.Lag.IfName = ObjectId(lagId), lagName
+		row.Member.Id = ObjectId(memberId)
+		return row
+	}
+
+	links := []applicationPointLinkRow{
+		link("l1", "leaf1", "leaf", "l1e1", "xe-0/0/1", "to_generic", "gs1", "server1", "generic", "server"),
+		link("l1", "leaf1", "leaf", "l1e2", "xe-0/0/2", "to_generic", "gs2", "firewall", "generic", "server"),
+		link("l1", "leaf1", "leaf", "l1e3", "xe-0/0/3", "to_generic", "gs2", "firewall", "generic", "server"),
+		link("l1", "leaf1", "leaf", "l1e48", "xe-0/0/48", "spine_leaf", "s1", "spine1", "spine", "switch"), // fabric: ignored
+		link("l2", "leaf2", "leaf", "l2e1", "xe-0/0/1", "to_external_router", "ext1", "router", "external_router", "external"),
+	}
+	// interface -> link <- interface also matches the starting interface; such rows must be ignored
+	self := link("l2", "leaf2", "leaf", "l2e9", "xe-0/0/9", "leaf_leaf", "l2", "leaf2", "leaf", "server")
+	self.PeerInterface.Id = self.Interface.Id
+	links = append(links, self)
+
+	lags := []applicationPointLagRow{
+		lag("l1ae1", "ae1", "l1e2"),
+		lag("l1ae1", "ae1", "l1e3"),
+	}
+
+	tags := map[ObjectId][]string{
+		"l1":   {"rack7"},
+		"l2":   {"rack8"},
+		"gs2":  {"firewall", "prod"},
+		"l1e1": {"uplink"},
+	}
+
+	vnIds := map[ObjectId][]ObjectId{"l1e1": {"vn1"}}
+
+	inventory := newApplicationPointInventory(links, lags, tags, vnIds)
+	require.Len(t, inventory, 3)
+
+	ids := func(aps []ApplicationPointInfo) []ObjectId {
+		var result []ObjectId
+		for _, ap := range aps {
+			result = append(result, ap.Id)
+		}
+		return result
+	}
+	require.Equal(t, []ObjectId{"l1ae1", "l1e1", "l2e1"}, ids(inventory))
+
+	ae1 := inventory[0]
+	require.True(t, ae1.Lag)
+	require.Equal(t, "ae1", ae1.IfName)
+	require.ElementsMatch(t, []ObjectId{"l1e2", "l1e3"}, ae1.LagMemberIds)
+	require.Equal(t, []ObjectId{"gs2"}, ae1.PeerSystemIds)
+	require.Equal(t, []string{"firewall", "prod"}, ae1.PeerSystemTags)
+	require.Equal(t, []string{"rack7"}, ae1.SystemTags)
+
+	type testCase struct {
+		selector ApplicationPointSelector
+		expected []ObjectId
+	}
+
+	testCases := map[string]testCase{
+		"everything": {
+			selector: ApplicationPointSelector{},
+			expected: []ObjectId{"l1ae1", "l1e1", "l2e1"},
+		},
+		"server_ports_rack7": {
+			selector: ApplicationPointSelector{SystemTags: []string{"rack7"}, LinkRoles: []enum.LinkRole{enum.LinkRoleToGeneric}},
+			expected: []ObjectId{"l1ae1", "l1e1"},
+		},
+		"generic_system_by_label": {
+			selector: ApplicationPointSelector{PeerSystemLabels: []string{"fire*"}},
+			expected: []ObjectId{"l1ae1"},
+		},
+		"peer_tags_all_required": {
+			selector: ApplicationPointSelector{PeerSystemTags: []string{"firewall", "dev"}},
+		},
+		"non_lag_on_leafs": {
+			selector: ApplicationPointSelector{Lag: pointer.To(false), SystemRoles: []enum.SystemNodeRole{enum.SystemNodeRoleLeaf}},
+			expected: []ObjectId{"l1e1", "l2e1"},
+		},
+		"external_peers": {
+			selector: ApplicationPointSelector{PeerSystemRoles: []enum.SystemNodeRole{{Value: "external_router"}}},
+			expected: []ObjectId{"l2e1"},
+		},
+		"vlan_binding_and_interface_tag": {
+			selector: ApplicationPointSelector{VirtualNetworkIds: []ObjectId{"vn1", "vn2"}, InterfaceTags: []string{"uplink"}, InterfaceNames: []string{"xe-0/0/*"}},
+			expected: []ObjectId{"l1e1"},
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			require.NoError(t, tCase.selector.Validate())
+			require.Equal(t, tCase.expected, ids(SelectApplicationPoints(inventory, tCase.selector)))
+		})
+	}
+
+	require.Error(t, ApplicationPointSelector{SystemLabels: []string{"leaf["}}.Validate())
+}
+
+func TestPlanApplicationPointAssignments(t *testing.T) {
+	current := map[ObjectId]map[ObjectId]bool{
+		"ap1": {"ct1": true, "ct2": false},
+		"ap2": {"ct1": false, "ct2": true},
+		"ap3": {"ct1": true, "ct2": true},
+	}
+
+	plan := PlanApplicationPointAssignments(current, []ObjectId{"ap1", "ap2", "ap4"}, []ObjectId{"ct1"}, false)
+	require.Equal(t, map[ObjectId][]ObjectId{"ap2": {"ct1"}, "ap4": {"ct1"}}, plan.Add)
+	require.Empty(t, plan.Remove)
+	require.False(t, plan.Empty())
+
+	plan = PlanApplicationPointAssignments(current, []ObjectId{"ap1", "ap2"}, []ObjectId{"ct1", "ct2"}, true)
+	require.Equal(t, map[ObjectId][]ObjectId{"ap1": {"ct2"}, "ap2": {"ct1"}}, plan.Add)
+	require.Equal(t, map[ObjectId][]ObjectId{"ap3": {"ct1", "ct2"}}, plan.Remove)
+
+	plan = PlanApplicationPointAssignments(current, []ObjectId{"ap3"}, []ObjectId{"ct1", "ct2"}, false)
+	require.True(t, plan.Empty())
+}
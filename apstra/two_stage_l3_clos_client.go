@@ -448,6 +448,12 @@ func (o *TwoStageL3ClosClient) GetIbaProbeState(ctx context.Context, id ObjectId
 	return probe, err
 }
 
+// GetIbaProbeStageData returns the current output of the named stage of the
+// IBA Probe that matches the ID
+func (o *TwoStageL3ClosClient) GetIbaProbeStageData(ctx context.Context, id ObjectId, stage string) ([]IbaStageItem, error) {
+	return o.client.getIbaProbeStageData(ctx, o.blueprintId, id, stage)
+}
+
 // DeleteIbaProbe deletes an IBA Probe
 func (o *TwoStageL3ClosClient) DeleteIbaProbe(ctx context.Context, id ObjectId) error {
 	return o.client.deleteIbaProbe(ctx, o.blueprintId, id)
@@ -0,0 +1,811 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/Juniper/apstra-go-sdk/yaml"
+)
+
+// ConnectivityTemplateSpec is a human-friendly description of a Connectivity
+// Template, suitable for authoring in YAML or JSON and for storing in version
+// control. Primitives are arranged as a tree and refer to virtual networks,
+// routing zones, routing policies and routing zone constraints by label
+// rather than by ID, so a spec can be compiled into any blueprint in which
+// those labels exist. Example:
+//
+//	label: bgp-to-firewall
+//	primitives:
+//	  - ip_link:
+//	      routing_zone: blue
+//	      tagged: true
+//	      vlan: 10
+//	      ipv4_addressing: numbered
+//	    children:
+//	      - bgp_peering_generic_system:
+//	          ipv4_safi: true
+//	          session_addressing_ipv4: addressed
+//	          peer_to: interface_or_ip_endpoint
+//	        children:
+//	          - routing_policy:
+//	              routing_policy: firewall-in
+type ConnectivityTemplateSpec struct {
+	Label       string                              `json:"label"`
+	Description string                              `json:"description,omitempty"`
+	Tags        []string                            `json:"tags,omitempty"`
+	Primitives  []ConnectivityTemplatePrimitiveSpec `json:"primitives,omitempty"`
+}
+
+// ConnectivityTemplatePrimitiveSpec describes a single CT primitive and its
+// children. Exactly one of the primitive-specific fields must be set.
+type ConnectivityTemplatePrimitiveSpec struct {
+	Label string `json:"label,omitempty"`
+
+	SingleVlan              *CtSingleVlanSpec              `json:"single_vlan,omitempty"`
+	MultipleVlan            *CtMultipleVlanSpec            `json:"multiple_vlan,omitempty"`
+	IpLink                  *CtIpLinkSpec                  `json:"ip_link,omitempty"`
+	StaticRoute             *CtStaticRouteSpec             `json:"static_route,omitempty"`
+	CustomStaticRoute       *CtCustomStaticRouteSpec       `json:"custom_static_route,omitempty"`
+	BgpPeeringIpEndpoint    *CtBgpPeeringIpEndpointSpec    `json:"bgp_peering_ip_endpoint,omitempty"`
+	BgpPeeringGenericSystem *CtBgpPeeringGenericSystemSpec `json:"bgp_peering_generic_system,omitempty"`
+	DynamicBgpPeering       *CtDynamicBgpPeeringSpec       `json:"dynamic_bgp_peering,omitempty"`
+	RoutingPolicy           *CtRoutingPolicySpec           `json:"routing_policy,omitempty"`
+	RoutingZoneConstraint   *CtRoutingZoneConstraintSpec   `json:"routing_zone_constraint,omitempty"`
+
+	Children []ConnectivityTemplatePrimitiveSpec `json:"children,omitempty"`
+}
+
+// CtSingleVlanSpec describes an AttachSingleVlan primitive.
+type CtSingleVlanSpec struct {
+	VirtualNetwork string `json:"virtual_network"`
+	Tagged         bool   `json:"tagged,omitempty"`
+}
+
+// CtMultipleVlanSpec describes an AttachMultipleVlan primitive.
+type CtMultipleVlanSpec struct {
+	UntaggedVirtualNetwork string   `json:"untagged_virtual_network,omitempty"`
+	TaggedVirtualNetworks  []string `json:"tagged_virtual_networks,omitempty"`
+}
+
+// CtIpLinkSpec describes an AttachLogicalLink primitive.
+type CtIpLinkSpec struct {
+	RoutingZone    string  `json:"routing_zone"`
+	Tagged         bool    `json:"tagged,omitempty"`
+	Vlan           *uint16 `json:"vlan,omitempty"`
+	Ipv4Addressing string  `json:"ipv4_addressing,omitempty"` // none, numbered
+	Ipv6Addressing string  `json:"ipv6_addressing,omitempty"` // none, numbered, link_local
+	L3Mtu          *uint16 `json:"l3_mtu,omitempty"`
+}
+
+// CtStaticRouteSpec describes an AttachStaticRoute primitive.
+type CtStaticRouteSpec struct {
+	Network         string `json:"network"`
+	ShareIpEndpoint bool   `json:"share_ip_endpoint,omitempty"`
+}
+
+// CtCustomStaticRouteSpec describes an AttachCustomStaticRoute primitive.
+type CtCustomStaticRouteSpec struct {
+	RoutingZone string `json:"routing_zone"`
+	Network     string `json:"network"`
+	NextHop     string `json:"next_hop"`
+}
+
+// CtBgpPeeringIpEndpointSpec describes an AttachIpEndpointWithBgpNsxt primitive.
+type CtBgpPeeringIpEndpointSpec struct {
+	Asn                *uint32 `json:"asn,omitempty"`
+	LocalAsn           *uint32 `json:"local_asn,omitempty"`
+	NeighborAsnDynamic bool    `json:"neighbor_asn_dynamic,omitempty"`
+	Ipv4Addr           string  `json:"ipv4_addr,omitempty"`
+	Ipv6Addr           string  `json:"ipv6_addr,omitempty"`
+	Ipv4Safi           bool    `json:"ipv4_safi,omitempty"`
+	Ipv6Safi           bool    `json:"ipv6_safi,omitempty"`
+	Bfd                bool    `json:"bfd,omitempty"`
+	Keepalive          *uint16 `json:"keepalive,omitempty"`
+	Holdtime           *uint16 `json:"holdtime,omitempty"`
+	Password           *string `json:"password,omitempty"`
+	Ttl                uint8   `json:"ttl,omitempty"`
+}
+
+// CtBgpPeeringGenericSystemSpec describes an AttachBgpOverSubinterfacesOrSvi primitive.
+type CtBgpPeeringGenericSystemSpec struct {
+	LocalAsn              *uint32 `json:"local_asn,omitempty"`
+	NeighborAsnDynamic    bool    `json:"neighbor_asn_dynamic,omitempty"`
+	PeerFromLoopback      bool    `json:"peer_from_loopback,omitempty"`
+	PeerTo                string  `json:"peer_to,omitempty"`                 // loopback, interface_or_ip_endpoint, interface_or_shared_ip_endpoint
+	SessionAddressingIpv4 string  `json:"session_addressing_ipv4,omitempty"` // none, addressed
+	SessionAddressingIpv6 string  `json:"session_addressing_ipv6,omitempty"` // none, addressed, link_local
+	Ipv4Safi              bool    `json:"ipv4_safi,omitempty"`
+	Ipv6Safi              bool    `json:"ipv6_safi,omitempty"`
+	Bfd                   bool    `json:"bfd,omitempty"`
+	Keepalive             *uint16 `json:"keepalive,omitempty"`
+	Holdtime              *uint16 `json:"holdtime,omitempty"`
+	Password              *string `json:"password,omitempty"`
+	Ttl                   uint8   `json:"ttl,omitempty"`
+}
+
+// CtDynamicBgpPeeringSpec describes an AttachBgpWithPrefixPeeringForSviOrSubinterface primitive.
+type CtDynamicBgpPeeringSpec struct {
+	LocalAsn              *uint32 `json:"local_asn,omitempty"`
+	PrefixNeighborIpv4    string  `json:"prefix_neighbor_ipv4,omitempty"`
+	PrefixNeighborIpv6    string  `json:"prefix_neighbor_ipv6,omitempty"`
+	SessionAddressingIpv4 bool    `json:"session_addressing_ipv4,omitempty"`
+	SessionAddressingIpv6 bool    `json:"session_addressing_ipv6,omitempty"`
+	Ipv4Safi              bool    `json:"ipv4_safi,omitempty"`
+	Ipv6Safi              bool    `json:"ipv6_safi,omitempty"`
+	Bfd                   bool    `json:"bfd,omitempty"`
+	Keepalive             *uint16 `json:"keepalive,omitempty"`
+	Holdtime              *uint16 `json:"holdtime,omitempty"`
+	Password              *string `json:"password,omitempty"`
+	Ttl                   uint8   `json:"ttl,omitempty"`
+}
+
+// CtRoutingPolicySpec describes an AttachExistingRoutingPolicy primitive.
+type CtRoutingPolicySpec struct {
+	RoutingPolicy string `json:"routing_policy"`
+}
+
+// CtRoutingZoneConstraintSpec describes an AttachRoutingZoneConstraint primitive.
+type CtRoutingZoneConstraintSpec struct {
+	RoutingZoneConstraint string `json:"routing_zone_constraint"`
+}
+
+// ctSpecRoot stands in for the CT's root batch when checking which
+// primitives may appear where.
+const ctSpecRoot = CtPrimitivePolicyTypeNameBatch
+
+// ctSpecKeys are the spec keys of each primitive type, used in error messages.
+var ctSpecKeys = map[CtPrimitivePolicyTypeName]string{
+	ctSpecRoot: "the connectivity template root",
+	CtPrimitivePolicyTypeNameAttachSingleVlan:                               "single_vlan",
+	CtPrimitivePolicyTypeNameAttachMultipleVlan:                             "multiple_vlan",
+	CtPrimitivePolicyTypeNameAttachLogicalLink:                              "ip_link",
+	CtPrimitivePolicyTypeNameAttachStaticRoute:                              "static_route",
+	CtPrimitivePolicyTypeNameAttachCustomStaticRoute:                        "custom_static_route",
+	CtPrimitivePolicyTypeNameAttachIpEndpointWithBgpNsxt:                    "bgp_peering_ip_endpoint",
+	CtPrimitivePolicyTypeNameAttachBgpOverSubinterfacesOrSvi:                "bgp_peering_generic_system",
+	CtPrimitivePolicyTypeNameAttachBgpWithPrefixPeeringForSviOrSubinterface: "dynamic_bgp_peering",
+	CtPrimitivePolicyTypeNameAttachExistingRoutingPolicy:                    "routing_policy",
+	CtPrimitivePolicyTypeNameAttachRoutingZoneConstraint:                    "routing_zone_constraint",
+}
+
+// ctSpecAllowedChildren lists the primitive types which may appear beneath
+// each primitive type. Types which do not appear as keys may not have children.
+var ctSpecAllowedChildren = map[CtPrimitivePolicyTypeName][]CtPrimitivePolicyTypeName{
+	ctSpecRoot: {
+		CtPrimitivePolicyTypeNameAttachSingleVlan,
+		CtPrimitivePolicyTypeNameAttachMultipleVlan,
+		CtPrimitivePolicyTypeNameAttachLogicalLink,
+		CtPrimitivePolicyTypeNameAttachCustomStaticRoute,
+		CtPrimitivePolicyTypeNameAttachRoutingZoneConstraint,
+	},
+	CtPrimitivePolicyTypeNameAttachSingleVlan: {
+		CtPrimitivePolicyTypeNameAttachStaticRoute,
+		CtPrimitivePolicyTypeNameAttachIpEndpointWithBgpNsxt,
+		CtPrimitivePolicyTypeNameAttachBgpOverSubinterfacesOrSvi,
+		CtPrimitivePolicyTypeNameAttachBgpWithPrefixPeeringForSviOrSubinterface,
+	},
+	CtPrimitivePolicyTypeNameAttachLogicalLink: {
+		CtPrimitivePolicyTypeNameAttachStaticRoute,
+		CtPrimitivePolicyTypeNameAttachIpEndpointWithBgpNsxt,
+		CtPrimitivePolicyTypeNameAttachBgpOverSubinterfacesOrSvi,
+		CtPrimitivePolicyTypeNameAttachBgpWithPrefixPeeringForSviOrSubinterface,
+	},
+	CtPrimitivePolicyTypeNameAttachIpEndpointWithBgpNsxt:                    {CtPrimitivePolicyTypeNameAttachExistingRoutingPolicy},
+	CtPrimitivePolicyTypeNameAttachBgpOverSubinterfacesOrSvi:                {CtPrimitivePolicyTypeNameAttachExistingRoutingPolicy},
+	CtPrimitivePolicyTypeNameAttachBgpWithPrefixPeeringForSviOrSubinterface: {CtPrimitivePolicyTypeNameAttachExistingRoutingPolicy},
+}
+
+// ConnectivityTemplateReferences maps the labels of blueprint objects which
+// may be referenced by a ConnectivityTemplateSpec to their IDs.
+type ConnectivityTemplateReferences struct {
+	VirtualNetworks        map[string]ObjectId
+	RoutingZones           map[string]ObjectId
+	RoutingPolicies        map[string]ObjectId
+	RoutingZoneConstraints map[string]ObjectId
+}
+
+// GetConnectivityTemplateReferences collects the labels and IDs of the
+// blueprint objects which may be referenced by a ConnectivityTemplateSpec.
+func (o *TwoStageL3ClosClient) GetConnectivityTemplateReferences(ctx context.Context) (*ConnectivityTemplateReferences, error) {
+	result := ConnectivityTemplateReferences{
+		VirtualNetworks:        make(map[string]ObjectId),
+		RoutingZones:           make(map[string]ObjectId),
+		RoutingPolicies:        make(map[string]ObjectId),
+		RoutingZoneConstraints: make(map[string]ObjectId),
+	}
+
+	vns, err := o.GetVirtualNetworks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching virtual networks from blueprint %q - %w", o.blueprintId, err)
+	}
+	for _, vn := range vns {
+		if vn.ID() != nil {
+			result.VirtualNetworks[vn.Label] = ObjectId(*vn.ID())
+		}
+	}
+
+	szs, err := o.GetSecurityZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching security zones from blueprint %q - %w", o.blueprintId, err)
+	}
+	for _, sz := range szs {
+		if sz.ID() != nil {
+			result.RoutingZones[sz.Label] = ObjectId(*sz.ID())
+		}
+	}
+
+	rps, err := o.GetAllRoutingPolicies(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching routing policies from blueprint %q - %w", o.blueprintId, err)
+	}
+	for _, rp := range rps {
+		if rp.Data != nil {
+			result.RoutingPolicies[rp.Data.Label] = rp.Id
+		}
+	}
+
+	rzcs, err := o.GetAllRoutingZoneConstraints(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching routing zone constraints from blueprint %q - %w", o.blueprintId, err)
+	}
+	for _, rzc := range rzcs {
+		if rzc.Data != nil {
+			result.RoutingZoneConstraints[rzc.Data.Label] = rzc.Id
+		}
+	}
+
+	return &result, nil
+}
+
+// ParseConnectivityTemplateSpec decodes a YAML or JSON (JSON is a subset of
+// YAML) connectivity template spec. Unknown keys produce an error.
+func ParseConnectivityTemplateSpec(data []byte) (*ConnectivityTemplateSpec, error) {
+	var result ConnectivityTemplateSpec
+	err := yaml.Unmarshal(data, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing connectivity template spec - %w", err)
+	}
+
+	return &result, nil
+}
+
+// Validate checks the structure of the spec and, when refs is not nil, that
+// every label it references exists. All problems found are returned together.
+func (o *ConnectivityTemplateSpec) Validate(refs *ConnectivityTemplateReferences) error {
+	_, err := o.compile(refs)
+	return err
+}
+
+// Compile validates the spec and converts it into a ConnectivityTemplate with
+// IDs and web UI layout populated, ready for CreateConnectivityTemplate.
+func (o *ConnectivityTemplateSpec) Compile(refs *ConnectivityTemplateReferences) (*ConnectivityTemplate, error) {
+	if refs == nil {
+		return nil, errors.New("cannot compile connectivity template spec without references")
+	}
+
+	result, err := o.compile(refs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = result.SetIds()
+	if err != nil {
+		return nil, err
+	}
+
+	err = result.SetUserData()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (o *ConnectivityTemplateSpec) compile(refs *ConnectivityTemplateReferences) (*ConnectivityTemplate, error) {
+	c := ctSpecCompiler{refs: refs}
+
+	if o.Label == "" {
+		c.errorf("label is required")
+	}
+
+	result := ConnectivityTemplate{
+		Label:       o.Label,
+		Description: o.Description,
+		Tags:        o.Tags,
+		Subpolicies: c.primitives(ctSpecRoot, "primitives", o.Primitives),
+	}
+
+	if len(c.errs) > 0 {
+		return nil, fmt.Errorf("invalid connectivity template spec %q - %w", o.Label, errors.Join(c.errs...))
+	}
+
+	return &result, nil
+}
+
+// kind returns the primitive type described by o.
+func (o *ConnectivityTemplatePrimitiveSpec) kind() (CtPrimitivePolicyTypeName, error) {
+	var kinds []CtPrimitivePolicyTypeName
+	for kind, set := range map[CtPrimitivePolicyTypeName]bool{
+		CtPrimitivePolicyTypeNameAttachSingleVlan:                               o.SingleVlan != nil,
+		CtPrimitivePolicyTypeNameAttachMultipleVlan:                             o.MultipleVlan != nil,
+		CtPrimitivePolicyTypeNameAttachLogicalLink:                              o.IpLink != nil,
+		CtPrimitivePolicyTypeNameAttachStaticRoute:                              o.StaticRoute != nil,
+		CtPrimitivePolicyTypeNameAttachCustomStaticRoute:                        o.CustomStaticRoute != nil,
+		CtPrimitivePolicyTypeNameAttachIpEndpointWithBgpNsxt:                    o.BgpPeeringIpEndpoint != nil,
+		CtPrimitivePolicyTypeNameAttachBgpOverSubinterfacesOrSvi:                o.BgpPeeringGenericSystem != nil,
+		CtPrimitivePolicyTypeNameAttachBgpWithPrefixPeeringForSviOrSubinterface: o.DynamicBgpPeering != nil,
+		CtPrimitivePolicyTypeNameAttachExistingRoutingPolicy:                    o.RoutingPolicy != nil,
+		CtPrimitivePolicyTypeNameAttachRoutingZoneConstraint:                    o.RoutingZoneConstraint != nil,
+	} {
+		if set {
+			kinds = append(kinds, kind)
+		}
+	}
+
+	switch len(kinds) {
+	case 0:
+		return CtPrimitivePolicyTypeNameNone, errors.New("no primitive type specified")
+	case 1:
+		return kinds[0], nil
+	default:
+		keys := make([]string, len(kinds))
+		for i, kind := range kinds {
+			keys[i] = ctSpecKeys[kind]
+		}
+		slices.Sort(keys)
+		return CtPrimitivePolicyTypeNameNone, fmt.Errorf("exactly one primitive type must be specified, got %s", strings.Join(keys, ", "))
+	}
+}
+
+// ctSpecCompiler accumulates errors, tagged with their location in the spec,
+// while converting a ConnectivityTemplateSpec.
+type ctSpecCompiler struct {
+	refs *ConnectivityTemplateReferences
+	path []string
+	errs []error
+}
+
+func (c *ctSpecCompiler) errorf(format string, a ...any) {
+	err := fmt.Errorf(format, a...)
+	if len(c.path) > 0 {
+		err = fmt.Errorf("%s: %w", strings.Join(c.path, "."), err)
+	}
+	c.errs = append(c.errs, err)
+}
+
+func (c *ctSpecCompiler) primitives(parent CtPrimitivePolicyTypeName, field string, in []ConnectivityTemplatePrimitiveSpec) []*ConnectivityTemplatePrimitive {
+	if len(in) == 0 {
+		return nil
+	}
+
+	var result []*ConnectivityTemplatePrimitive
+	for i := range in {
+		c.path = append(c.path, fmt.Sprintf("%s[%d]", field, i))
+		primitive := c.primitive(parent, &in[i])
+		if primitive != nil {
+			result = append(result, primitive)
+		}
+		c.path = c.path[:len(c.path)-1]
+	}
+
+	return result
+}
+
+func (c *ctSpecCompiler) primitive(parent CtPrimitivePolicyTypeName, in *ConnectivityTemplatePrimitiveSpec) *ConnectivityTemplatePrimitive {
+	kind, err := in.kind()
+	if err != nil {
+		c.errorf("%s", err)
+		return nil
+	}
+
+	allowed := false
+	for _, k := range ctSpecAllowedChildren[parent] {
+		allowed = allowed || k == kind
+	}
+	if !allowed {
+		c.errorf("%s is not permitted beneath %s", ctSpecKeys[kind], ctSpecKeys[parent])
+	}
+
+	var attributes ConnectivityTemplatePrimitiveAttributes
+	switch kind {
+	case CtPrimitivePolicyTypeNameAttachSingleVlan:
+		attributes = &ConnectivityTemplatePrimitiveAttributesAttachSingleVlan{
+			Tagged:   in.SingleVlan.Tagged,
+			VnNodeId: c.ref("virtual network", c.refs.virtualNetworks(), in.SingleVlan.VirtualNetwork, true),
+		}
+	case CtPrimitivePolicyTypeNameAttachMultipleVlan:
+		a := ConnectivityTemplatePrimitiveAttributesAttachMultipleVlan{
+			UntaggedVnNodeId: c.ref("virtual network", c.refs.virtualNetworks(), in.MultipleVlan.UntaggedVirtualNetwork, false),
+		}
+		for _, label := range in.MultipleVlan.TaggedVirtualNetworks {
+			if id := c.ref("virtual network", c.refs.virtualNetworks(), label, true); id != nil {
+				a.TaggedVnNodeIds = append(a.TaggedVnNodeIds, *id)
+			}
+		}
+		if a.UntaggedVnNodeId == nil && len(in.MultipleVlan.TaggedVirtualNetworks) == 0 {
+			c.errorf("multiple_vlan requires at least one virtual network")
+		}
+		attributes = &a
+	case CtPrimitivePolicyTypeNameAttachLogicalLink:
+		a := ConnectivityTemplatePrimitiveAttributesAttachLogicalLink{
+			SecurityZone: c.ref("routing zone", c.refs.routingZones(), in.IpLink.RoutingZone, true),
+			Tagged:       in.IpLink.Tagged,
+			Vlan:         in.IpLink.Vlan,
+			L3Mtu:        in.IpLink.L3Mtu,
+		}
+		c.enum("ipv4_addressing", in.IpLink.Ipv4Addressing, &a.IPv4AddressingType)
+		c.enum("ipv6_addressing", in.IpLink.Ipv6Addressing, &a.IPv6AddressingType)
+		if a.Tagged && a.Vlan == nil {
+			c.errorf("tagged ip_link requires a vlan")
+		}
+		attributes = &a
+	case CtPrimitivePolicyTypeNameAttachStaticRoute:
+		attributes = &ConnectivityTemplatePrimitiveAttributesAttachStaticRoute{
+			ShareIpEndpoint: in.StaticRoute.ShareIpEndpoint,
+			Network:         c.cidr("network", in.StaticRoute.Network, true),
+		}
+	case CtPrimitivePolicyTypeNameAttachCustomStaticRoute:
+		attributes = &ConnectivityTemplatePrimitiveAttributesAttachCustomStaticRoute{
+			Network:      c.cidr("network", in.CustomStaticRoute.Network, true),
+			NextHop:      c.ip("next_hop", in.CustomStaticRoute.NextHop, true),
+			SecurityZone: c.ref("routing zone", c.refs.routingZones(), in.CustomStaticRoute.RoutingZone, true),
+		}
+	case CtPrimitivePolicyTypeNameAttachIpEndpointWithBgpNsxt:
+		s := in.BgpPeeringIpEndpoint
+		attributes = &ConnectivityTemplatePrimitiveAttributesAttachIpEndpointWithBgpNsxt{
+			Asn:                s.Asn,
+			Bfd:                s.Bfd,
+			Holdtime:           s.Holdtime,
+			Ipv4Addr:           c.ip("ipv4_addr", s.Ipv4Addr, false),
+			Ipv6Addr:           c.ip("ipv6_addr", s.Ipv6Addr, false),
+			Ipv4Safi:           s.Ipv4Safi,
+			Ipv6Safi:           s.Ipv6Safi,
+			Keepalive:          s.Keepalive,
+			LocalAsn:           s.LocalAsn,
+			NeighborAsnDynamic: s.NeighborAsnDynamic,
+			Password:           s.Password,
+			Ttl:                s.Ttl,
+		}
+		if s.Asn == nil && !s.NeighborAsnDynamic {
+			c.errorf("bgp_peering_ip_endpoint requires asn unless neighbor_asn_dynamic is set")
+		}
+	case CtPrimitivePolicyTypeNameAttachBgpOverSubinterfacesOrSvi:
+		s := in.BgpPeeringGenericSystem
+		a := ConnectivityTemplatePrimitiveAttributesAttachBgpOverSubinterfacesOrSvi{
+			Bfd:                s.Bfd,
+			Holdtime:           s.Holdtime,
+			Ipv4Safi:           s.Ipv4Safi,
+			Ipv6Safi:           s.Ipv6Safi,
+			Keepalive:          s.Keepalive,
+			LocalAsn:           s.LocalAsn,
+			NeighborAsnDynamic: s.NeighborAsnDynamic,
+			Password:           s.Password,
+			PeerFromLoopback:   s.PeerFromLoopback,
+			Ttl:                s.Ttl,
+		}
+		c.enum("peer_to", s.PeerTo, &a.PeerTo)
+		c.enum("session_addressing_ipv4", s.SessionAddressingIpv4, &a.SessionAddressingIpv4)
+		c.enum("session_addressing_ipv6", s.SessionAddressingIpv6, &a.SessionAddressingIpv6)
+		attributes = &a
+	case CtPrimitivePolicyTypeNameAttachBgpWithPrefixPeeringForSviOrSubinterface:
+		s := in.DynamicBgpPeering
+		attributes = &ConnectivityTemplatePrimitiveAttributesAttachBgpWithPrefixPeeringForSviOrSubinterface{
+			Bfd:                   s.Bfd,
+			Holdtime:              s.Holdtime,
+			Ipv4Safi:              s.Ipv4Safi,
+			Ipv6Safi:              s.Ipv6Safi,
+			Keepalive:             s.Keepalive,
+			LocalAsn:              s.LocalAsn,
+			Password:              s.Password,
+			PrefixNeighborIpv4:    c.cidr("prefix_neighbor_ipv4", s.PrefixNeighborIpv4, false),
+			PrefixNeighborIpv6:    c.cidr("prefix_neighbor_ipv6", s.PrefixNeighborIpv6, false),
+			SessionAddressingIpv4: s.SessionAddressingIpv4,
+			SessionAddressingIpv6: s.SessionAddressingIpv6,
+			Ttl:                   s.Ttl,
+		}
+	case CtPrimitivePolicyTypeNameAttachExistingRoutingPolicy:
+		attributes = &ConnectivityTemplatePrimitiveAttributesAttachExistingRoutingPolicy{
+			RpToAttach: c.ref("routing policy", c.refs.routingPolicies(), in.RoutingPolicy.RoutingPolicy, true),
+		}
+	case CtPrimitivePolicyTypeNameAttachRoutingZoneConstraint:
+		attributes = &ConnectivityTemplatePrimitiveAttributesAttachRoutingZoneConstraint{
+			RoutingZoneConstraint: c.ref("routing zone constraint", c.refs.routingZoneConstraints(), in.RoutingZoneConstraint.RoutingZoneConstraint, true),
+		}
+	}
+
+	return &ConnectivityTemplatePrimitive{
+		Label:       in.Label,
+		Attributes:  attributes,
+		Subpolicies: c.primitives(kind, "children", in.Children),
+	}
+}
+
+// ref looks up label in m. A nil m (no references supplied) skips the lookup
+// but still produces a placeholder ID so that structure can be validated.
+func (c *ctSpecCompiler) ref(kind string, m map[string]ObjectId, label string, required bool) *ObjectId {
+	if label == "" {
+		if required {
+			c.errorf("%s label is required", kind)
+		}
+		return nil
+	}
+
+	if c.refs == nil {
+		id := ObjectId(label)
+		return &id
+	}
+
+	id, ok := m[label]
+	if !ok {
+		c.errorf("%s %q not found", kind, label)
+		return nil
+	}
+
+	return &id
+}
+
+func (c *ctSpecCompiler) cidr(field, s string, required bool) *net.IPNet {
+	if s == "" {
+		if required {
+			c.errorf("%s is required", field)
+		}
+		return nil
+	}
+
+	_, result, err := net.ParseCIDR(s)
+	if err != nil {
+		c.errorf("%s: %s", field, err)
+		return nil
+	}
+
+	return result
+}
+
+func (c *ctSpecCompiler) ip(field, s string, required bool) net.IP {
+	if s == "" {
+		if required {
+			c.errorf("%s is required", field)
+		}
+		return nil
+	}
+
+	result := net.ParseIP(s)
+	if result == nil {
+		c.errorf("%s: invalid IP address %q", field, s)
+	}
+
+	return result
+}
+
+// enum parses s into target. An empty s leaves target at its zero value.
+func (c *ctSpecCompiler) enum(field, s string, target interface{ FromString(string) error }) {
+	if s == "" {
+		return
+	}
+
+	err := target.FromString(s)
+	if err != nil {
+		c.errorf("%s: %s", field, err)
+	}
+}
+
+func (o *ConnectivityTemplateReferences) virtualNetworks() map[string]ObjectId {
+	if o == nil {
+		return nil
+	}
+	return o.VirtualNetworks
+}
+
+func (o *ConnectivityTemplateReferences) routingZones() map[string]ObjectId {
+	if o == nil {
+		return nil
+	}
+	return o.RoutingZones
+}
+
+func (o *ConnectivityTemplateReferences) routingPolicies() map[string]ObjectId {
+	if o == nil {
+		return nil
+	}
+	return o.RoutingPolicies
+}
+
+func (o *ConnectivityTemplateReferences) routingZoneConstraints() map[string]ObjectId {
+	if o == nil {
+		return nil
+	}
+	return o.RoutingZoneConstraints
+}
+
+// NewConnectivityTemplateSpec converts an existing ConnectivityTemplate into a
+// spec, replacing object IDs with the labels found in refs. This is useful
+// for storing CTs in version control and for copying them between blueprints.
+func NewConnectivityTemplateSpec(in *ConnectivityTemplate, refs *ConnectivityTemplateReferences) (*ConnectivityTemplateSpec, error) {
+	if refs == nil {
+		return nil, errors.New("cannot export connectivity template without references")
+	}
+
+	d := ctSpecDecompiler{
+		virtualNetworks:        invertObjectIdMap(refs.VirtualNetworks),
+		routingZones:           invertObjectIdMap(refs.RoutingZones),
+		routingPolicies:        invertObjectIdMap(refs.RoutingPolicies),
+		routingZoneConstraints: invertObjectIdMap(refs.RoutingZoneConstraints),
+	}
+
+	result := ConnectivityTemplateSpec{
+		Label:       in.Label,
+		Description: in.Description,
+		Tags:        in.Tags,
+		Primitives:  d.primitives(in.Subpolicies),
+	}
+
+	if len(d.errs) > 0 {
+		return nil, fmt.Errorf("failed exporting connectivity template %q - %w", in.Label, errors.Join(d.errs...))
+	}
+
+	return &result, nil
+}
+
+func invertObjectIdMap(in map[string]ObjectId) map[ObjectId]string {
+	result := make(map[ObjectId]string, len(in))
+	for k, v := range in {
+		result[v] = k
+	}
+	return result
+}
+
+type ctSpecDecompiler struct {
+	virtualNetworks        map[ObjectId]string
+	routingZones           map[ObjectId]string
+	routingPolicies        map[ObjectId]string
+	routingZoneConstraints map[ObjectId]string
+	errs                   []error
+}
+
+func (d *ctSpecDecompiler) label(kind string, m map[ObjectId]string, id *ObjectId) string {
+	if id == nil {
+		return ""
+	}
+
+	label, ok := m[*id]
+	if !ok {
+		d.errs = append(d.errs, fmt.Errorf("%s %q not found", kind, *id))
+	}
+
+	return label
+}
+
+func (d *ctSpecDecompiler) primitives(in []*ConnectivityTemplatePrimitive) []ConnectivityTemplatePrimitiveSpec {
+	if len(in) == 0 {
+		return nil
+	}
+
+	result := make([]ConnectivityTemplatePrimitiveSpec, len(in))
+	for i, primitive := range in {
+		result[i] = d.primitive(primitive)
+	}
+
+	return result
+}
+
+func (d *ctSpecDecompiler) primitive(in *ConnectivityTemplatePrimitive) ConnectivityTemplatePrimitiveSpec {
+	result := ConnectivityTemplatePrimitiveSpec{
+		Label:    in.Label,
+		Children: d.primitives(in.Subpolicies),
+	}
+
+	ipString := func(ip net.IP) string {
+		if ip == nil {
+			return ""
+		}
+		return ip.String()
+	}
+
+	cidrString := func(n *net.IPNet) string {
+		if n == nil {
+			return ""
+		}
+		return n.String()
+	}
+
+	switch a := in.Attributes.(type) {
+	case *ConnectivityTemplatePrimitiveAttributesAttachSingleVlan:
+		result.SingleVlan = &CtSingleVlanSpec{
+			VirtualNetwork: d.label("virtual network", d.virtualNetworks, a.VnNodeId),
+			Tagged:         a.Tagged,
+		}
+	case *ConnectivityTemplatePrimitiveAttributesAttachMultipleVlan:
+		result.MultipleVlan = &CtMultipleVlanSpec{
+			UntaggedVirtualNetwork: d.label("virtual network", d.virtualNetworks, a.UntaggedVnNodeId),
+		}
+		for _, id := range a.TaggedVnNodeIds {
+			result.MultipleVlan.TaggedVirtualNetworks = append(result.MultipleVlan.TaggedVirtualNetworks, d.label("virtual network", d.virtualNetworks, &id))
+		}
+	case *ConnectivityTemplatePrimitiveAttributesAttachLogicalLink:
+		result.IpLink = &CtIpLinkSpec{
+			RoutingZone:    d.label("routing zone", d.routingZones, a.SecurityZone),
+			Tagged:         a.Tagged,
+			Vlan:           a.Vlan,
+			Ipv4Addressing: a.IPv4AddressingType.String(),
+			Ipv6Addressing: a.IPv6AddressingType.String(),
+			L3Mtu:          a.L3Mtu,
+		}
+	case *ConnectivityTemplatePrimitiveAttributesAttachStaticRoute:
+		result.StaticRoute = &CtStaticRouteSpec{
+			Network:         cidrString(a.Network),
+			ShareIpEndpoint: a.ShareIpEndpoint,
+		}
+	case *ConnectivityTemplatePrimitiveAttributesAttachCustomStaticRoute:
+		result.CustomStaticRoute = &CtCustomStaticRouteSpec{
+			RoutingZone: d.label("routing zone", d.routingZones, a.SecurityZone),
+			Network:     cidrString(a.Network),
+			NextHop:     ipString(a.NextHop),
+		}
+	case *ConnectivityTemplatePrimitiveAttributesAttachIpEndpointWithBgpNsxt:
+		result.BgpPeeringIpEndpoint = &CtBgpPeeringIpEndpointSpec{
+			Asn:                a.Asn,
+			LocalAsn:           a.LocalAsn,
+			NeighborAsnDynamic: a.NeighborAsnDynamic,
+			Ipv4Addr:           ipString(a.Ipv4Addr),
+			Ipv6Addr:           ipString(a.Ipv6Addr),
+			Ipv4Safi:           a.Ipv4Safi,
+			Ipv6Safi:           a.Ipv6Safi,
+			Bfd:                a.Bfd,
+			Keepalive:          a.Keepalive,
+			Holdtime:           a.Holdtime,
+			Password:           a.Password,
+			Ttl:                a.Ttl,
+		}
+	case *ConnectivityTemplatePrimitiveAttributesAttachBgpOverSubinterfacesOrSvi:
+		result.BgpPeeringGenericSystem = &CtBgpPeeringGenericSystemSpec{
+			LocalAsn:              a.LocalAsn,
+			NeighborAsnDynamic:    a.NeighborAsnDynamic,
+			PeerFromLoopback:      a.PeerFromLoopback,
+			PeerTo:                a.PeerTo.String(),
+			SessionAddressingIpv4: a.SessionAddressingIpv4.String(),
+			SessionAddressingIpv6: a.SessionAddressingIpv6.String(),
+			Ipv4Safi:              a.Ipv4Safi,
+			Ipv6Safi:              a.Ipv6Safi,
+			Bfd:                   a.Bfd,
+			Keepalive:             a.Keepalive,
+			Holdtime:              a.Holdtime,
+			Password:              a.Password,
+			Ttl:                   a.Ttl,
+		}
+	case *ConnectivityTemplatePrimitiveAttributesAttachBgpWithPrefixPeeringForSviOrSubinterface:
+		result.DynamicBgpPeering = &CtDynamicBgpPeeringSpec{
+			LocalAsn:              a.LocalAsn,
+			PrefixNeighborIpv4:    cidrString(a.PrefixNeighborIpv4),
+			PrefixNeighborIpv6:    cidrString(a.PrefixNeighborIpv6),
+			SessionAddressingIpv4: a.SessionAddressingIpv4,
+			SessionAddressingIpv6: a.SessionAddressingIpv6,
+			Ipv4Safi:              a.Ipv4Safi,
+			Ipv6Safi:              a.Ipv6Safi,
+			Bfd:                   a.Bfd,
+			Keepalive:             a.Keepalive,
+			Holdtime:              a.Holdtime,
+			Password:              a.Password,
+			Ttl:                   a.Ttl,
+		}
+	case *ConnectivityTemplatePrimitiveAttributesAttachExistingRoutingPolicy:
+		result.RoutingPolicy = &CtRoutingPolicySpec{
+			RoutingPolicy: d.label("routing policy", d.routingPolicies, a.RpToAttach),
+		}
+	case *ConnectivityTemplatePrimitiveAttributesAttachRoutingZoneConstraint:
+		result.RoutingZoneConstraint = &CtRoutingZoneConstraintSpec{
+			RoutingZoneConstraint: d.label("routing zone constraint", d.routingZoneConstraints, a.RoutingZoneConstraint),
+		}
+	default:
+		d.errs = append(d.errs, fmt.Errorf("unsupported connectivity template primitive attributes %T", in.Attributes))
+	}
+
+	return result
+}
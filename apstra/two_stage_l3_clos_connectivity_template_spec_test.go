@@ -0,0 +1,219 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/yaml"
+	"github.com/stretchr/testify/require"
+)
+
+func testConnectivityTemplateReferences() *ConnectivityTemplateReferences {
+	return &ConnectivityTemplateReferences{
+		VirtualNetworks:        map[string]ObjectId{"web": "vn-web", "db": "vn-db", "mgmt": "vn-mgmt"},
+		RoutingZones:           map[string]ObjectId{"blue": "sz-blue"},
+		RoutingPolicies:        map[string]ObjectId{"firewall-in": "rp-fw"},
+		RoutingZoneConstraints: map[string]ObjectId{"one-vrf": "rzc-1"},
+	}
+}
+
+const testConnectivityTemplateSpec = `
+label: bgp-to-firewall
+description: firewall uplink
+tags: [fw]
+primitives:
+  - label: uplink
+    ip_link:
+      routing_zone: blue
+      tagged: true
+      vlan: 10
+      ipv4_addressing: numbered
+      ipv6_addressing: link_local
+    children:
+      - bgp_peering_generic_system:
+          ipv4_safi: true
+          session_addressing_ipv4: addressed
+          peer_to: interface_or_ip_endpoint
+          keepalive: 10
+          holdtime: 30
+        children:
+          - routing_policy:
+              routing_policy: firewall-in
+      - static_route:
+          network: 192.168.0.0/16
+  - multiple_vlan:
+      untagged_virtual_network: mgmt
+      tagged_virtual_networks: [web, db]
+  - routing_zone_constraint:
+      routing_zone_constraint: one-vrf
+`
+
+func TestConnectivityTemplateSpec_Compile(t *testing.T) {
+	refs := testConnectivityTemplateReferences()
+
+	spec, err := ParseConnectivityTemplateSpec([]byte(testConnectivityTemplateSpec))
+	require.NoError(t, err)
+
+	ct, err := spec.Compile(refs)
+	require.NoError(t, err)
+	require.NotNil(t, ct.Id)
+	require.NotNil(t, ct.UserData)
+	require.Equal(t, "bgp-to-firewall", ct.Label)
+	require.Len(t, ct.Subpolicies, 3)
+
+	ipLink := ct.Subpolicies[0]
+	require.Equal(t, "uplink", ipLink.Label)
+	require.IsType(t, &ConnectivityTemplatePrimitiveAttributesAttachLogicalLink{}, ipLink.Attributes)
+	ll := ipLink.Attributes.(*ConnectivityTemplatePrimitiveAttributesAttachLogicalLink)
+	require.Equal(t, ObjectId("sz-blue"), *ll.SecurityZone)
+	require.Equal(t, CtPrimitiveIPv4AddressingTypeNumbered, ll.IPv4AddressingType)
+	require.Equal(t, CtPrimitiveIPv6AddressingTypeLinkLocal, ll.IPv6AddressingType)
+	require.Len(t, ipLink.Subpolicies, 2)
+	require.NotNil(t, ipLink.BatchId)
+
+	bgp := ipLink.Subpolicies[0]
+	require.Equal(t, CtPrimitiveBgpPeerToInterfaceOrIpEndpoint, bgp.Attributes.(*ConnectivityTemplatePrimitiveAttributesAttachBgpOverSubinterfacesOrSvi).PeerTo)
+	require.Equal(t, ObjectId("rp-fw"), *bgp.Subpolicies[0].Attributes.(*ConnectivityTemplatePrimitiveAttributesAttachExistingRoutingPolicy).RpToAttach)
+
+	mv := ct.Subpolicies[1].Attributes.(*ConnectivityTemplatePrimitiveAttributesAttachMultipleVlan)
+	require.Equal(t, ObjectId("vn-mgmt"), *mv.UntaggedVnNodeId)
+	require.Equal(t, []ObjectId{"vn-web", "vn-db"}, mv.TaggedVnNodeIds)
+
+	// the compiled CT must be acceptable to the API payload generator
+	raw, err := ct.raw()
+	require.NoError(t, err)
+	require.NotEmpty(t, raw.Policies)
+
+	// export, then round-trip through YAML and JSON
+	exported, err := NewConnectivityTemplateSpec(ct, refs)
+	require.NoError(t, err)
+	require.Equal(t, "blue", exported.Primitives[0].IpLink.RoutingZone)
+	require.Equal(t, "firewall-in", exported.Primitives[0].Children[0].Children[0].RoutingPolicy.RoutingPolicy)
+
+	yamlBytes, err := yaml.Marshal(exported)
+	require.NoError(t, err)
+	jsonBytes, err := json.Marshal(exported)
+	require.NoError(t, err)
+
+	for _, data := range [][]byte{yamlBytes, jsonBytes} {
+		reparsed, err := ParseConnectivityTemplateSpec(data)
+		require.NoError(t, err)
+		require.Equal(t, exported, reparsed)
+
+		recompiled, err := reparsed.Compile(refs)
+		require.NoError(t, err)
+
+		var compare func(a, b []*ConnectivityTemplatePrimitive)
+		compare = func(a, b []*ConnectivityTemplatePrimitive) {
+			require.Equal(t, len(a), len(b))
+			for i := range a {
+				require.Equal(t, a[i].Label, b[i].Label)
+				require.Equal(t, a[i].Attributes, b[i].Attributes)
+				compare(a[i].Subpolicies, b[i].Subpolicies)
+			}
+		}
+		compare(ct.Subpolicies, recompiled.Subpolicies)
+	}
+
+	// export fails when a referenced object is unknown
+	delete(refs.RoutingPolicies, "firewall-in")
+	_, err = NewConnectivityTemplateSpec(ct, refs)
+	require.ErrorContains(t, err, `routing policy "rp-fw" not found`)
+}
+
+func TestConnectivityTemplateSpec_Validate(t *testing.T) {
+	refs := testConnectivityTemplateReferences()
+
+	type testCase struct {
+		spec   string
+		errors []string
+	}
+
+	testCases := map[string]testCase{
+		"bgp_at_root": {
+			spec: `
+label: x
+primitives:
+  - bgp_peering_generic_system: {ipv4_safi: true}
+`,
+			errors: []string{"primitives[0]: bgp_peering_generic_system is not permitted beneath the connectivity template root"},
+		},
+		"routing_policy_under_ip_link": {
+			spec: `
+label: x
+primitives:
+  - ip_link: {routing_zone: blue}
+    children:
+      - routing_policy: {routing_policy: firewall-in}
+`,
+			errors: []string{"primitives[0].children[0]: routing_policy is not permitted beneath ip_link"},
+		},
+		"unknown_references": {
+			spec: `
+label: x
+primitives:
+  - single_vlan: {virtual_network: nope}
+  - ip_link: {routing_zone: red, tagged: true}
+`,
+			errors: []string{
+				`primitives[0]: virtual network "nope" not found`,
+				`primitives[1]: routing zone "red" not found`,
+				"primitives[1]: tagged ip_link requires a vlan",
+			},
+		},
+		"ambiguous_and_empty": {
+			spec: `
+primitives:
+  - single_vlan: {virtual_network: web}
+    multiple_vlan: {tagged_virtual_networks: [db]}
+  - label: empty
+`,
+			errors: []string{
+				"label is required",
+				"primitives[0]: exactly one primitive type must be specified, got multiple_vlan, single_vlan",
+				"primitives[1]: no primitive type specified",
+			},
+		},
+		"bad_values": {
+			spec: `
+label: x
+primitives:
+  - ip_link: {routing_zone: blue, ipv4_addressing: bogus}
+    children:
+      - static_route: {network: 10.0.0.0/33}
+`,
+			errors: []string{
+				"primitives[0]: ipv4_addressing:",
+				"primitives[0].children[0]: network: invalid CIDR address",
+			},
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			spec, err := ParseConnectivityTemplateSpec([]byte(tCase.spec))
+			require.NoError(t, err)
+
+			err = spec.Validate(refs)
+			require.Error(t, err)
+			for _, e := range tCase.errors {
+				require.ErrorContains(t, err, e)
+			}
+
+			_, err = spec.Compile(refs)
+			require.Error(t, err)
+		})
+	}
+
+	// structural validation works without references
+	spec, err := ParseConnectivityTemplateSpec([]byte(testConnectivityTemplateSpec))
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(nil))
+
+	_, err = ParseConnectivityTemplateSpec([]byte("label: x\nbogus: true\n"))
+	require.ErrorContains(t, err, `unknown key "bogus"`)
+}
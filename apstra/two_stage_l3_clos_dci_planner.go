@@ -0,0 +1,849 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+)
+
+// DCIGateway is a switch which may peer with the gateways of a remote
+// blueprint.
+type DCIGateway struct {
+	Id       ObjectId
+	Label    string
+	Role     enum.SystemNodeRole
+	Loopback netip.Addr // default routing zone loopback (loopback 0)
+	Asn      uint32
+}
+
+// DCISiteState holds the configuration of a blueprint taking part in EVPN
+// data center interconnect.
+type DCISiteState struct {
+	RouteTargetState
+	Gateways       []DCIGateway
+	RemoteGateways []TwoStageL3ClosRemoteGateway
+}
+
+// DCISiteRequest holds the site-specific portion of a DCIRequest.
+type DCISiteRequest struct {
+	GatewayLabels []string         // local switches which peer with the other site's gateways
+	ESIMAC        net.HardwareAddr // optional: the existing (or Apstra-assigned) value is kept when nil
+}
+
+// DCIRequest describes the virtual networks and routing zones to be stretched
+// between two blueprints. Objects are matched across blueprints by label.
+type DCIRequest struct {
+	InterconnectGroupLabel string
+	RouteTarget            string // optional when either blueprint already has the interconnect group
+	VirtualNetworkLabels   []string
+	RoutingZoneLabels      []string
+	A, B                   DCISiteRequest
+
+	// remote gateway settings applied in both blueprints. When nil, the
+	// value of an existing remote gateway is retained.
+	RouteTypes     *enum.RemoteGatewayRouteType
+	Ttl            *uint8
+	KeepaliveTimer *uint16
+	HoldtimeTimer  *uint16
+	Password       *string // not readable via the API, so only sent when a remote gateway is created or otherwise changed
+
+	// Updating a remote gateway replaces its password, which cannot be read
+	// from the API. Planning therefore fails when an existing remote gateway
+	// must be updated and Password is nil, unless NoPassword confirms that
+	// the remote gateways do not use BGP authentication.
+	NoPassword bool
+}
+
+// DCIRemoteGatewayChange is a remote gateway to be created (Id is empty) or
+// updated.
+type DCIRemoteGatewayChange struct {
+	Id   ObjectId
+	Data *TwoStageL3ClosRemoteGatewayData
+}
+
+// DCISitePlan holds the changes required in a single blueprint.
+type DCISitePlan struct {
+	BlueprintID          string
+	InterconnectGroup    EVPNInterconnectGroup // desired state. ID() returns nil when the group is to be created.
+	GroupChanged         bool
+	RemoteGateways       []DCIRemoteGatewayChange
+	RemoveRemoteGateways []ObjectId
+
+	labels map[string]string // routing zone and virtual network labels keyed by ID, for String()
+}
+
+// Empty returns true when the blueprint requires no changes.
+func (o DCISitePlan) Empty() bool {
+	return !o.GroupChanged && len(o.RemoteGateways) == 0 && len(o.RemoveRemoteGateways) == 0
+}
+
+func (o DCISitePlan) String() string {
+	var sb strings.Builder
+
+	label := func(id string) string {
+		if l, ok := o.labels[id]; ok {
+			return fmt.Sprintf("%q (%s)", l, id)
+		}
+		return id
+	}
+
+	fmt.Fprintf(&sb, "blueprint %q:\n", o.BlueprintID)
+	if o.Empty() {
+		sb.WriteString("  no changes\n")
+		return sb.String()
+	}
+
+	if o.GroupChanged {
+		g := o.InterconnectGroup
+		verb := "update"
+		if g.ID() == nil {
+			verb = "create"
+		}
+		fmt.Fprintf(&sb, "  %s evpn interconnect group %q route target %s", verb, dciString(g.Label), dciString(g.RouteTarget))
+		if g.ESIMAC != nil {
+			fmt.Fprintf(&sb, " esi mac %s", g.ESIMAC)
+		}
+		sb.WriteString("\n")
+		for _, id := range slices.Sorted(maps.Keys(g.InterconnectSecurityZones)) {
+			isz := g.InterconnectSecurityZones[id]
+			fmt.Fprintf(&sb, "    routing zone %s route target %s\n", label(id), dciString(isz.RouteTarget))
+		}
+		for _, id := range slices.Sorted(maps.Keys(g.InterconnectVirtualNetworks)) {
+			ivn := g.InterconnectVirtualNetworks[id]
+			fmt.Fprintf(&sb, "    virtual network %s l2 %t l3 %t", label(id), ivn.L2Enabled, ivn.L3Enabled)
+			if ivn.TranslationVNI != nil {
+				fmt.Fprintf(&sb, " translation vni %d", *ivn.TranslationVNI)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	for _, id := range o.RemoveRemoteGateways {
+		fmt.Fprintf(&sb, "  delete remote gateway %s\n", id)
+	}
+
+	for _, change := range o.RemoteGateways {
+		verb := "update"
+		if change.Id == "" {
+			verb = "create"
+		}
+		fmt.Fprintf(&sb, "  %s remote gateway %q %s AS %d\n", verb, change.Data.Label, change.Data.GwIp, change.Data.GwAsn)
+	}
+
+	return sb.String()
+}
+
+// DCIPlan holds the changes required in both blueprints.
+type DCIPlan struct {
+	A, B DCISitePlan
+}
+
+// Empty returns true when neither blueprint requires changes.
+func (o DCIPlan) Empty() bool {
+	return o.A.Empty() && o.B.Empty()
+}
+
+func (o DCIPlan) String() string {
+	return o.A.String() + o.B.String()
+}
+
+// dciSite bundles a site's state with the objects selected by the request.
+type dciSite struct {
+	state    *DCISiteState
+	req      DCISiteRequest
+	group    *EVPNInterconnectGroup // existing group, may be nil
+	gateways []DCIGateway
+	szs      []datacenter.SecurityZone // ordered per DCIRequest.RoutingZoneLabels
+	vns      []datacenter.VirtualNetwork
+}
+
+// NewDCIPlan calculates the EVPN interconnect group and remote gateway changes
+// required to stretch the requested virtual networks and routing zones between
+// blueprints a and b. Existing interconnect group entries not mentioned in the
+// request are retained.
+func NewDCIPlan(a, b DCISiteState, req DCIRequest) (*DCIPlan, error) {
+	if req.InterconnectGroupLabel == "" {
+		return nil, errors.New("interconnect group label is required")
+	}
+	if len(req.VirtualNetworkLabels) == 0 && len(req.RoutingZoneLabels) == 0 {
+		return nil, errors.New("at least one virtual network or routing zone label is required")
+	}
+	if a.BlueprintID == b.BlueprintID {
+		return nil, fmt.Errorf("both sites refer to blueprint %q", a.BlueprintID)
+	}
+	if req.NoPassword && req.Password != nil {
+		return nil, errors.New("password and no-password cannot both be specified")
+	}
+	if req.A.ESIMAC != nil && bytes.Equal(req.A.ESIMAC, req.B.ESIMAC) {
+		return nil, fmt.Errorf("both sites use interconnect ESI MAC %s", req.A.ESIMAC)
+	}
+
+	sites := [2]*dciSite{{state: &a, req: req.A}, {state: &b, req: req.B}}
+	var err error
+	for _, site := range sites {
+		if err = site.resolve(req); err != nil {
+			return nil, err
+		}
+	}
+
+	rt, err := dciGroupRouteTarget(req, sites)
+	if err != nil {
+		return nil, err
+	}
+
+	szRTs, err := dciSecurityZoneRouteTargets(rt, sites)
+	if err != nil {
+		return nil, err
+	}
+
+	ivns, err := dciInterconnectVirtualNetworks(req, sites)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, site := range sites {
+		inUse := dciRouteTargetsInUse(site.state.RouteTargetState, req.InterconnectGroupLabel)
+		for _, v := range append([]string{rt}, szRTs...) {
+			if desc, ok := inUse[v]; ok {
+				return nil, fmt.Errorf("route target %s is already used by %s in blueprint %q", v, desc, site.state.BlueprintID)
+			}
+		}
+	}
+
+	var result DCIPlan
+	for i, plan := range []*DCISitePlan{&result.A, &result.B} {
+		local, remote := sites[i], sites[1-i]
+		*plan, err = local.plan(req, rt, szRTs, ivns[i], remote.gateways)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
+
+// resolve locates the existing interconnect group and the requested gateways,
+// routing zones and virtual networks.
+func (o *dciSite) resolve(req DCIRequest) error {
+	for _, group := range o.state.EVPNInterconnectGroups {
+		if group.Label != nil && *group.Label == req.InterconnectGroupLabel {
+			if o.group != nil {
+				return fmt.Errorf("blueprint %q has multiple EVPN interconnect groups with label %q", o.state.BlueprintID, req.InterconnectGroupLabel)
+			}
+			o.group = &group
+		}
+	}
+
+	if len(o.req.GatewayLabels) == 0 {
+		return fmt.Errorf("no gateway labels specified for blueprint %q", o.state.BlueprintID)
+	}
+	for _, label := range o.req.GatewayLabels {
+		i := slices.IndexFunc(o.state.Gateways, func(g DCIGateway) bool { return g.Label == label })
+		if i < 0 {
+			return ClientErr{
+				errType: ErrNotfound,
+				err:     fmt.Errorf("gateway system %q not found in blueprint %q", label, o.state.BlueprintID),
+			}
+		}
+		if !o.state.Gateways[i].Loopback.IsValid() {
+			return fmt.Errorf("gateway system %q in blueprint %q has no loopback address", label, o.state.BlueprintID)
+		}
+		if o.state.Gateways[i].Asn == 0 {
+			return fmt.Errorf("gateway system %q in blueprint %q has no ASN", label, o.state.BlueprintID)
+		}
+		o.gateways = append(o.gateways, o.state.Gateways[i])
+	}
+	slices.SortFunc(o.gateways, func(a, b DCIGateway) int { return strings.Compare(a.Label, b.Label) })
+
+	for _, label := range req.RoutingZoneLabels {
+		i := slices.IndexFunc(o.state.SecurityZones, func(sz datacenter.SecurityZone) bool { return sz.Label == label })
+		if i < 0 {
+			return ClientErr{
+				errType: ErrNotfound,
+				err:     fmt.Errorf("routing zone %q not found in blueprint %q", label, o.state.BlueprintID),
+			}
+		}
+		o.szs = append(o.szs, o.state.SecurityZones[i])
+	}
+
+	for _, label := range req.VirtualNetworkLabels {
+		i := slices.IndexFunc(o.state.VirtualNetworks, func(vn datacenter.VirtualNetwork) bool { return vn.Label == label })
+		if i < 0 {
+			return ClientErr{
+				errType: ErrNotfound,
+				err:     fmt.Errorf("virtual network %q not found in blueprint %q", label, o.state.BlueprintID),
+			}
+		}
+		vn := o.state.VirtualNetworks[i]
+		if vn.Type != enum.VnTypeVxlan {
+			return fmt.Errorf("virtual network %q in blueprint %q has type %s, only %s networks can be stretched", label, o.state.BlueprintID, vn.Type, enum.VnTypeVxlan)
+		}
+		if vn.VNI == nil {
+			return fmt.Errorf("virtual network %q in blueprint %q has no VNI", label, o.state.BlueprintID)
+		}
+		o.vns = append(o.vns, vn)
+	}
+
+	return nil
+}
+
+// securityZoneLabel returns the label of the security zone with the given ID.
+func (o *dciSite) securityZoneLabel(id string) string {
+	for _, sz := range o.state.SecurityZones {
+		if sz.ID() != nil && *sz.ID() == id {
+			return sz.Label
+		}
+	}
+	return ""
+}
+
+// plan calculates the changes in the local blueprint which connect it to the
+// remote gateways.
+func (o *dciSite) plan(req DCIRequest, rt string, szRTs []string, ivns map[string]InterconnectVirtualNetwork, remoteGateways []DCIGateway) (DCISitePlan, error) {
+	result := DCISitePlan{
+		BlueprintID: o.state.BlueprintID,
+		labels:      make(map[string]string),
+	}
+
+	var group EVPNInterconnectGroup
+	if o.group != nil {
+		group = *o.group
+	}
+	group.Label = &req.InterconnectGroupLabel
+	group.RouteTarget = &rt
+	if o.req.ESIMAC != nil {
+		group.ESIMAC = o.req.ESIMAC
+	}
+
+	group.InterconnectSecurityZones = maps.Clone(group.InterconnectSecurityZones)
+	if group.InterconnectSecurityZones == nil && len(o.szs) > 0 {
+		group.InterconnectSecurityZones = make(map[string]InterconnectSecurityZone, len(o.szs))
+	}
+	for i, sz := range o.szs {
+		isz := group.InterconnectSecurityZones[*sz.ID()]
+		isz.L3Enabled = true
+		isz.RouteTarget = &szRTs[i]
+		group.InterconnectSecurityZones[*sz.ID()] = isz
+		result.labels[*sz.ID()] = sz.Label
+	}
+
+	group.InterconnectVirtualNetworks = maps.Clone(group.InterconnectVirtualNetworks)
+	if group.InterconnectVirtualNetworks == nil && len(ivns) > 0 {
+		group.InterconnectVirtualNetworks = make(map[string]InterconnectVirtualNetwork, len(ivns))
+	}
+	for _, vn := range o.vns {
+		group.InterconnectVirtualNetworks[*vn.ID()] = ivns[*vn.ID()]
+		result.labels[*vn.ID()] = vn.Label
+	}
+
+	result.InterconnectGroup = group
+	result.GroupChanged = o.group == nil || !evpnInterconnectGroupsEqual(*o.group, group)
+
+	var groupId *ObjectId
+	if group.ID() != nil {
+		groupId = pointer.To(ObjectId(*group.ID()))
+	}
+
+	localGwNodes := make([]ObjectId, len(o.gateways))
+	for i, gw := range o.gateways {
+		localGwNodes[i] = gw.Id
+	}
+	slices.Sort(localGwNodes)
+
+	wanted := make(map[netip.Addr]bool, len(remoteGateways))
+	for _, gw := range remoteGateways {
+		wanted[gw.Loopback] = true
+
+		desired := TwoStageL3ClosRemoteGatewayData{
+			Label:                   req.InterconnectGroupLabel + "_" + gw.Label,
+			GwIp:                    gw.Loopback,
+			GwAsn:                   gw.Asn,
+			RouteTypes:              req.RouteTypes,
+			Ttl:                     req.Ttl,
+			KeepaliveTimer:          req.KeepaliveTimer,
+			HoldtimeTimer:           req.HoldtimeTimer,
+			Password:                req.Password,
+			EvpnInterconnectGroupId: groupId,
+			LocalGwNodes:            localGwNodes,
+		}
+
+		i := slices.IndexFunc(o.state.RemoteGateways, func(rgw TwoStageL3ClosRemoteGateway) bool {
+			return rgw.Data != nil && rgw.Data.GwIp == gw.Loopback
+		})
+		if i < 0 {
+			result.RemoteGateways = append(result.RemoteGateways, DCIRemoteGatewayChange{Data: &desired})
+			continue
+		}
+
+		existing := o.state.RemoteGateways[i]
+		desired.RouteTypes = cmp.Or(desired.RouteTypes, existing.Data.RouteTypes)
+		desired.Ttl = cmp.Or(desired.Ttl, existing.Data.Ttl)
+		desired.KeepaliveTimer = cmp.Or(desired.KeepaliveTimer, existing.Data.KeepaliveTimer)
+		desired.HoldtimeTimer = cmp.Or(desired.HoldtimeTimer, existing.Data.HoldtimeTimer)
+		if groupId != nil && remoteGatewayDataEqual(*existing.Data, desired) {
+			continue
+		}
+		if req.Password == nil && !req.NoPassword {
+			return DCISitePlan{}, fmt.Errorf("remote gateway %q in blueprint %q must be updated, which would remove its password - "+
+				"the password must be resupplied (or no-password specified) because it cannot be read from the API", existing.Data.Label, o.state.BlueprintID)
+		}
+		result.RemoteGateways = append(result.RemoteGateways, DCIRemoteGatewayChange{Id: existing.Id, Data: &desired})
+	}
+
+	// remote gateways attached to this group which no longer match a remote gateway system are stale
+	if groupId != nil {
+		for _, rgw := range o.state.RemoteGateways {
+			if rgw.Data == nil || rgw.Data.EvpnInterconnectGroupId == nil || *rgw.Data.EvpnInterconnectGroupId != *groupId || wanted[rgw.Data.GwIp] {
+				continue
+			}
+			result.RemoveRemoteGateways = append(result.RemoveRemoteGateways, rgw.Id)
+		}
+	}
+
+	return result, nil
+}
+
+// dciGroupRouteTarget selects the interconnect group route target: the value
+// from the request or, when not specified, the value already configured in
+// either blueprint.
+func dciGroupRouteTarget(req DCIRequest, sites [2]*dciSite) (string, error) {
+	rt := normalizeRouteTarget(req.RouteTarget)
+	if rt == "" {
+		var existing []string
+		for _, site := range sites {
+			if site.group != nil && site.group.RouteTarget != nil && *site.group.RouteTarget != "" {
+				existing = append(existing, normalizeRouteTarget(*site.group.RouteTarget))
+			}
+		}
+		existing = slices.Compact(existing)
+		switch len(existing) {
+		case 0:
+			return "", errors.New("route target is required when neither blueprint has the interconnect group")
+		case 1:
+			rt = existing[0]
+		default:
+			return "", fmt.Errorf("interconnect groups in blueprints %q and %q use different route targets %s and %s",
+				sites[0].state.BlueprintID, sites[1].state.BlueprintID, existing[0], existing[1])
+		}
+	}
+
+	if err := validateRouteTarget(rt); err != nil {
+		return "", err
+	}
+
+	return rt, nil
+}
+
+// dciSecurityZoneRouteTargets returns the interconnect route target of each
+// requested security zone. Values already configured in either blueprint are
+// retained. Others are built from the administrator field of the interconnect
+// group route target and the security zone's VNI in the first blueprint.
+func dciSecurityZoneRouteTargets(groupRT string, sites [2]*dciSite) ([]string, error) {
+	admin := groupRT[:strings.LastIndex(groupRT, ":")]
+
+	result := make([]string, len(sites[0].szs))
+	for i := range sites[0].szs {
+		var existing []string
+		for _, site := range sites {
+			if site.group == nil {
+				continue
+			}
+			isz, ok := site.group.InterconnectSecurityZones[*site.szs[i].ID()]
+			if ok && isz.RouteTarget != nil && *isz.RouteTarget != "" {
+				existing = append(existing, normalizeRouteTarget(*isz.RouteTarget))
+			}
+		}
+		existing = slices.Compact(existing)
+
+		switch len(existing) {
+		case 0:
+			sz := sites[0].szs[i]
+			if sz.VNI == nil {
+				return nil, fmt.Errorf("routing zone %q in blueprint %q has no VNI", sz.Label, sites[0].state.BlueprintID)
+			}
+			result[i] = fmt.Sprintf("%s:%d", admin, *sz.VNI)
+		case 1:
+			result[i] = existing[0]
+		default:
+			return nil, fmt.Errorf("routing zone %q uses different interconnect route targets %s and %s",
+				sites[0].szs[i].Label, existing[0], existing[1])
+		}
+
+		if err := validateRouteTarget(result[i]); err != nil {
+			return nil, fmt.Errorf("routing zone %q - %w", sites[0].szs[i].Label, err)
+		}
+	}
+
+	for i := range result {
+		if j := slices.Index(result[i+1:], result[i]); j >= 0 {
+			return nil, fmt.Errorf("routing zones %q and %q would share interconnect route target %s",
+				sites[0].szs[i].Label, sites[0].szs[i+1+j].Label, result[i])
+		}
+		if result[i] == groupRT {
+			return nil, fmt.Errorf("routing zone %q would use the interconnect group route target %s", sites[0].szs[i].Label, groupRT)
+		}
+	}
+
+	return result, nil
+}
+
+// dciInterconnectVirtualNetworks returns the interconnect virtual network
+// entries for each site, keyed by virtual network ID. Virtual networks are
+// stretched at layer 3 when they have IP addressing and their routing zone is
+// also stretched. When the VNIs differ between sites, the second site
+// translates to the VNI used by the first.
+func dciInterconnectVirtualNetworks(req DCIRequest, sites [2]*dciSite) ([2]map[string]InterconnectVirtualNetwork, error) {
+	var result [2]map[string]InterconnectVirtualNetwork
+	for i := range result {
+		result[i] = make(map[string]InterconnectVirtualNetwork, len(req.VirtualNetworkLabels))
+	}
+
+	for i := range req.VirtualNetworkLabels {
+		vnA, vnB := sites[0].vns[i], sites[1].vns[i]
+
+		szA, szB := sites[0].securityZoneLabel(vnA.SecurityZoneID), sites[1].securityZoneLabel(vnB.SecurityZoneID)
+		l3 := (vnA.IPv4Enabled || vnA.IPv6Enabled) && slices.Contains(req.RoutingZoneLabels, szA)
+		if l3 && szA != szB {
+			return result, fmt.Errorf("virtual network %q belongs to routing zone %q in blueprint %q but %q in blueprint %q",
+				vnA.Label, szA, sites[0].state.BlueprintID, szB, sites[1].state.BlueprintID)
+		}
+
+		ivnA := InterconnectVirtualNetwork{L2Enabled: true, L3Enabled: l3}
+		if sites[0].group != nil {
+			ivnA.TranslationVNI = sites[0].group.InterconnectVirtualNetworks[*vnA.ID()].TranslationVNI
+		}
+
+		interconnectVNI := *vnA.VNI
+		if ivnA.TranslationVNI != nil {
+			interconnectVNI = *ivnA.TranslationVNI
+		}
+
+		ivnB := InterconnectVirtualNetwork{L2Enabled: true, L3Enabled: l3}
+		if *vnB.VNI != interconnectVNI {
+			ivnB.TranslationVNI = &interconnectVNI
+		}
+
+		result[0][*vnA.ID()] = ivnA
+		result[1][*vnB.ID()] = ivnB
+	}
+
+	return result, nil
+}
+
+// dciRouteTargetsInUse returns route targets used in the blueprint other than
+// by the named interconnect group, with a description of the user.
+func dciRouteTargetsInUse(state RouteTargetState, groupLabel string) map[string]string {
+	result := make(map[string]string)
+	add := func(rt *string, format string, a ...any) {
+		if rt != nil && *rt != "" {
+			result[normalizeRouteTarget(*rt)] = fmt.Sprintf(format, a...)
+		}
+	}
+	addPolicy := func(policy *datacenter.RTPolicy, format string, a ...any) {
+		if policy == nil {
+			return
+		}
+		for _, rt := range append(slices.Clone(policy.ImportRTs), policy.ExportRTs...) {
+			add(&rt, format, a...)
+		}
+	}
+
+	for _, sz := range state.SecurityZones {
+		add(sz.RouteTarget, "routing zone %q", sz.Label)
+		addPolicy(sz.RTPolicy, "routing zone %q", sz.Label)
+	}
+
+	for _, vn := range state.VirtualNetworks {
+		addPolicy(vn.RTPolicy, "virtual network %q", vn.Label)
+	}
+
+	for _, group := range state.EVPNInterconnectGroups {
+		if group.Label != nil && *group.Label == groupLabel {
+			continue
+		}
+		label := dciString(group.Label)
+		add(group.RouteTarget, "evpn interconnect group %q", label)
+		for _, isz := range group.InterconnectSecurityZones {
+			add(isz.RouteTarget, "evpn interconnect group %q", label)
+		}
+	}
+
+	return result
+}
+
+// validateRouteTarget checks that rt has the form <administrator>:<value>
+// where the administrator is an ASN or an IPv4 address.
+func validateRouteTarget(rt string) error {
+	i := strings.LastIndex(rt, ":")
+	if i < 0 {
+		return fmt.Errorf("route target %q must have the form <administrator>:<value>", rt)
+	}
+	admin, value := rt[:i], rt[i+1:]
+
+	valueBits := 16
+	if asn, err := strconv.ParseUint(admin, 10, 32); err == nil {
+		if asn <= 65535 {
+			valueBits = 32
+		}
+	} else if addr, err := netip.ParseAddr(admin); err != nil || !addr.Is4() {
+		return fmt.Errorf("route target %q administrator must be an ASN or IPv4 address", rt)
+	}
+
+	if _, err := strconv.ParseUint(value, 10, valueBits); err != nil {
+		return fmt.Errorf("route target %q value must be a %d-bit integer", rt, valueBits)
+	}
+
+	return nil
+}
+
+func evpnInterconnectGroupsEqual(a, b EVPNInterconnectGroup) bool {
+	aj, errA := json.Marshal(a)
+	bj, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aj, bj)
+}
+
+// remoteGatewayDataEqual compares remote gateways, ignoring the password which
+// cannot be read from the API.
+func remoteGatewayDataEqual(a, b TwoStageL3ClosRemoteGatewayData) bool {
+	a.Password, b.Password = nil, nil
+	a.LocalGwNodes = slices.Sorted(slices.Values(a.LocalGwNodes))
+	b.LocalGwNodes = slices.Sorted(slices.Values(b.LocalGwNodes))
+	aj, errA := json.Marshal(a)
+	bj, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aj, bj)
+}
+
+// GetDCISiteState collects the route target configuration, candidate gateway
+// systems and remote gateways of the blueprint.
+func (o *TwoStageL3ClosClient) GetDCISiteState(ctx context.Context) (*DCISiteState, error) {
+	rtState, err := o.GetRouteTargetState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := DCISiteState{RouteTargetState: *rtState}
+
+	result.Gateways, err = o.getDCIGateways(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result.RemoteGateways, err = o.GetAllRemoteGateways(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching remote gateways from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	return &result, nil
+}
+
+// getDCIGateways returns the blueprint's switches along with their loopback
+// address and ASN.
+func (o *TwoStageL3ClosClient) getDCIGateways(ctx context.Context) ([]DCIGateway, error) {
+	loopbackQuery := new(PathQuery).
+		SetBlueprintId(o.blueprintId).
+		SetBlueprintType(BlueprintTypeStaging).
+		SetClient(o.client).
+		Node([]QEEAttribute{
+			NodeTypeSystem.QEEAttribute(),
+			{Key: "system_type", Value: QEStringVal("switch")},
+			{Key: "name", Value: QEStringVal("n_system")},
+		}).
+		Out([]QEEAttribute{RelationshipTypeHostedInterfaces.QEEAttribute()}).
+		Node([]QEEAttribute{
+			NodeTypeInterface.QEEAttribute(),
+			{Key: "if_type", Value: QEStringVal("loopback")},
+			{Key: "loopback_id", Value: QEIntVal(0)},
+			{Key: "name", Value: QEStringVal("n_loopback")},
+		})
+
+	var loopbackResult struct {
+		Items []struct {
+			System struct {
+				Id    ObjectId `json:"id"`
+				Label string   `json:"label"`
+				Role  string   `json:"role"`
+			} `json:"n_system"`
+			Loopback struct {
+				IPv4Addr *string `json:"ipv4_addr"`
+			} `json:"n_loopback"`
+		} `json:"items"`
+	}
+	err := loopbackQuery.Do(ctx, &loopbackResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying switch loopbacks in blueprint %q - %w", o.blueprintId, err)
+	}
+
+	asnQuery := new(PathQuery).
+		SetBlueprintId(o.blueprintId).
+		SetBlueprintType(BlueprintTypeStaging).
+		SetClient(o.client).
+		Node([]QEEAttribute{
+			NodeTypeDomain.QEEAttribute(),
+			{Key: "domain_type", Value: QEStringVal("autonomous_system")},
+			{Key: "name", Value: QEStringVal("n_domain")},
+		}).
+		Out([]QEEAttribute{RelationshipTypeComposedOfSystems.QEEAttribute()}).
+		Node([]QEEAttribute{
+			NodeTypeSystem.QEEAttribute(),
+			{Key: "name", Value: QEStringVal("n_system")},
+		})
+
+	var asnResult struct {
+		Items []struct {
+			Domain struct {
+				DomainId json.RawMessage `json:"domain_id"` // string or number depending on Apstra version
+			} `json:"n_domain"`
+			System struct {
+				Id ObjectId `json:"id"`
+			} `json:"n_system"`
+		} `json:"items"`
+	}
+	err = asnQuery.Do(ctx, &asnResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying switch ASNs in blueprint %q - %w", o.blueprintId, err)
+	}
+
+	asns := make(map[ObjectId]uint32, len(asnResult.Items))
+	for _, item := range asnResult.Items {
+		s := strings.Trim(string(item.Domain.DomainId), `"`)
+		if s == "" || s == "null" {
+			continue
+		}
+		asn, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing ASN %q of system %q in blueprint %q - %w", s, item.System.Id, o.blueprintId, err)
+		}
+		asns[item.System.Id] = uint32(asn)
+	}
+
+	result := make([]DCIGateway, len(loopbackResult.Items))
+	for i, item := range loopbackResult.Items {
+		result[i] = DCIGateway{
+			Id:    item.System.Id,
+			Label: item.System.Label,
+			Role:  enum.SystemNodeRole{Value: item.System.Role},
+			Asn:   asns[item.System.Id],
+		}
+		if item.Loopback.IPv4Addr != nil && *item.Loopback.IPv4Addr != "" {
+			prefix, err := netip.ParsePrefix(*item.Loopback.IPv4Addr)
+			if err != nil {
+				return nil, fmt.Errorf("failed parsing loopback address of system %q in blueprint %q - %w", item.System.Label, o.blueprintId, err)
+			}
+			result[i].Loopback = prefix.Addr()
+		}
+	}
+
+	slices.SortFunc(result, func(a, b DCIGateway) int { return strings.Compare(a.Label, b.Label) })
+
+	return result, nil
+}
+
+// PlanDCI collects the state of both blueprints and calculates the changes
+// required to stretch the requested virtual networks and routing zones
+// between them.
+func PlanDCI(ctx context.Context, a, b *TwoStageL3ClosClient, req DCIRequest) (*DCIPlan, error) {
+	stateA, err := a.GetDCISiteState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stateB, err := b.GetDCISiteState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDCIPlan(*stateA, *stateB, req)
+}
+
+// ApplyDCIPlan applies the plan to blueprint a, then to blueprint b. Within
+// each blueprint, stale remote gateways are removed first, then the
+// interconnect group is created or updated, and finally the remote gateways
+// are created or updated. Changes are not rolled back: when blueprint b (or a
+// later step in either blueprint) fails, the changes already made remain in
+// place. Planning and applying again completes the remaining changes.
+func ApplyDCIPlan(ctx context.Context, a, b *TwoStageL3ClosClient, plan *DCIPlan) error {
+	for _, site := range []struct {
+		client *TwoStageL3ClosClient
+		plan   DCISitePlan
+	}{
+		{client: a, plan: plan.A},
+		{client: b, plan: plan.B},
+	} {
+		if site.client.blueprintId.String() != site.plan.BlueprintID {
+			return fmt.Errorf("plan for blueprint %q cannot be applied to blueprint %q", site.plan.BlueprintID, site.client.blueprintId)
+		}
+
+		if err := site.client.applyDCISitePlan(ctx, site.plan); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *TwoStageL3ClosClient) applyDCISitePlan(ctx context.Context, plan DCISitePlan) error {
+	for _, id := range plan.RemoveRemoteGateways {
+		if err := o.DeleteRemoteGateway(ctx, id); err != nil {
+			return fmt.Errorf("failed deleting remote gateway %q from blueprint %q - %w", id, o.blueprintId, err)
+		}
+	}
+
+	group := plan.InterconnectGroup
+	if plan.GroupChanged {
+		if group.ID() == nil {
+			id, err := o.CreateEVPNInterconnectGroup(ctx, group)
+			if err != nil {
+				return fmt.Errorf("failed creating EVPN interconnect group in blueprint %q - %w", o.blueprintId, err)
+			}
+			if err = group.SetID(id); err != nil {
+				return err
+			}
+		} else if err := o.UpdateEVPNInterconnectGroup(ctx, group); err != nil {
+			return fmt.Errorf("failed updating EVPN interconnect group %q in blueprint %q - %w", *group.ID(), o.blueprintId, err)
+		}
+	}
+
+	groupId := ObjectId(*group.ID())
+	for _, change := range plan.RemoteGateways {
+		data := *change.Data
+		data.EvpnInterconnectGroupId = &groupId
+
+		if change.Id == "" {
+			if _, err := o.CreateRemoteGateway(ctx, &data); err != nil {
+				return fmt.Errorf("failed creating remote gateway %q in blueprint %q - %w", data.Label, o.blueprintId, err)
+			}
+			continue
+		}
+
+		if err := o.UpdateRemoteGateway(ctx, change.Id, &data); err != nil {
+			return fmt.Errorf("failed updating remote gateway %q in blueprint %q - %w", change.Id, o.blueprintId, err)
+		}
+	}
+
+	return nil
+}
+
+// dciString returns the value of p, or an empty string when p is nil.
+func dciString(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
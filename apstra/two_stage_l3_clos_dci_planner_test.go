@@ -0,0 +1,230 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDCIPlan(t *testing.T) {
+	siteState := func(bpId string, webVNI uint32, gateways ...DCIGateway) DCISiteState {
+		sz := datacenter.SecurityZone{Label: "blue", VNI: pointer.To(10000), RouteTarget: pointer.To("10000:1")}
+		require.NoError(t, sz.SetID(bpId+"-blue"))
+
+		web := datacenter.VirtualNetwork{Label: "web", Type: enum.VnTypeVxlan, VNI: pointer.To(webVNI), SecurityZoneID: bpId + "-blue", IPv4Enabled: true}
+		require.NoError(t, web.SetID(bpId+"-web"))
+
+		db := datacenter.VirtualNetwork{Label: "db", Type: enum.VnTypeVxlan, VNI: pointer.To(uint32(20002)), SecurityZoneID: bpId + "-blue"}
+		require.NoError(t, db.SetID(bpId+"-db"))
+
+		vlan := datacenter.VirtualNetwork{Label: "vlan", Type: enum.VnTypeVlan, VNI: pointer.To(uint32(20003))}
+		require.NoError(t, vlan.SetID(bpId+"-vlan"))
+
+		return DCISiteState{
+			RouteTargetState: RouteTargetState{
+				BlueprintID:     bpId,
+				SecurityZones:   []datacenter.SecurityZone{sz},
+				VirtualNetworks: []datacenter.VirtualNetwork{web, db, vlan},
+			},
+			Gateways: gateways,
+		}
+	}
+
+	stateA := siteState("bpA", 20001,
+		DCIGateway{Id: "a1", Label: "borderA1", Loopback: netip.MustParseAddr("10.0.0.1"), Asn: 65001},
+		DCIGateway{Id: "a2", Label: "borderA2", Loopback: netip.MustParseAddr("10.0.0.2"), Asn: 65002},
+		DCIGateway{Id: "a3", Label: "leafA3", Asn: 65003},
+	)
+	stateB := siteState("bpB", 30001,
+		DCIGateway{Id: "b1", Label: "borderB1", Loopback: netip.MustParseAddr("10.1.0.1"), Asn: 65101},
+	)
+	request := DCIRequest{
+		InterconnectGroupLabel: "dci",
+		RouteTarget:            "100:100",
+		VirtualNetworkLabels:   []string{"web", "db"},
+		RoutingZoneLabels:      []string{"blue"},
+		A:                      DCISiteRequest{GatewayLabels: []string{"borderA2", "borderA1"}},
+		B:                      DCISiteRequest{GatewayLabels: []string{"borderB1"}},
+		RouteTypes:             &enum.RemoteGatewayRouteTypeAll,
+	}
+
+	t.Run("plan_apply_replan", func(t *testing.T) {
+		a, b, req := stateA, stateB, request
+
+		plan, err := NewDCIPlan(a, b, req)
+		require.NoError(t, err)
+		require.False(t, plan.Empty())
+
+		// blueprint A: new group, no translation
+		require.True(t, plan.A.GroupChanged)
+		require.Nil(t, plan.A.InterconnectGroup.ID())
+		require.Equal(t, "100:100", *plan.A.InterconnectGroup.RouteTarget)
+		require.Equal(t, map[string]InterconnectSecurityZone{
+			"bpA-blue": {L3Enabled: true, RouteTarget: pointer.To("100:10000")},
+		}, plan.A.InterconnectGroup.InterconnectSecurityZones)
+		require.Equal(t, map[string]InterconnectVirtualNetwork{
+			"bpA-web": {L2Enabled: true, L3Enabled: true},
+			"bpA-db":  {L2Enabled: true, L3Enabled: false},
+		}, plan.A.InterconnectGroup.InterconnectVirtualNetworks)
+
+		// blueprint B: web uses a different VNI, so it translates to blueprint A's VNI
+		require.Equal(t, map[string]InterconnectVirtualNetwork{
+			"bpB-web": {L2Enabled: true, L3Enabled: true, TranslationVNI: pointer.To(uint32(20001))},
+			"bpB-db":  {L2Enabled: true, L3Enabled: false},
+		}, plan.B.InterconnectGroup.InterconnectVirtualNetworks)
+		require.Equal(t, "100:10000", *plan.B.InterconnectGroup.InterconnectSecurityZones["bpB-blue"].RouteTarget)
+
+		// remote gateways point at the other blueprint's gateways
+		require.Len(t, plan.A.RemoteGateways, 1)
+		require.Empty(t, plan.A.RemoteGateways[0].Id)
+		require.Equal(t, TwoStageL3ClosRemoteGatewayData{
+			Label:        "dci_borderB1",
+			GwIp:         netip.MustParseAddr("10.1.0.1"),
+			GwAsn:        65101,
+			RouteTypes:   &enum.RemoteGatewayRouteTypeAll,
+			LocalGwNodes: []ObjectId{"a1", "a2"},
+		}, *plan.A.RemoteGateways[0].Data)
+
+		require.Len(t, plan.B.RemoteGateways, 2)
+		require.Equal(t, "dci_borderA1", plan.B.RemoteGateways[0].Data.Label)
+		require.Equal(t, uint32(65002), plan.B.RemoteGateways[1].Data.GwAsn)
+		require.Equal(t, []ObjectId{"b1"}, plan.B.RemoteGateways[1].Data.LocalGwNodes)
+
+		require.Contains(t, plan.String(), `create evpn interconnect group "dci" route target 100:100`)
+		require.Contains(t, plan.String(), `virtual network "web" (bpB-web) l2 true l3 true translation vni 20001`)
+
+		// simulate application of the plan, then plan again
+		applied := func(state *DCISiteState, sitePlan DCISitePlan, groupId string) {
+			group := sitePlan.InterconnectGroup
+			require.NoError(t, group.SetID(groupId))
+			state.EVPNInterconnectGroups = []EVPNInterconnectGroup{group}
+			for i, change := range sitePlan.RemoteGateways {
+				data := *change.Data
+				data.Password = nil
+				data.EvpnInterconnectGroupId = pointer.To(ObjectId(groupId))
+				state.RemoteGateways = append(state.RemoteGateways, TwoStageL3ClosRemoteGateway{Id: ObjectId(groupId + "-rgw" + string(rune('0'+i))), Data: &data})
+			}
+		}
+		applied(&a, plan.A, "groupA")
+		applied(&b, plan.B, "groupB")
+
+		req.RouteTarget = "" // learned from the existing groups
+		req.RouteTypes = nil // retained from the existing remote gateways
+		plan, err = NewDCIPlan(a, b, req)
+		require.NoError(t, err)
+		require.True(t, plan.Empty(), plan.String())
+		require.Contains(t, plan.String(), "no changes")
+
+		// drop a gateway from blueprint B: blueprint A's remote gateway is now stale
+		req.B.GatewayLabels = nil
+		_, err = NewDCIPlan(a, b, req)
+		require.Error(t, err)
+
+		b.Gateways = append(b.Gateways, DCIGateway{Id: "b2", Label: "borderB2", Loopback: netip.MustParseAddr("10.1.0.2"), Asn: 65102})
+		req.B.GatewayLabels = []string{"borderB2"}
+		req.B.ESIMAC = net.HardwareAddr{0, 1, 2, 3, 4, 5}
+		_, err = NewDCIPlan(a, b, req)
+		require.ErrorContains(t, err, "password must be resupplied") // existing remote gateways must be updated
+
+		req.NoPassword = true
+		plan, err = NewDCIPlan(a, b, req)
+		require.NoError(t, err)
+		require.Equal(t, []ObjectId{"groupA-rgw0"}, plan.A.RemoveRemoteGateways)
+		require.Len(t, plan.A.RemoteGateways, 1)
+		require.Equal(t, ObjectId("groupA"), *plan.A.RemoteGateways[0].Data.EvpnInterconnectGroupId)
+
+		// blueprint B: the group changes (ESI MAC) and both remote gateways change (local gateway nodes)
+		require.True(t, plan.B.GroupChanged)
+		require.Equal(t, "groupB", *plan.B.InterconnectGroup.ID())
+		require.Len(t, plan.B.RemoteGateways, 2)
+		require.Equal(t, []ObjectId{"b2"}, plan.B.RemoteGateways[0].Data.LocalGwNodes)
+		require.NotEmpty(t, plan.B.RemoteGateways[0].Id)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		type testCase struct {
+			modify func(a, b *DCISiteState, req *DCIRequest)
+			errStr string
+		}
+
+		testCases := map[string]testCase{
+			"no_route_target": {
+				modify: func(_, _ *DCISiteState, req *DCIRequest) { req.RouteTarget = "" },
+				errStr: "route target is required",
+			},
+			"bad_route_target": {
+				modify: func(_, _ *DCISiteState, req *DCIRequest) { req.RouteTarget = "100:100:100x" },
+				errStr: "administrator must be an ASN or IPv4 address",
+			},
+			"route_target_in_use": {
+				modify: func(_, b *DCISiteState, req *DCIRequest) { req.RouteTarget = "10000:1" },
+				errStr: `route target 10000:1 is already used by routing zone "blue" in blueprint "bpA"`,
+			},
+			"differing_existing_route_targets": {
+				modify: func(a, b *DCISiteState, req *DCIRequest) {
+					req.RouteTarget = ""
+					a.EVPNInterconnectGroups = []EVPNInterconnectGroup{{Label: pointer.To("dci"), RouteTarget: pointer.To("1:1")}}
+					b.EVPNInterconnectGroups = []EVPNInterconnectGroup{{Label: pointer.To("dci"), RouteTarget: pointer.To("2:2")}}
+				},
+				errStr: "use different route targets 1:1 and 2:2",
+			},
+			"unknown_virtual_network": {
+				modify: func(_, _ *DCISiteState, req *DCIRequest) { req.VirtualNetworkLabels = []string{"nope"} },
+				errStr: `virtual network "nope" not found in blueprint "bpA"`,
+			},
+			"vlan_virtual_network": {
+				modify: func(_, _ *DCISiteState, req *DCIRequest) { req.VirtualNetworkLabels = []string{"vlan"} },
+				errStr: "only vxlan networks can be stretched",
+			},
+			"gateway_without_loopback": {
+				modify: func(_, _ *DCISiteState, req *DCIRequest) { req.A.GatewayLabels = []string{"leafA3"} },
+				errStr: `gateway system "leafA3" in blueprint "bpA" has no loopback address`,
+			},
+			"same_esi": {
+				modify: func(_, _ *DCISiteState, req *DCIRequest) {
+					req.A.ESIMAC = net.HardwareAddr{0, 1, 2, 3, 4, 5}
+					req.B.ESIMAC = net.HardwareAddr{0, 1, 2, 3, 4, 5}
+				},
+				errStr: "both sites use interconnect ESI MAC",
+			},
+			"password_and_no_password": {
+				modify: func(_, _ *DCISiteState, req *DCIRequest) {
+					req.Password = pointer.To("secret")
+					req.NoPassword = true
+				},
+				errStr: "password and no-password cannot both be specified",
+			},
+			"same_blueprint": {
+				modify: func(_, b *DCISiteState, _ *DCIRequest) { b.BlueprintID = "bpA" },
+				errStr: `both sites refer to blueprint "bpA"`,
+			},
+		}
+		for tName, tCase := range testCases {
+			t.Run(tName, func(t *testing.T) {
+				a, b, req := stateA, stateB, request
+				tCase.modify(&a, &b, &req)
+
+				_, err := NewDCIPlan(a, b, req)
+				require.ErrorContains(t, err, tCase.errStr)
+			})
+		}
+	})
+}
+
+func TestValidateRouteTarget(t *testing.T) {
+	for _, rt := range []string{"65000:100", "65000:4294967295", "4200000000:65535", "192.0.2.1:1"} {
+		require.NoError(t, validateRouteTarget(rt), rt)
+	}
+	for _, rt := range []string{"", "100", "4200000000:65536", "192.0.2.1:65536", "2001:db8::1:1", "x:1", "1:x"} {
+		require.Error(t, validateRouteTarget(rt), rt)
+	}
+}
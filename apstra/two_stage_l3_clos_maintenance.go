@@ -0,0 +1,379 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+const (
+	maintenancePollIntervalDefault = 10 * time.Second
+	maintenanceTimeoutDefault      = 15 * time.Minute
+)
+
+// MaintenanceTrafficCheck determines when drained systems have shed their
+// traffic. When ProbeId is empty, Interval (which must be positive) is simply
+// waited out. Otherwise the named stage of the IBA probe (e.g. the interface
+// counters stage of a device traffic probe) is polled until the traffic it
+// reports for the drained systems falls below Threshold. The check fails if
+// none of the stage's items belong to the drained systems.
+type MaintenanceTrafficCheck struct {
+	Interval time.Duration
+
+	ProbeId   ObjectId
+	StageName string
+	ValueKeys []string // keys of multi-value stage items to sum (e.g. "tx_bps"); all keys when empty
+	Threshold float64
+
+	PollInterval time.Duration // default 10 seconds
+	Timeout      time.Duration // default 15 minutes
+}
+
+func (o MaintenanceTrafficCheck) validate() error {
+	if o.ProbeId == "" && o.Interval <= 0 {
+		return errors.New("traffic check requires either a probe ID or a positive interval")
+	}
+	if o.ProbeId != "" && o.StageName == "" {
+		return fmt.Errorf("traffic check using IBA probe %q requires a stage name", o.ProbeId)
+	}
+	return nil
+}
+
+// MaintenanceRequest describes systems to be taken out of service.
+type MaintenanceRequest struct {
+	SystemNodeIds []ObjectId
+	Description   string // blueprint commit description
+	TrafficCheck  MaintenanceTrafficCheck
+
+	// AllowUncommittedChanges permits the drain when the staging blueprint
+	// already has uncommitted changes. Those changes are deployed along with
+	// the drain.
+	AllowUncommittedChanges bool
+}
+
+// MaintenanceWindow records the systems drained by BeginMaintenance and their
+// prior deploy modes. It may be persisted as JSON and later passed to
+// EndMaintenance.
+type MaintenanceWindow struct {
+	BlueprintId ObjectId                     `json:"blueprint_id"`
+	DeployModes map[ObjectId]enum.DeployMode `json:"deploy_modes"`
+	DrainedAt   time.Time                    `json:"drained_at"`
+}
+
+// maintenanceSafetyCheck ensures that draining the specified nodes will not
+// take down both members of a redundancy group (MLAG or ESI pair), or every
+// spine / superspine in the blueprint. Nodes must be switches currently in
+// deploy mode.
+func maintenanceSafetyCheck(nodes map[ObjectId]SystemNodeInfo, drain []ObjectId) error {
+	if len(drain) == 0 {
+		return errors.New("no systems to drain")
+	}
+
+	inService := func(n SystemNodeInfo) bool {
+		return n.DeployMode != nil && *n.DeployMode == enum.DeployModeDeploy && !slices.Contains(drain, n.Id)
+	}
+
+	for i, id := range drain {
+		if slices.Contains(drain[:i], id) {
+			return fmt.Errorf("system node %q is listed more than once", id)
+		}
+
+		node, ok := nodes[id]
+		if !ok {
+			return ClientErr{
+				errType: ErrNotfound,
+				err:     fmt.Errorf("system node %q not found", id),
+			}
+		}
+
+		switch node.Role {
+		case SystemRoleAccess, SystemRoleLeaf, SystemRoleSpine, SystemRoleSuperSpine:
+		default:
+			return fmt.Errorf("system node %q has role %s, only switches can be drained", id, node.Role)
+		}
+
+		if node.DeployMode == nil || *node.DeployMode != enum.DeployModeDeploy {
+			mode := enum.DeployModeNone
+			if node.DeployMode != nil {
+				mode = *node.DeployMode
+			}
+			return fmt.Errorf("system node %q is in deploy mode %q, expected %q", id, mode.Value, enum.DeployModeDeploy.Value)
+		}
+
+		if node.RedundancyGroupId != nil {
+			for _, peer := range nodes {
+				if peer.Id == id || peer.RedundancyGroupId == nil || *peer.RedundancyGroupId != *node.RedundancyGroupId {
+					continue
+				}
+				if !inService(peer) {
+					return fmt.Errorf("system node %q cannot be drained: its redundancy group peer %q would also be out of service", id, peer.Id)
+				}
+			}
+		}
+	}
+
+	for _, role := range []SystemRole{SystemRoleSpine, SystemRoleSuperSpine} {
+		var total, remaining int
+		for _, node := range nodes {
+			if node.Role != role {
+				continue
+			}
+			total++
+			if inService(node) {
+				remaining++
+			}
+		}
+		if total > 0 && remaining == 0 {
+			return fmt.Errorf("draining the requested systems would leave no %s in service", role)
+		}
+	}
+
+	return nil
+}
+
+// ibaStageTraffic sums the traffic reported by stage items belonging to the
+// specified systems, and returns the number of items matched. Items are
+// matched on their "system_id" property. For multi-value items only keys are
+// summed (all values when keys is empty).
+func ibaStageTraffic(items []IbaStageItem, systemIds []SystemId, keys []string) (float64, int) {
+	var result float64
+	var matched int
+	for _, item := range items {
+		if !slices.Contains(systemIds, SystemId(item.Properties["system_id"])) {
+			continue
+		}
+		matched++
+		if item.Value != nil {
+			result += *item.Value
+		}
+		for k, v := range item.Values {
+			if len(keys) == 0 || slices.Contains(keys, k) {
+				result += v
+			}
+		}
+	}
+	return result, matched
+}
+
+// ibaStageTrafficDrained reports whether the traffic the stage items report
+// for systemIds is below the check's threshold. An error is returned when
+// there are no systems, or when no item belongs to any of them, because the
+// threshold would otherwise be met without anything having been measured.
+func ibaStageTrafficDrained(items []IbaStageItem, systemIds []SystemId, check MaintenanceTrafficCheck) (bool, float64, error) {
+	if len(systemIds) == 0 {
+		return false, 0, errors.New("none of the drained system nodes has an assigned device")
+	}
+
+	traffic, matched := ibaStageTraffic(items, systemIds, check.ValueKeys)
+	if matched == 0 {
+		return false, 0, fmt.Errorf("stage %q of IBA probe %q has no items with a system_id property matching the drained systems %v",
+			check.StageName, check.ProbeId, systemIds)
+	}
+
+	return traffic < check.Threshold, traffic, nil
+}
+
+// deployStaging commits the staging blueprint.
+func (o *TwoStageL3ClosClient) deployStaging(ctx context.Context, description string) error {
+	status, err := o.client.GetBlueprintStatus(ctx, o.blueprintId)
+	if err != nil {
+		return fmt.Errorf("failed fetching blueprint %q status - %w", o.blueprintId, err)
+	}
+
+	response, err := o.client.DeployBlueprint(ctx, &BlueprintDeployRequest{
+		Id:          o.blueprintId,
+		Description: description,
+		Version:     status.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("failed deploying blueprint %q - %w", o.blueprintId, err)
+	}
+
+	if response.Status != DeployStatusSuccess {
+		var msg string
+		if response.Error != nil {
+			msg = *response.Error
+		}
+		return fmt.Errorf("deployment of blueprint %q version %d failed: %s", o.blueprintId, response.Version, msg)
+	}
+
+	return nil
+}
+
+// systemIdsByNodeId returns the system (device) IDs assigned to the specified
+// system nodes. Nodes with no assigned device are omitted.
+func (o *TwoStageL3ClosClient) systemIdsByNodeId(ctx context.Context, nodeIds []ObjectId) (map[ObjectId]SystemId, error) {
+	query := new(PathQuery).
+		SetBlueprintId(o.blueprintId).
+		SetClient(o.client).
+		Node([]QEEAttribute{NodeTypeSystem.QEEAttribute(), {Key: "name", Value: QEStringVal("n_system")}})
+
+	var queryResult struct {
+		Items []struct {
+			System struct {
+				Id       ObjectId  `json:"id"`
+				SystemId *SystemId `json:"system_id"`
+			} `json:"n_system"`
+		} `json:"items"`
+	}
+
+	err := query.Do(ctx, &queryResult)
+	if err != nil {
+		return nil, fmt.Errorf("graph query %q failed - %w", query, err)
+	}
+
+	result := make(map[ObjectId]SystemId, len(nodeIds))
+	for _, item := range queryResult.Items {
+		if item.System.SystemId != nil && slices.Contains(nodeIds, item.System.Id) {
+			result[item.System.Id] = *item.System.SystemId
+		}
+	}
+
+	return result, nil
+}
+
+// waitForTrafficToDrain implements MaintenanceTrafficCheck.
+func (o *TwoStageL3ClosClient) waitForTrafficToDrain(ctx context.Context, nodeIds []ObjectId, check MaintenanceTrafficCheck) error {
+	if check.ProbeId == "" {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(check.Interval):
+			return nil
+		}
+	}
+
+	systemIdMap, err := o.systemIdsByNodeId(ctx, nodeIds)
+	if err != nil {
+		return err
+	}
+	systemIds := make([]SystemId, 0, len(systemIdMap))
+	for _, systemId := range systemIdMap {
+		systemIds = append(systemIds, systemId)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cmp.Or(check.Timeout, maintenanceTimeoutDefault))
+	defer cancel()
+
+	for {
+		items, err := o.client.getIbaProbeStageData(ctx, o.blueprintId, check.ProbeId, check.StageName)
+		if err != nil {
+			return fmt.Errorf("failed reading stage %q of IBA probe %q - %w", check.StageName, check.ProbeId, err)
+		}
+
+		drained, traffic, err := ibaStageTrafficDrained(items, systemIds, check)
+		if err != nil {
+			return err
+		}
+		if drained {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("drained systems still report traffic %g, threshold %g - %w", traffic, check.Threshold, ctx.Err())
+		case <-time.After(cmp.Or(check.PollInterval, maintenancePollIntervalDefault)):
+		}
+	}
+}
+
+func (o *TwoStageL3ClosClient) setDeployMode(ctx context.Context, nodeId ObjectId, mode enum.DeployMode) error {
+	err := o.PatchNode(ctx, nodeId, &struct {
+		DeployMode enum.DeployMode `json:"deploy_mode"`
+	}{DeployMode: mode}, nil)
+	if err != nil {
+		return fmt.Errorf("failed setting deploy mode of system node %q to %q - %w", nodeId, mode.Value, err)
+	}
+	return nil
+}
+
+// BeginMaintenance takes the requested systems out of service: after safety
+// checks (see MaintenanceRequest) each system is set to drain mode, the
+// blueprint is committed, and the traffic check is run. The returned
+// MaintenanceWindow must be passed to EndMaintenance to return the systems to
+// service. If the traffic check fails, the systems remain drained and the
+// window is returned along with the error.
+func (o *TwoStageL3ClosClient) BeginMaintenance(ctx context.Context, req MaintenanceRequest) (*MaintenanceWindow, error) {
+	status, err := o.client.GetBlueprintStatus(ctx, o.blueprintId)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching blueprint %q status - %w", o.blueprintId, err)
+	}
+	if status.HasUncommittedChanges && !req.AllowUncommittedChanges {
+		return nil, fmt.Errorf("blueprint %q has uncommitted changes", o.blueprintId)
+	}
+
+	nodes, err := o.GetAllSystemNodeInfos(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching system nodes - %w", err)
+	}
+
+	err = maintenanceSafetyCheck(nodes, req.SystemNodeIds)
+	if err != nil {
+		return nil, err
+	}
+
+	err = req.TrafficCheck.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	window := MaintenanceWindow{
+		BlueprintId: o.blueprintId,
+		DeployModes: make(map[ObjectId]enum.DeployMode, len(req.SystemNodeIds)),
+	}
+	for _, id := range req.SystemNodeIds {
+		window.DeployModes[id] = *nodes[id].DeployMode
+	}
+
+	for _, id := range req.SystemNodeIds {
+		err = o.setDeployMode(ctx, id, enum.DeployModeDrain)
+		if err != nil {
+			return &window, err
+		}
+	}
+
+	err = o.deployStaging(ctx, cmp.Or(req.Description, "drain systems for maintenance"))
+	if err != nil {
+		return &window, err
+	}
+	window.DrainedAt = time.Now()
+
+	err = o.waitForTrafficToDrain(ctx, req.SystemNodeIds, req.TrafficCheck)
+	if err != nil {
+		return &window, err
+	}
+
+	return &window, nil
+}
+
+// EndMaintenance returns the systems drained by BeginMaintenance to their
+// prior deploy modes and commits the blueprint.
+func (o *TwoStageL3ClosClient) EndMaintenance(ctx context.Context, window MaintenanceWindow, description string) error {
+	if window.BlueprintId != o.blueprintId {
+		return fmt.Errorf("maintenance window belongs to blueprint %q, not %q", window.BlueprintId, o.blueprintId)
+	}
+
+	ids := make([]ObjectId, 0, len(window.DeployModes))
+	for id := range window.DeployModes {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	for _, id := range ids {
+		err := o.setDeployMode(ctx, id, window.DeployModes[id])
+		if err != nil {
+			return err
+		}
+	}
+
+	return o.deployStaging(ctx, cmp.Or(description, "restore systems following maintenance"))
+}
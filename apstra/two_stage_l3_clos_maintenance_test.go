@@ -0,0 +1,224 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceSafetyCheck(t *testing.T) {
+	node := func(id string, role SystemRole, mode enum.DeployMode, rgId string) SystemNodeInfo {
+		result := SystemNodeInfo{Id: ObjectId(id), Role: role, DeployMode: &mode}
+		if rgId != "" {
+			result.RedundancyGroupId = pointer.To(ObjectId(rgId))
+		}
+		return result
+	}
+
+	nodes := make(map[ObjectId]SystemNodeInfo)
+	for _, n := range []SystemNodeInfo{
+		node("spine1", SystemRoleSpine, enum.DeployModeDeploy, ""),
+		node("spine2", SystemRoleSpine, enum.DeployModeDeploy, ""),
+		node("leaf1a", SystemRoleLeaf, enum.DeployModeDeploy, "rg1"),
+		node("leaf1b", SystemRoleLeaf, enum.DeployModeDeploy, "rg1"),
+		node("leaf2a", SystemRoleLeaf, enum.DeployModeDeploy, "rg2"),
+		node("leaf2b", SystemRoleLeaf, enum.DeployModeUndeploy, "rg2"),
+		node("leaf3", SystemRoleLeaf, enum.DeployModeDrain, ""),
+		node("generic", SystemRoleGeneric, enum.DeployModeDeploy, ""),
+	} {
+		nodes[n.Id] = n
+	}
+
+	type testCase struct {
+		drain  []ObjectId
+		expErr bool
+	}
+
+	testCases := map[string]testCase{
+		"one_mlag_member": {
+			drain: []ObjectId{"leaf1a"},
+		},
+		"one_mlag_member_and_one_spine": {
+			drain: []ObjectId{"leaf1b", "spine2"},
+		},
+		"both_mlag_members": {
+			drain:  []ObjectId{"leaf1a", "leaf1b"},
+			expErr: true,
+		},
+		"mlag_peer_already_out_of_service": {
+			drain:  []ObjectId{"leaf2a"},
+			expErr: true,
+		},
+		"every_spine": {
+			drain:  []ObjectId{"spine1", "spine2"},
+			expErr: true,
+		},
+		"already_drained": {
+			drain:  []ObjectId{"leaf3"},
+			expErr: true,
+		},
+		"not_a_switch": {
+			drain:  []ObjectId{"generic"},
+			expErr: true,
+		},
+		"duplicate": {
+			drain:  []ObjectId{"leaf1a", "leaf1a"},
+			expErr: true,
+		},
+		"unknown_node": {
+			drain:  []ObjectId{"bogus"},
+			expErr: true,
+		},
+		"empty": {
+			expErr: true,
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			err := maintenanceSafetyCheck(nodes, tCase.drain)
+			if tCase.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestIbaStageTraffic(t *testing.T) {
+	items := []IbaStageItem{
+		{Properties: map[string]string{"system_id": "SN1", "interface": "et-0/0/0"}, Values: map[string]float64{"tx_bps": 100, "rx_bps": 20}},
+		{Properties: map[string]string{"system_id": "SN1", "interface": "et-0/0/1"}, Values: map[string]float64{"tx_bps": 5, "rx_bps": 1}},
+		{Properties: map[string]string{"system_id": "SN2", "interface": "et-0/0/0"}, Values: map[string]float64{"tx_bps": 1000}},
+		{Properties: map[string]string{"system_id": "SN3"}, Value: pointer.To(7.0)},
+	}
+
+	type testCase struct {
+		systemIds  []SystemId
+		keys       []string
+		expTraffic float64
+		expMatched int
+	}
+
+	testCases := map[string]testCase{
+		"all_keys": {
+			systemIds:  []SystemId{"SN1"},
+			expTraffic: 126,
+			expMatched: 2,
+		},
+		"tx_only": {
+			systemIds:  []SystemId{"SN1"},
+			keys:       []string{"tx_bps"},
+			expTraffic: 105,
+			expMatched: 2,
+		},
+		"single_and_multi_value": {
+			systemIds:  []SystemId{"SN2", "SN3"},
+			expTraffic: 1007,
+			expMatched: 2,
+		},
+		"unknown_system": {
+			systemIds:  []SystemId{"SN9"},
+			expMatched: 0,
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			traffic, matched := ibaStageTraffic(items, tCase.systemIds, tCase.keys)
+			require.Equal(t, tCase.expTraffic, traffic)
+			require.Equal(t, tCase.expMatched, matched)
+		})
+	}
+}
+
+func TestIbaStageTrafficDrained(t *testing.T) {
+	items := []IbaStageItem{
+		{Properties: map[string]string{"system_id": "SN1"}, Values: map[string]float64{"tx_bps": 100}},
+		{Properties: map[string]string{"interface": "et-0/0/0"}, Values: map[string]float64{"tx_bps": 5}},
+	}
+
+	type testCase struct {
+		items      []IbaStageItem
+		systemIds  []SystemId
+		threshold  float64
+		expDrained bool
+		expErr     bool
+	}
+
+	testCases := map[string]testCase{
+		"below_threshold": {
+			items:      items,
+			systemIds:  []SystemId{"SN1"},
+			threshold:  1000,
+			expDrained: true,
+		},
+		"above_threshold": {
+			items:     items,
+			systemIds: []SystemId{"SN1"},
+			threshold: 10,
+		},
+		"no_systems": {
+			items:     items,
+			threshold: 1000,
+			expErr:    true,
+		},
+		"no_matching_items": {
+			items:     items,
+			systemIds: []SystemId{"SN2"},
+			threshold: 1000,
+			expErr:    true,
+		},
+		"no_items": {
+			systemIds: []SystemId{"SN1"},
+			threshold: 1000,
+			expErr:    true,
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			drained, _, err := ibaStageTrafficDrained(tCase.items, tCase.systemIds, MaintenanceTrafficCheck{Threshold: tCase.threshold})
+			if tCase.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tCase.expDrained, drained)
+		})
+	}
+}
+
+func TestMaintenanceTrafficCheckValidate(t *testing.T) {
+	type testCase struct {
+		check  MaintenanceTrafficCheck
+		expErr bool
+	}
+
+	testCases := map[string]testCase{
+		"interval":          {check: MaintenanceTrafficCheck{Interval: time.Minute}},
+		"probe":             {check: MaintenanceTrafficCheck{ProbeId: "probe", StageName: "stage"}},
+		"zero_interval":     {check: MaintenanceTrafficCheck{}, expErr: true},
+		"negative_interval": {check: MaintenanceTrafficCheck{Interval: -time.Second}, expErr: true},
+		"probe_no_stage":    {check: MaintenanceTrafficCheck{ProbeId: "probe"}, expErr: true},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			err := tCase.check.validate()
+			if tCase.expErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,412 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+// ResourceInventory counts the blueprint objects which consume resources.
+type ResourceInventory struct {
+	Systems              map[string]int // by system role
+	Loopbacks            map[string]int // systems with a loopback interface, by system role
+	Asns                 map[string]int // systems with an autonomous system domain, by system role
+	Links                map[string]int // by link role
+	MlagDomains          int
+	EvpnSecurityZones    int
+	VxlanVirtualNetworks int
+}
+
+// ResourceRequirement is the number of resources (ASNs, VNIs or individual IP
+// addresses) required by a resource group, along with the pools currently
+// assigned to it.
+type ResourceRequirement struct {
+	ResourceGroup ResourceGroup
+	Count         uint64
+	PoolIds       []ObjectId
+}
+
+// resourceRequirementCount returns the number of resources required by the
+// named group. IP link groups require two addresses (a /31 or /127) per link.
+// Virtual network SVI subnets depend on per-network choices and are reported
+// as zero.
+func resourceRequirementCount(name ResourceGroupName, inv ResourceInventory) uint64 {
+	link := func(role enum.LinkRole) uint64 { return 2 * uint64(inv.Links[role.Value]) }
+
+	switch name {
+	case ResourceGroupNameSuperspineAsn:
+		return uint64(inv.Asns["superspine"])
+	case ResourceGroupNameSpineAsn:
+		return uint64(inv.Asns["spine"])
+	case ResourceGroupNameLeafAsn:
+		return uint64(inv.Asns["leaf"])
+	case ResourceGroupNameAccessAsn:
+		return uint64(inv.Asns["access"])
+	case ResourceGroupNameGenericAsn:
+		return uint64(inv.Asns["generic"])
+	case ResourceGroupNameSuperspineIp4, ResourceGroupNameSuperspineIp6:
+		return uint64(inv.Loopbacks["superspine"])
+	case ResourceGroupNameSpineIp4, ResourceGroupNameSpineIp6:
+		return uint64(inv.Loopbacks["spine"])
+	case ResourceGroupNameLeafIp4, ResourceGroupNameLeafIp6:
+		return uint64(inv.Loopbacks["leaf"])
+	case ResourceGroupNameAccessIp4:
+		return uint64(inv.Loopbacks["access"])
+	case ResourceGroupNameGenericIp4, ResourceGroupNameGenericIp6:
+		return uint64(inv.Loopbacks["generic"])
+	case ResourceGroupNameSuperspineSpineIp4, ResourceGroupNameSuperspineSpineIp6:
+		return link(enum.LinkRoleSpineSuperspine)
+	case ResourceGroupNameSpineLeafIp4, ResourceGroupNameSpineLeafIp6:
+		return link(enum.LinkRoleSpineLeaf)
+	case ResourceGroupNameAccessAccessIp4:
+		return link(enum.LinkRoleAccessL3PeerLink)
+	case ResourceGroupNameLeafLeafIp4, ResourceGroupNameLeafLeafIp6:
+		return link(enum.LinkRoleLeafLeaf)
+	case ResourceGroupNameLeafL3PeerLinkLinkIp4, ResourceGroupNameLeafL3PeerLinkLinkIp6:
+		return link(enum.LinkRoleLeafL3PeerLink)
+	case ResourceGroupNameToGenericLinkIpv4, ResourceGroupNameToGenericLinkIpv6:
+		return link(enum.LinkRoleToGeneric)
+	case ResourceGroupNameMlagDomainIp4, ResourceGroupNameMlagDomainIp6:
+		return 2 * uint64(inv.MlagDomains)
+	case ResourceGroupNameVtepIp4:
+		return uint64(inv.Systems["leaf"] + inv.MlagDomains) // MLAG pairs add an anycast VTEP
+	case ResourceGroupNameEvpnL3Vni:
+		return uint64(inv.EvpnSecurityZones)
+	case ResourceGroupNameVxlanVnIds:
+		return uint64(inv.VxlanVirtualNetworks)
+	}
+	return 0
+}
+
+// NewResourceRequirements computes the requirement of each resource group in
+// allocations (as returned by GetResourceAllocations, which includes
+// unassigned groups) from the blueprint inventory.
+func NewResourceRequirements(allocations ResourceGroupAllocations, inv ResourceInventory) []ResourceRequirement {
+	result := make([]ResourceRequirement, len(allocations))
+	for i, alloc := range allocations {
+		result[i] = ResourceRequirement{
+			ResourceGroup: alloc.ResourceGroup,
+			Count:         resourceRequirementCount(alloc.ResourceGroup.Name, inv),
+			PoolIds:       alloc.PoolIds,
+		}
+	}
+	return result
+}
+
+// PoolSelector restricts the pools considered for automatic assignment. In both
+// fields, the string "{group}" is replaced by the resource group name (e.g.
+// "leaf_asns") so that one selector can express a naming convention.
+type PoolSelector struct {
+	Tags        []string // candidate pools must carry every tag
+	NamePattern string   // regular expression matched against candidate pool display names
+}
+
+func (o PoolSelector) matcher(name ResourceGroupName) (func(pool PoolUtilization) bool, error) {
+	expand := func(s string) string { return strings.ReplaceAll(s, "{group}", name.String()) }
+
+	var re *regexp.Regexp
+	if o.NamePattern != "" {
+		var err error
+		if re, err = regexp.Compile(expand(o.NamePattern)); err != nil {
+			return nil, fmt.Errorf("invalid pool name pattern for resource group %q - %w", name, err)
+		}
+	}
+
+	wantTags := make([]string, len(o.Tags))
+	for i, tag := range o.Tags {
+		wantTags[i] = expand(tag)
+	}
+
+	return func(pool PoolUtilization) bool {
+		if re != nil && !re.MatchString(pool.Label) {
+			return false
+		}
+		for _, tag := range wantTags {
+			if !slices.Contains(pool.Tags, tag) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// ResourceShortfall describes a resource group whose requirement cannot be met
+// by the free capacity of the matching pools.
+type ResourceShortfall struct {
+	ResourceGroup ResourceGroup
+	Count         uint64
+	Available     *big.Int
+}
+
+func (o ResourceShortfall) String() string {
+	return fmt.Sprintf("%s requires %d but matching pools have %s free", resourceGroupString(o.ResourceGroup), o.Count, o.Available)
+}
+
+// ResourceAssignmentPlan lists the pool assignments which satisfy a blueprint's
+// resource requirements.
+type ResourceAssignmentPlan struct {
+	Requirements []ResourceRequirement
+	Assignments  []ResourceGroupAllocation
+	Shortfalls   []ResourceShortfall
+}
+
+// NewResourceAssignmentPlan selects pools for each unassigned resource group
+// with a non-zero requirement. Candidate pools are those of the correct type
+// which match selector. A single pool with enough free capacity is preferred,
+// choosing the one with the least free capacity; otherwise the candidates with
+// the most free capacity are combined. Capacity claimed by one group is not
+// available to the next. Free capacity of IP pools is counted in addresses, so
+// fragmentation may leave an aligned /31 unavailable even when two addresses
+// are free.
+func NewResourceAssignmentPlan(requirements []ResourceRequirement, report *PoolUtilizationReport, selector PoolSelector) (*ResourceAssignmentPlan, error) {
+	result := ResourceAssignmentPlan{Requirements: requirements}
+
+	free := make(map[ObjectId]*big.Int, len(report.Pools))
+	for _, pool := range report.Pools {
+		free[pool.Id] = new(big.Int).Set(pool.Free)
+	}
+
+	for _, req := range requirements {
+		if req.Count == 0 || len(req.PoolIds) > 0 {
+			continue
+		}
+
+		match, err := selector.matcher(req.ResourceGroup.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		var candidates []PoolUtilization
+		for _, pool := range report.Pools {
+			if pool.Type == req.ResourceGroup.Type && free[pool.Id].Sign() > 0 && match(pool) {
+				candidates = append(candidates, pool)
+			}
+		}
+		slices.SortFunc(candidates, func(a, b PoolUtilization) int {
+			return cmp.Or(free[a.Id].Cmp(free[b.Id]), strings.Compare(a.Label, b.Label))
+		})
+
+		need := new(big.Int).SetUint64(req.Count)
+
+		// best fit: the smallest single pool which satisfies the requirement
+		if i := slices.IndexFunc(candidates, func(p PoolUtilization) bool { return free[p.Id].Cmp(need) >= 0 }); i >= 0 {
+			free[candidates[i].Id].Sub(free[candidates[i].Id], need)
+			result.Assignments = append(result.Assignments, ResourceGroupAllocation{
+				ResourceGroup: req.ResourceGroup,
+				PoolIds:       []ObjectId{candidates[i].Id},
+			})
+			continue
+		}
+
+		// combine the largest pools
+		available := new(big.Int)
+		for _, c := range candidates {
+			available.Add(available, free[c.Id])
+		}
+		if available.Cmp(need) < 0 {
+			result.Shortfalls = append(result.Shortfalls, ResourceShortfall{
+				ResourceGroup: req.ResourceGroup,
+				Count:         req.Count,
+				Available:     available,
+			})
+			continue
+		}
+
+		alloc := ResourceGroupAllocation{ResourceGroup: req.ResourceGroup}
+		for _, c := range slices.Backward(candidates) {
+			take := new(big.Int).Set(free[c.Id])
+			if take.Cmp(need) > 0 {
+				take.Set(need)
+			}
+			free[c.Id].Sub(free[c.Id], take)
+			need.Sub(need, take)
+			alloc.PoolIds = append(alloc.PoolIds, c.Id)
+			if need.Sign() == 0 {
+				break
+			}
+		}
+		result.Assignments = append(result.Assignments, alloc)
+	}
+
+	return &result, nil
+}
+
+func resourceGroupString(rg ResourceGroup) string {
+	if rg.SecurityZoneId != nil {
+		return fmt.Sprintf("resource group %q (security zone %q)", rg.Name, *rg.SecurityZoneId)
+	}
+	return fmt.Sprintf("resource group %q", rg.Name)
+}
+
+// countGraphNodes runs query and counts the results by the value of attribute
+// key on the node named name. An empty key counts all results under "".
+func (o *TwoStageL3ClosClient) countGraphNodes(ctx context.Context, query *PathQuery, name, key string) (map[string]int, error) {
+	var response struct {
+		Items []map[string]map[string]any `json:"items"`
+	}
+	err := query.
+		SetBlueprintId(o.blueprintId).
+		SetBlueprintType(BlueprintTypeStaging).
+		SetClient(o.client).
+		Do(ctx, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]int)
+	for _, item := range response.Items {
+		var value string
+		if v, ok := item[name][key]; ok && v != nil {
+			value = fmt.Sprint(v)
+		}
+		result[value]++
+	}
+	return result, nil
+}
+
+// GetResourceInventory counts the objects in the blueprint which consume
+// resources.
+func (o *TwoStageL3ClosClient) GetResourceInventory(ctx context.Context) (*ResourceInventory, error) {
+	var result ResourceInventory
+	var err error
+
+	result.Systems, err = o.countGraphNodes(ctx, new(PathQuery).
+		Node([]QEEAttribute{
+			NodeTypeSystem.QEEAttribute(),
+			{Key: "name", Value: QEStringVal("n_system")},
+		}), "n_system", "role")
+	if err != nil {
+		return nil, fmt.Errorf("failed counting systems in blueprint %q - %w", o.blueprintId, err)
+	}
+
+	result.Loopbacks, err = o.countGraphNodes(ctx, new(PathQuery).
+		Node([]QEEAttribute{
+			NodeTypeSystem.QEEAttribute(),
+			{Key: "name", Value: QEStringVal("n_system")},
+		}).
+		Out([]QEEAttribute{RelationshipTypeHostedInterfaces.QEEAttribute()}).
+		Node([]QEEAttribute{
+			NodeTypeInterface.QEEAttribute(),
+			{Key: "if_type", Value: QEStringVal("loopback")},
+			{Key: "loopback_id", Value: QEIntVal(0)},
+		}), "n_system", "role")
+	if err != nil {
+		return nil, fmt.Errorf("failed counting loopbacks in blueprint %q - %w", o.blueprintId, err)
+	}
+
+	result.Asns, err = o.countGraphNodes(ctx, new(PathQuery).
+		Node([]QEEAttribute{
+			NodeTypeDomain.QEEAttribute(),
+			{Key: "domain_type", Value: QEStringVal("autonomous_system")},
+		}).
+		Out([]QEEAttribute{RelationshipTypeComposedOfSystems.QEEAttribute()}).
+		Node([]QEEAttribute{
+			NodeTypeSystem.QEEAttribute(),
+			{Key: "name", Value: QEStringVal("n_system")},
+		}), "n_system", "role")
+	if err != nil {
+		return nil, fmt.Errorf("failed counting autonomous systems in blueprint %q - %w", o.blueprintId, err)
+	}
+
+	result.Links, err = o.countGraphNodes(ctx, new(PathQuery).
+		Node([]QEEAttribute{
+			NodeTypeLink.QEEAttribute(),
+			{Key: "name", Value: QEStringVal("n_link")},
+		}), "n_link", "role")
+	if err != nil {
+		return nil, fmt.Errorf("failed counting links in blueprint %q - %w", o.blueprintId, err)
+	}
+
+	counts := []struct {
+		target *int
+		what   string
+		node   []QEEAttribute
+	}{
+		{&result.MlagDomains, "MLAG domains", []QEEAttribute{NodeTypeRedundancyGroup.QEEAttribute(), {Key: "rg_type", Value: QEStringVal("mlag")}}},
+		{&result.EvpnSecurityZones, "routing zones", []QEEAttribute{NodeTypeSecurityZone.QEEAttribute(), {Key: "sz_type", Value: QEStringVal("evpn")}}},
+		{&result.VxlanVirtualNetworks, "virtual networks", []QEEAttribute{NodeTypeVirtualNetwork.QEEAttribute(), {Key: "vn_type", Value: QEStringVal("vxlan")}}},
+	}
+	for _, c := range counts {
+		m, err := o.countGraphNodes(ctx, new(PathQuery).Node(append(c.node, QEEAttribute{Key: "name", Value: QEStringVal("n_node")})), "n_node", "")
+		if err != nil {
+			return nil, fmt.Errorf("failed counting %s in blueprint %q - %w", c.what, o.blueprintId, err)
+		}
+		*c.target = m[""]
+	}
+
+	return &result, nil
+}
+
+// GetResourceRequirements returns the requirement of every resource group in
+// the blueprint, whether or not pools have been assigned.
+func (o *TwoStageL3ClosClient) GetResourceRequirements(ctx context.Context) ([]ResourceRequirement, error) {
+	allocations, err := o.GetResourceAllocations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching resource allocations from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	inv, err := o.GetResourceInventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResourceRequirements(allocations, *inv), nil
+}
+
+// PlanResourceAssignment computes the blueprint's resource requirements and
+// selects pools for every unassigned resource group from the pools which
+// match selector.
+func (o *TwoStageL3ClosClient) PlanResourceAssignment(ctx context.Context, selector PoolSelector) (*ResourceAssignmentPlan, error) {
+	requirements, err := o.GetResourceRequirements(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var state PoolUtilizationState
+	if state.AsnPools, err = o.client.GetAsnPools(ctx); err != nil {
+		return nil, fmt.Errorf("failed fetching ASN pools - %w", err)
+	}
+	if state.VniPools, err = o.client.GetVniPools(ctx); err != nil {
+		return nil, fmt.Errorf("failed fetching VNI pools - %w", err)
+	}
+	if state.Ip4Pools, err = o.client.GetIp4Pools(ctx); err != nil {
+		return nil, fmt.Errorf("failed fetching IPv4 pools - %w", err)
+	}
+	if state.Ip6Pools, err = o.client.GetIp6Pools(ctx); err != nil {
+		return nil, fmt.Errorf("failed fetching IPv6 pools - %w", err)
+	}
+
+	return NewResourceAssignmentPlan(requirements, NewPoolUtilizationReport(state), selector)
+}
+
+// ApplyResourceAssignment assigns the planned pools to the blueprint's resource
+// groups. Nothing is assigned when the plan has shortfalls.
+func (o *TwoStageL3ClosClient) ApplyResourceAssignment(ctx context.Context, plan *ResourceAssignmentPlan) error {
+	if len(plan.Shortfalls) > 0 {
+		msgs := make([]string, len(plan.Shortfalls))
+		for i, s := range plan.Shortfalls {
+			msgs[i] = s.String()
+		}
+		return errors.New("insufficient pool capacity: " + strings.Join(msgs, "; "))
+	}
+
+	for _, alloc := range plan.Assignments {
+		err := o.SetResourceAllocation(ctx, &alloc)
+		if err != nil {
+			return fmt.Errorf("failed assigning pools %v to %s in blueprint %q - %w", alloc.PoolIds, resourceGroupString(alloc.ResourceGroup), o.blueprintId, err)
+		}
+	}
+
+	return nil
+}
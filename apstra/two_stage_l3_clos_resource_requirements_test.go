@@ -0,0 +1,101 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewResourceRequirements(t *testing.T) {
+	sz := ObjectId("sz1")
+	allocations := ResourceGroupAllocations{
+		{ResourceGroup: ResourceGroup{Type: ResourceTypeAsnPool, Name: ResourceGroupNameLeafAsn}, PoolIds: []ObjectId{"asn"}},
+		{ResourceGroup: ResourceGroup{Type: ResourceTypeAsnPool, Name: ResourceGroupNameGenericAsn}},
+		{ResourceGroup: ResourceGroup{Type: ResourceTypeIp4Pool, Name: ResourceGroupNameSpineLeafIp4}},
+		{ResourceGroup: ResourceGroup{Type: ResourceTypeIp4Pool, Name: ResourceGroupNameVtepIp4}},
+		{ResourceGroup: ResourceGroup{Type: ResourceTypeIp4Pool, Name: ResourceGroupNameLeafIp4, SecurityZoneId: &sz}},
+		{ResourceGroup: ResourceGroup{Type: ResourceTypeVniPool, Name: ResourceGroupNameEvpnL3Vni}},
+		{ResourceGroup: ResourceGroup{Type: ResourceTypeIp4Pool, Name: ResourceGroupNameVirtualNetworkSviIpv4}},
+	}
+	inv := ResourceInventory{
+		Systems:           map[string]int{"spine": 2, "leaf": 4, "generic": 6},
+		Loopbacks:         map[string]int{"spine": 2, "leaf": 4},
+		Asns:              map[string]int{"spine": 2, "leaf": 4, "generic": 1},
+		Links:             map[string]int{"spine_leaf": 8, "to_generic": 12},
+		MlagDomains:       1,
+		EvpnSecurityZones: 3,
+	}
+
+	var counts []uint64
+	for _, req := range NewResourceRequirements(allocations, inv) {
+		counts = append(counts, req.Count)
+	}
+	require.Equal(t, []uint64{4, 1, 16, 5, 4, 3, 0}, counts)
+}
+
+func TestNewResourceAssignmentPlan(t *testing.T) {
+	asn := func(id, label string, total, used uint32, tags ...string) AsnPool {
+		return AsnPool{Id: ObjectId(id), DisplayName: label, Total: total, Used: used, Tags: tags}
+	}
+	report := NewPoolUtilizationReport(PoolUtilizationState{
+		AsnPools: []AsnPool{
+			asn("big", "dc1-leaf_asns-big", 100, 0, "dc1"),
+			asn("small", "dc1-leaf_asns-small", 10, 2, "dc1"),
+			asn("other", "dc2-leaf_asns", 1000, 0, "dc2"),
+			asn("spine", "dc1-spine_asns", 4, 0, "dc1"),
+		},
+	})
+
+	req := func(name ResourceGroupName, count uint64, pools ...ObjectId) ResourceRequirement {
+		return ResourceRequirement{ResourceGroup: ResourceGroup{Type: name.Type(), Name: name}, Count: count, PoolIds: pools}
+	}
+
+	selector := PoolSelector{Tags: []string{"dc1"}, NamePattern: "^dc1-{group}"}
+
+	t.Run("best_fit", func(t *testing.T) {
+		plan, err := NewResourceAssignmentPlan([]ResourceRequirement{
+			req(ResourceGroupNameLeafAsn, 8),
+			req(ResourceGroupNameSpineAsn, 2),
+			req(ResourceGroupNameGenericAsn, 0),
+			req(ResourceGroupNameAccessAsn, 5, "already"),
+		}, report, selector)
+		require.NoError(t, err)
+		require.Empty(t, plan.Shortfalls)
+		require.Equal(t, []ResourceGroupAllocation{
+			{ResourceGroup: ResourceGroup{Type: ResourceTypeAsnPool, Name: ResourceGroupNameLeafAsn}, PoolIds: []ObjectId{"small"}},
+			{ResourceGroup: ResourceGroup{Type: ResourceTypeAsnPool, Name: ResourceGroupNameSpineAsn}, PoolIds: []ObjectId{"spine"}},
+		}, plan.Assignments)
+	})
+
+	t.Run("combined", func(t *testing.T) {
+		plan, err := NewResourceAssignmentPlan([]ResourceRequirement{req(ResourceGroupNameLeafAsn, 105)}, report, selector)
+		require.NoError(t, err)
+		require.Empty(t, plan.Shortfalls)
+		require.Equal(t, []ObjectId{"big", "small"}, plan.Assignments[0].PoolIds)
+	})
+
+	t.Run("shortfall", func(t *testing.T) {
+		plan, err := NewResourceAssignmentPlan([]ResourceRequirement{
+			req(ResourceGroupNameSpineAsn, 3),
+			req(ResourceGroupNameSpineAsn, 3), // capacity claimed by the first requirement
+			req(ResourceGroupNameVxlanVnIds, 1),
+		}, report, selector)
+		require.NoError(t, err)
+		require.Len(t, plan.Assignments, 1)
+		require.Len(t, plan.Shortfalls, 2)
+		require.Equal(t, `resource group "spine_asns" requires 3 but matching pools have 1 free`, plan.Shortfalls[0].String())
+		require.Equal(t, int64(0), plan.Shortfalls[1].Available.Int64())
+
+		err = new(TwoStageL3ClosClient).ApplyResourceAssignment(t.Context(), plan)
+		require.ErrorContains(t, err, "insufficient pool capacity")
+	})
+
+	t.Run("bad_pattern", func(t *testing.T) {
+		_, err := NewResourceAssignmentPlan([]ResourceRequirement{req(ResourceGroupNameLeafAsn, 1)}, report, PoolSelector{NamePattern: "("})
+		require.ErrorContains(t, err, "invalid pool name pattern")
+	})
+}
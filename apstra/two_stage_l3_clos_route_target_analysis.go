@@ -0,0 +1,354 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+// RouteTargetState holds the route target configuration of a single blueprint.
+type RouteTargetState struct {
+	BlueprintID            string
+	SecurityZones          []datacenter.SecurityZone
+	VirtualNetworks        []datacenter.VirtualNetwork
+	EVPNInterconnectGroups []EVPNInterconnectGroup
+}
+
+// GetRouteTargetState collects the security zones, virtual networks and EVPN
+// interconnect groups which determine the blueprint's route target usage.
+func (o *TwoStageL3ClosClient) GetRouteTargetState(ctx context.Context) (*RouteTargetState, error) {
+	result := RouteTargetState{BlueprintID: o.blueprintId.String()}
+	var err error
+
+	result.SecurityZones, err = o.GetSecurityZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching security zones from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	result.VirtualNetworks, err = o.GetVirtualNetworks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching virtual networks from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	result.EVPNInterconnectGroups, err = o.GetAllEVPNInterconnectGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching EVPN interconnect groups from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	return &result, nil
+}
+
+type routeTargetOrigin int
+
+const (
+	routeTargetOriginImplicit     = routeTargetOrigin(iota) // calculated by Apstra
+	routeTargetOriginPolicy                                 // from an RTPolicy
+	routeTargetOriginInterconnect                           // from an EVPN interconnect group
+)
+
+// RouteTargetNode is a VRF (security zone) or MAC-VRF (virtual network) which
+// imports and exports route targets.
+type RouteTargetNode struct {
+	BlueprintID      string
+	SecurityZoneID   string // for virtual networks, the VN's security zone
+	VirtualNetworkID string // empty for security zones
+	Label            string
+	Imports          []string
+	Exports          []string
+
+	importOrigins map[string]routeTargetOrigin
+	exportOrigins map[string]routeTargetOrigin
+}
+
+func (o RouteTargetNode) String() string {
+	if o.VirtualNetworkID != "" {
+		return fmt.Sprintf("virtual network %q (%s) in blueprint %q", o.Label, o.VirtualNetworkID, o.BlueprintID)
+	}
+	return fmt.Sprintf("routing zone %q (%s) in blueprint %q", o.Label, o.SecurityZoneID, o.BlueprintID)
+}
+
+func (o *RouteTargetNode) isVirtualNetwork() bool {
+	return o.VirtualNetworkID != ""
+}
+
+func (o *RouteTargetNode) addImport(rt string, origin routeTargetOrigin) {
+	rt = normalizeRouteTarget(rt)
+	if _, ok := o.importOrigins[rt]; ok {
+		o.importOrigins[rt] = max(o.importOrigins[rt], origin)
+		return
+	}
+	o.importOrigins[rt] = origin
+	o.Imports = append(o.Imports, rt)
+}
+
+func (o *RouteTargetNode) addExport(rt string, origin routeTargetOrigin) {
+	rt = normalizeRouteTarget(rt)
+	if _, ok := o.exportOrigins[rt]; ok {
+		o.exportOrigins[rt] = max(o.exportOrigins[rt], origin)
+		return
+	}
+	o.exportOrigins[rt] = origin
+	o.Exports = append(o.Exports, rt)
+}
+
+// normalizeRouteTarget converts strings like " target:65000:100" to "65000:100".
+func normalizeRouteTarget(rt string) string {
+	rt = strings.ToLower(strings.TrimSpace(rt))
+	return strings.TrimPrefix(rt, "target:")
+}
+
+// RouteTargetLeak indicates that routes from From are imported by To.
+type RouteTargetLeak struct {
+	From         *RouteTargetNode
+	To           *RouteTargetNode
+	RouteTargets []string
+}
+
+// RouteTargetGraph is the route leaking graph of one or more blueprints.
+// Leaks are computed between security zones and between virtual networks;
+// blueprints are connected wherever they share route targets.
+type RouteTargetGraph struct {
+	Nodes []*RouteTargetNode
+	Leaks []RouteTargetLeak
+}
+
+// NewRouteTargetGraph builds the route leaking graph of the supplied blueprints.
+func NewRouteTargetGraph(states ...RouteTargetState) *RouteTargetGraph {
+	var result RouteTargetGraph
+
+	for _, state := range states {
+		result.Nodes = append(result.Nodes, routeTargetNodes(state)...)
+	}
+
+	for _, from := range result.Nodes {
+		for _, to := range result.Nodes {
+			if from == to || from.isVirtualNetwork() != to.isVirtualNetwork() {
+				continue
+			}
+
+			var rts []string
+			for _, rt := range from.Exports {
+				if _, ok := to.importOrigins[rt]; ok {
+					rts = append(rts, rt)
+				}
+			}
+
+			if len(rts) > 0 {
+				result.Leaks = append(result.Leaks, RouteTargetLeak{From: from, To: to, RouteTargets: rts})
+			}
+		}
+	}
+
+	return &result
+}
+
+func routeTargetNodes(state RouteTargetState) []*RouteTargetNode {
+	newNode := func(szId, vnId, label string) *RouteTargetNode {
+		return &RouteTargetNode{
+			BlueprintID:      state.BlueprintID,
+			SecurityZoneID:   szId,
+			VirtualNetworkID: vnId,
+			Label:            label,
+			importOrigins:    make(map[string]routeTargetOrigin),
+			exportOrigins:    make(map[string]routeTargetOrigin),
+		}
+	}
+
+	addPolicy := func(node *RouteTargetNode, policy *datacenter.RTPolicy) {
+		if policy == nil {
+			return
+		}
+		for _, rt := range policy.ImportRTs {
+			node.addImport(rt, routeTargetOriginPolicy)
+		}
+		for _, rt := range policy.ExportRTs {
+			node.addExport(rt, routeTargetOriginPolicy)
+		}
+	}
+
+	szNodes := make(map[string]*RouteTargetNode)
+	vnNodes := make(map[string]*RouteTargetNode)
+	var result []*RouteTargetNode
+
+	for _, sz := range state.SecurityZones {
+		if sz.ID() == nil {
+			continue
+		}
+
+		node := newNode(*sz.ID(), "", sz.Label)
+		if sz.RouteTarget != nil && *sz.RouteTarget != "" {
+			node.addImport(*sz.RouteTarget, routeTargetOriginImplicit)
+			node.addExport(*sz.RouteTarget, routeTargetOriginImplicit)
+		}
+		addPolicy(node, sz.RTPolicy)
+
+		szNodes[*sz.ID()] = node
+		result = append(result, node)
+	}
+
+	for _, vn := range state.VirtualNetworks {
+		if vn.ID() == nil {
+			continue
+		}
+
+		node := newNode(vn.SecurityZoneID, *vn.ID(), vn.Label)
+		addPolicy(node, vn.RTPolicy)
+
+		vnNodes[*vn.ID()] = node
+		result = append(result, node)
+	}
+
+	for _, group := range state.EVPNInterconnectGroups {
+		for szId, isz := range group.InterconnectSecurityZones {
+			node, ok := szNodes[szId]
+			if !ok || !isz.L3Enabled || isz.RouteTarget == nil {
+				continue
+			}
+			node.addImport(*isz.RouteTarget, routeTargetOriginInterconnect)
+			node.addExport(*isz.RouteTarget, routeTargetOriginInterconnect)
+		}
+
+		if group.RouteTarget == nil {
+			continue
+		}
+		for vnId, ivn := range group.InterconnectVirtualNetworks {
+			node, ok := vnNodes[vnId]
+			if !ok || !ivn.L2Enabled {
+				continue
+			}
+			node.addImport(*group.RouteTarget, routeTargetOriginInterconnect)
+			node.addExport(*group.RouteTarget, routeTargetOriginInterconnect)
+		}
+	}
+
+	kind := func(n *RouteTargetNode) int {
+		if n.isVirtualNetwork() {
+			return 1
+		}
+		return 0
+	}
+
+	slices.SortFunc(result, func(a, b *RouteTargetNode) int {
+		return cmp.Or(
+			cmp.Compare(kind(a), kind(b)),
+			cmp.Compare(a.Label, b.Label),
+			cmp.Compare(a.SecurityZoneID+a.VirtualNetworkID, b.SecurityZoneID+b.VirtualNetworkID),
+		)
+	})
+
+	return result
+}
+
+// RouteTargetFinding describes a route target problem.
+type RouteTargetFinding struct {
+	Issue        enum.RouteTargetIssue
+	RouteTargets []string
+	Node         *RouteTargetNode
+	Peer         *RouteTargetNode // nil for orphan route targets
+}
+
+func (o RouteTargetFinding) String() string {
+	rts := strings.Join(o.RouteTargets, ", ")
+	switch o.Issue {
+	case enum.RouteTargetIssueAsymmetricImport:
+		return fmt.Sprintf("%s imports routes from %s (%s), but not the other way around", o.Node, o.Peer, rts)
+	case enum.RouteTargetIssueOrphanImport:
+		return fmt.Sprintf("%s imports %s, which is not exported by anything", o.Node, rts)
+	case enum.RouteTargetIssueOrphanExport:
+		return fmt.Sprintf("%s exports %s, which is not imported by anything", o.Node, rts)
+	case enum.RouteTargetIssueUnintendedLeak:
+		return fmt.Sprintf("%s imports routes from %s because they share %s, which it does not import deliberately", o.Node, o.Peer, rts)
+	}
+	return fmt.Sprintf("%s: %s %s", o.Node, o.Issue, rts)
+}
+
+// Findings reports:
+//   - asymmetric imports: one node imports another's routes but not vice versa
+//   - orphan route targets: explicitly configured import (export) route targets
+//     which no other node exports (imports)
+//   - unintended leaks: routes imported only because the importer's own
+//     calculated route target collides with one exported elsewhere
+func (o *RouteTargetGraph) Findings() []RouteTargetFinding {
+	var result []RouteTargetFinding
+
+	leaks := make(map[[2]*RouteTargetNode]RouteTargetLeak, len(o.Leaks))
+	for _, leak := range o.Leaks {
+		leaks[[2]*RouteTargetNode{leak.From, leak.To}] = leak
+	}
+
+	for _, leak := range o.Leaks {
+		if _, ok := leaks[[2]*RouteTargetNode{leak.To, leak.From}]; !ok {
+			result = append(result, RouteTargetFinding{
+				Issue:        enum.RouteTargetIssueAsymmetricImport,
+				RouteTargets: leak.RouteTargets,
+				Node:         leak.To,
+				Peer:         leak.From,
+			})
+		}
+
+		var accidental []string
+		for _, rt := range leak.RouteTargets {
+			if leak.To.importOrigins[rt] == routeTargetOriginImplicit {
+				accidental = append(accidental, rt)
+			}
+		}
+		if len(accidental) > 0 {
+			result = append(result, RouteTargetFinding{
+				Issue:        enum.RouteTargetIssueUnintendedLeak,
+				RouteTargets: accidental,
+				Node:         leak.To,
+				Peer:         leak.From,
+			})
+		}
+	}
+
+	for _, node := range o.Nodes {
+		var orphanImports, orphanExports []string
+		for _, rt := range node.Imports {
+			if node.importOrigins[rt] != routeTargetOriginImplicit && !o.exportedByOther(node, rt) {
+				orphanImports = append(orphanImports, rt)
+			}
+		}
+		for _, rt := range node.Exports {
+			if node.exportOrigins[rt] != routeTargetOriginImplicit && !o.importedByOther(node, rt) {
+				orphanExports = append(orphanExports, rt)
+			}
+		}
+
+		if len(orphanImports) > 0 {
+			result = append(result, RouteTargetFinding{Issue: enum.RouteTargetIssueOrphanImport, RouteTargets: orphanImports, Node: node})
+		}
+		if len(orphanExports) > 0 {
+			result = append(result, RouteTargetFinding{Issue: enum.RouteTargetIssueOrphanExport, RouteTargets: orphanExports, Node: node})
+		}
+	}
+
+	return result
+}
+
+func (o *RouteTargetGraph) exportedByOther(node *RouteTargetNode, rt string) bool {
+	for _, other := range o.Nodes {
+		if _, ok := other.exportOrigins[rt]; ok && other != node {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *RouteTargetGraph) importedByOther(node *RouteTargetNode, rt string) bool {
+	for _, other := range o.Nodes {
+		if _, ok := other.importOrigins[rt]; ok && other != node {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteTargetGraph(t *testing.T) {
+	sz := func(id, label, rt string, policy *datacenter.RTPolicy) datacenter.SecurityZone {
+		result := datacenter.SecurityZone{Label: label, RouteTarget: &rt, RTPolicy: policy}
+		require.NoError(t, result.SetID(id))
+		return result
+	}
+
+	vn := func(id, label string) datacenter.VirtualNetwork {
+		result := datacenter.VirtualNetwork{Label: label}
+		require.NoError(t, result.SetID(id))
+		return result
+	}
+
+	icg := func(l3RT, l2RT string, szId, vnId string) EVPNInterconnectGroup {
+		return EVPNInterconnectGroup{
+			RouteTarget:                 &l2RT,
+			InterconnectSecurityZones:   map[string]InterconnectSecurityZone{szId: {L3Enabled: true, RouteTarget: &l3RT}},
+			InterconnectVirtualNetworks: map[string]InterconnectVirtualNetwork{vnId: {L2Enabled: true}},
+		}
+	}
+
+	bp1 := RouteTargetState{
+		BlueprintID: "bp1",
+		SecurityZones: []datacenter.SecurityZone{
+			sz("red", "red", "1:1", &datacenter.RTPolicy{ImportRTs: []string{"1:2"}}),
+			sz("blue", "blue", "1:2", &datacenter.RTPolicy{ImportRTs: []string{"1:1"}, ExportRTs: []string{"9:9"}}),
+			sz("green", "green", "1:3", &datacenter.RTPolicy{ImportRTs: []string{"1:1", "7:7"}}),
+		},
+		VirtualNetworks:        []datacenter.VirtualNetwork{vn("vn1", "vn1")},
+		EVPNInterconnectGroups: []EVPNInterconnectGroup{icg("100:100", "200:200", "blue", "vn1")},
+	}
+
+	bp2 := RouteTargetState{
+		BlueprintID: "bp2",
+		SecurityZones: []datacenter.SecurityZone{
+			sz("red", "red", " target:1:1", nil), // collides with bp1 red
+			sz("blue", "blue", "2:2", nil),
+		},
+		VirtualNetworks:        []datacenter.VirtualNetwork{vn("vn1", "vn1")},
+		EVPNInterconnectGroups: []EVPNInterconnectGroup{icg("100:100", "200:200", "blue", "vn1")},
+	}
+
+	g := NewRouteTargetGraph(bp1, bp2)
+	require.Len(t, g.Nodes, 7)
+
+	name := func(n *RouteTargetNode) string {
+		if n == nil {
+			return ""
+		}
+		return n.BlueprintID + "/" + n.Label
+	}
+
+	var leaks []string
+	for _, leak := range g.Leaks {
+		leaks = append(leaks, fmt.Sprintf("%s -> %s %v", name(leak.From), name(leak.To), leak.RouteTargets))
+	}
+	require.ElementsMatch(t, []string{
+		"bp1/blue -> bp1/red [1:2]",
+		"bp1/red -> bp1/blue [1:1]",
+		"bp1/red -> bp1/green [1:1]",
+		"bp1/red -> bp2/red [1:1]",
+		"bp2/red -> bp1/red [1:1]",
+		"bp2/red -> bp1/blue [1:1]",
+		"bp2/red -> bp1/green [1:1]",
+		"bp1/blue -> bp2/blue [100:100]",
+		"bp2/blue -> bp1/blue [100:100]",
+		"bp1/vn1 -> bp2/vn1 [200:200]",
+		"bp2/vn1 -> bp1/vn1 [200:200]",
+	}, leaks)
+
+	var findings []string
+	for _, f := range g.Findings() {
+		require.NotEmpty(t, f.String())
+		findings = append(findings, fmt.Sprintf("%s %s %s %v", f.Issue, name(f.Node), name(f.Peer), f.RouteTargets))
+	}
+	require.ElementsMatch(t, []string{
+		"asymmetric_import bp1/green bp1/red [1:1]",
+		"asymmetric_import bp1/blue bp2/red [1:1]",
+		"asymmetric_import bp1/green bp2/red [1:1]",
+		"unintended_leak bp2/red bp1/red [1:1]",
+		"unintended_leak bp1/red bp2/red [1:1]",
+		"orphan_import bp1/green  [7:7]",
+		"orphan_export bp1/blue  [9:9]",
+	}, findings)
+}
+
+func TestNormalizeRouteTarget(t *testing.T) {
+	require.Equal(t, "65000:100", normalizeRouteTarget(" Target:65000:100 "))
+	require.Equal(t, "10.0.0.1:5", normalizeRouteTarget("10.0.0.1:5"))
+}
@@ -0,0 +1,344 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"slices"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+// RoutingPolicyVerdict is the outcome of simulating a prefix against a
+// DcRoutingPolicyData.
+type RoutingPolicyVerdict struct {
+	Permitted   bool
+	Filter      *PrefixFilter // the extra import/export rule which decided the outcome, if any
+	FilterIndex int
+	Aggregate   *net.IPNet // export only: aggregate prefix which covers the route
+	Reason      string
+}
+
+// SimulateImport determines whether prefix, learned from an external peer,
+// would be imported. Extra import routes are evaluated in order and the first
+// match decides; otherwise the outcome is determined by ImportPolicy.
+func (o *DcRoutingPolicyData) SimulateImport(prefix net.IPNet) (RoutingPolicyVerdict, error) {
+	p, err := prefixFromIPNet(&prefix)
+	if err != nil {
+		return RoutingPolicyVerdict{}, err
+	}
+
+	result, ok := evaluatePrefixFilters(o.ExtraImportRoutes, p, "extra import route")
+	if ok {
+		return result, nil
+	}
+
+	switch o.ImportPolicy {
+	case DcRoutingPolicyImportPolicyAll:
+		result = RoutingPolicyVerdict{Permitted: true, Reason: "import policy permits all routes"}
+	case DcRoutingPolicyImportPolicyDefaultOnly:
+		result = RoutingPolicyVerdict{Permitted: p.Bits() == 0, Reason: "import policy permits only the default route"}
+	case DcRoutingPolicyImportPolicyExtraOnly:
+		result = RoutingPolicyVerdict{Reason: "import policy permits only extra import routes, none of which match"}
+	default:
+		return RoutingPolicyVerdict{}, fmt.Errorf("cannot simulate unsupported import policy %q", o.ImportPolicy)
+	}
+
+	return result, nil
+}
+
+// SimulateExport determines whether prefix, originated within the fabric by
+// source, would be exported to external peers. Extra export routes are
+// evaluated in order and the first match decides; otherwise the outcome is
+// determined by the ExportPolicy flag corresponding to source.
+func (o *DcRoutingPolicyData) SimulateExport(prefix net.IPNet, source enum.RouteSource) (RoutingPolicyVerdict, error) {
+	p, err := prefixFromIPNet(&prefix)
+	if err != nil {
+		return RoutingPolicyVerdict{}, err
+	}
+
+	result, ok := evaluatePrefixFilters(o.ExtraExportRoutes, p, "extra export route")
+	if !ok {
+		var flag bool
+		var name string
+		switch source {
+		case enum.RouteSourceExternal:
+			flag, name = false, "routes learned from external peers are not re-exported"
+		case enum.RouteSourceL2EdgeSubnet:
+			flag, name = o.ExportPolicy.L2EdgeSubnets, "L2 edge subnets"
+		case enum.RouteSourceL3EdgeServerLink:
+			flag, name = o.ExportPolicy.L3EdgeServerLinks, "L3 edge server links"
+		case enum.RouteSourceLoopback:
+			flag, name = o.ExportPolicy.Loopbacks, "loopbacks"
+		case enum.RouteSourceSpineLeafLink:
+			flag, name = o.ExportPolicy.SpineLeafLinks, "spine-leaf links"
+		case enum.RouteSourceSpineSuperspineLink:
+			flag, name = o.ExportPolicy.SpineSuperspineLinks, "spine-superspine links"
+		case enum.RouteSourceStaticRoute:
+			flag, name = o.ExportPolicy.StaticRoutes, "static routes"
+		default:
+			return RoutingPolicyVerdict{}, fmt.Errorf("cannot simulate export of route with unsupported source %q", source)
+		}
+
+		result = RoutingPolicyVerdict{Permitted: flag}
+		switch {
+		case source == enum.RouteSourceExternal:
+			result.Reason = name
+		case flag:
+			result.Reason = fmt.Sprintf("export policy permits %s", name)
+		default:
+			result.Reason = fmt.Sprintf("export policy does not permit %s", name)
+		}
+	}
+
+	if result.Permitted {
+		for i := range o.AggregatePrefixes {
+			a, err := prefixFromIPNet(&o.AggregatePrefixes[i])
+			if err == nil && a.Bits() <= p.Bits() && a.Contains(p.Addr()) {
+				result.Aggregate = ipNetFromPrefix(a)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// evaluatePrefixFilters returns the verdict of the first filter matching p.
+func evaluatePrefixFilters(filters []PrefixFilter, p netip.Prefix, kind string) (RoutingPolicyVerdict, bool) {
+	for i := range filters {
+		pfr, err := filters[i].prefixRange()
+		if err != nil || !pfr.matches(p) {
+			continue
+		}
+
+		return RoutingPolicyVerdict{
+			Permitted:   filters[i].Action == PrefixFilterActionPermit,
+			Filter:      &filters[i],
+			FilterIndex: i,
+			Reason:      fmt.Sprintf("%s %d (%s %s) matches", kind, i, filters[i].Action, pfr),
+		}, true
+	}
+
+	return RoutingPolicyVerdict{}, false
+}
+
+// prefixRange is the set of prefixes matched by a PrefixFilter: those within
+// prefix having length between ge and le, inclusive.
+type prefixRange struct {
+	prefix netip.Prefix
+	ge, le int
+}
+
+func (o prefixRange) String() string {
+	if o.ge == o.prefix.Bits() && o.le == o.prefix.Bits() {
+		return o.prefix.String()
+	}
+	return fmt.Sprintf("%s ge %d le %d", o.prefix, o.ge, o.le)
+}
+
+func (o prefixRange) matches(p netip.Prefix) bool {
+	return p.Addr().BitLen() == o.prefix.Addr().BitLen() &&
+		o.ge <= p.Bits() && p.Bits() <= o.le &&
+		o.prefix.Contains(p.Addr())
+}
+
+// covers returns true when every prefix matched by other is also matched by o.
+func (o prefixRange) covers(other prefixRange) bool {
+	return o.prefix.Bits() <= other.prefix.Bits() &&
+		o.prefix.Contains(other.prefix.Addr()) &&
+		o.ge <= other.ge && other.le <= o.le
+}
+
+// overlaps returns true when at least one prefix is matched by both o and other.
+func (o prefixRange) overlaps(other prefixRange) bool {
+	if !o.prefix.Overlaps(other.prefix) {
+		return false
+	}
+	lo := max(o.ge, other.ge, o.prefix.Bits(), other.prefix.Bits())
+	hi := min(o.le, other.le)
+	return lo <= hi
+}
+
+// prefixRange validates the filter and returns the range of prefixes it
+// matches. A filter without GeMask and LeMask matches Prefix exactly.
+func (o *PrefixFilter) prefixRange() (prefixRange, error) {
+	p, err := prefixFromIPNet(&o.Prefix)
+	if err != nil {
+		return prefixRange{}, err
+	}
+
+	result := prefixRange{prefix: p, ge: p.Bits(), le: p.Bits()}
+	if o.GeMask != nil {
+		result.ge = *o.GeMask
+		result.le = p.Addr().BitLen()
+	}
+	if o.LeMask != nil {
+		result.le = *o.LeMask
+	}
+
+	switch {
+	case result.ge < p.Bits():
+		return prefixRange{}, fmt.Errorf("ge %d is shorter than prefix %s", result.ge, p)
+	case result.le > p.Addr().BitLen():
+		return prefixRange{}, fmt.Errorf("le %d exceeds the address length of prefix %s", result.le, p)
+	case result.ge > result.le:
+		return prefixRange{}, fmt.Errorf("ge %d is greater than le %d", result.ge, result.le)
+	}
+
+	return result, nil
+}
+
+// RoutingPolicyFinding describes a problem with an extra import or export route.
+type RoutingPolicyFinding struct {
+	Issue       enum.PrefixFilterIssue
+	Export      bool // true for extra export routes, false for extra import routes
+	FilterIndex int
+	ByIndex     int // index of the earlier filter responsible; -1 for invalid filters
+	Reason      string
+}
+
+func (o RoutingPolicyFinding) String() string {
+	kind := "extra import route"
+	if o.Export {
+		kind = "extra export route"
+	}
+	return fmt.Sprintf("%s %d is %s: %s", kind, o.FilterIndex, o.Issue, o.Reason)
+}
+
+// Lint reports extra import and export routes which are invalid, which can
+// never match because an earlier route matches everything they do
+// (unreachable), or which partially overlap an earlier route with a
+// different action, so that their order matters (overlapping).
+func (o *DcRoutingPolicyData) Lint() []RoutingPolicyFinding {
+	return append(lintPrefixFilters(o.ExtraImportRoutes, false), lintPrefixFilters(o.ExtraExportRoutes, true)...)
+}
+
+func lintPrefixFilters(filters []PrefixFilter, export bool) []RoutingPolicyFinding {
+	var result []RoutingPolicyFinding
+
+	ranges := make([]*prefixRange, len(filters))
+	for i := range filters {
+		pfr, err := filters[i].prefixRange()
+		if err != nil {
+			result = append(result, RoutingPolicyFinding{
+				Issue:       enum.PrefixFilterIssueInvalid,
+				Export:      export,
+				FilterIndex: i,
+				ByIndex:     -1,
+				Reason:      err.Error(),
+			})
+			continue
+		}
+		ranges[i] = &pfr
+
+	earlier:
+		for j := range i {
+			switch {
+			case ranges[j] == nil:
+				continue
+			case ranges[j].covers(pfr):
+				result = append(result, RoutingPolicyFinding{
+					Issue:       enum.PrefixFilterIssueUnreachable,
+					Export:      export,
+					FilterIndex: i,
+					ByIndex:     j,
+					Reason:      fmt.Sprintf("%s is covered by earlier route %d (%s %s)", pfr, j, filters[j].Action, ranges[j]),
+				})
+				break earlier
+			case filters[j].Action != filters[i].Action && ranges[j].overlaps(pfr) && !pfr.covers(*ranges[j]):
+				result = append(result, RoutingPolicyFinding{
+					Issue:       enum.PrefixFilterIssueOverlapping,
+					Export:      export,
+					FilterIndex: i,
+					ByIndex:     j,
+					Reason:      fmt.Sprintf("%s partially overlaps earlier route %d (%s %s)", pfr, j, filters[j].Action, ranges[j]),
+				})
+			}
+		}
+	}
+
+	return result
+}
+
+// RoutingPolicyRoute is a prefix to be simulated against a routing policy.
+type RoutingPolicyRoute struct {
+	Prefix      net.IPNet
+	Source      enum.RouteSource
+	Description string
+}
+
+// RoutingPolicyRouteVerdict pairs a RoutingPolicyRoute with its export verdict.
+type RoutingPolicyRouteVerdict struct {
+	Route RoutingPolicyRoute
+	RoutingPolicyVerdict
+}
+
+// SimulateExports determines whether each of routes would be exported.
+func (o *DcRoutingPolicyData) SimulateExports(routes []RoutingPolicyRoute) ([]RoutingPolicyRouteVerdict, error) {
+	result := make([]RoutingPolicyRouteVerdict, len(routes))
+	for i, route := range routes {
+		verdict, err := o.SimulateExport(route.Prefix, route.Source)
+		if err != nil {
+			return nil, fmt.Errorf("simulating export of %s - %w", route.Description, err)
+		}
+		result[i] = RoutingPolicyRouteVerdict{Route: route, RoutingPolicyVerdict: verdict}
+	}
+	return result, nil
+}
+
+// SimulateSecurityZoneExports determines whether the subnets of the virtual
+// networks and the loopback addresses belonging to security zone szId would
+// be exported by the routing policy identified by policyId.
+func (o *TwoStageL3ClosClient) SimulateSecurityZoneExports(ctx context.Context, policyId ObjectId, szId string) ([]RoutingPolicyRouteVerdict, error) {
+	policy, err := o.GetRoutingPolicy(ctx, policyId)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching routing policy %q - %w", policyId, err)
+	}
+
+	vns, err := o.GetVirtualNetworks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching virtual networks from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	loopbacks, err := o.GetSecurityZoneLoopbacks(ctx, szId)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching loopbacks of security zone %q - %w", szId, err)
+	}
+
+	var routes []RoutingPolicyRoute
+	for _, vn := range vns {
+		if vn.SecurityZoneID != szId {
+			continue
+		}
+		for _, subnet := range []*net.IPNet{vn.IPv4Subnet, vn.IPv6Subnet} {
+			if subnet != nil {
+				routes = append(routes, RoutingPolicyRoute{
+					Prefix:      *subnet,
+					Source:      enum.RouteSourceL2EdgeSubnet,
+					Description: virtualNetworkOwner(vn),
+				})
+			}
+		}
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(loopbacks)) {
+		for _, addr := range []*netip.Prefix{loopbacks[id].IPv4Addr, loopbacks[id].IPv6Addr} {
+			if addr != nil {
+				routes = append(routes, RoutingPolicyRoute{
+					Prefix:      *ipNetFromPrefix(*addr),
+					Source:      enum.RouteSourceLoopback,
+					Description: fmt.Sprintf("loopback %q", id),
+				})
+			}
+		}
+	}
+
+	return policy.Data.SimulateExports(routes)
+}
@@ -0,0 +1,163 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"net"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDcRoutingPolicyData_SimulateImport(t *testing.T) {
+	cidr := func(s string) net.IPNet {
+		_, n, err := net.ParseCIDR(s)
+		require.NoError(t, err)
+		return *n
+	}
+
+	policy := DcRoutingPolicyData{
+		ImportPolicy: DcRoutingPolicyImportPolicyDefaultOnly,
+		ExtraImportRoutes: []PrefixFilter{
+			{Action: PrefixFilterActionDeny, Prefix: cidr("10.1.0.0/16"), GeMask: pointer.To(24), LeMask: pointer.To(24)},
+			{Action: PrefixFilterActionPermit, Prefix: cidr("10.0.0.0/8"), LeMask: pointer.To(24)},
+		},
+	}
+
+	type testCase struct {
+		prefix    string
+		permitted bool
+		index     int // -1 when no filter should match
+	}
+
+	testCases := []testCase{
+		{prefix: "10.1.2.0/24", permitted: false, index: 0},
+		{prefix: "10.1.0.0/16", permitted: true, index: 1},
+		{prefix: "10.2.3.0/24", permitted: true, index: 1},
+		{prefix: "10.2.3.0/25", permitted: false, index: -1}, // longer than le
+		{prefix: "0.0.0.0/0", permitted: true, index: -1},
+		{prefix: "192.168.0.0/16", permitted: false, index: -1},
+		{prefix: "::/0", permitted: true, index: -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.prefix, func(t *testing.T) {
+			result, err := policy.SimulateImport(cidr(tc.prefix))
+			require.NoError(t, err)
+			require.Equal(t, tc.permitted, result.Permitted)
+			require.NotEmpty(t, result.Reason)
+			if tc.index < 0 {
+				require.Nil(t, result.Filter)
+			} else {
+				require.NotNil(t, result.Filter)
+				require.Equal(t, tc.index, result.FilterIndex)
+			}
+		})
+	}
+
+	policy.ImportPolicy = DcRoutingPolicyImportPolicyExtraOnly
+	result, err := policy.SimulateImport(cidr("0.0.0.0/0"))
+	require.NoError(t, err)
+	require.False(t, result.Permitted)
+
+	policy.ImportPolicy = DcRoutingPolicyImportPolicyAll
+	result, err = policy.SimulateImport(cidr("192.168.0.0/16"))
+	require.NoError(t, err)
+	require.True(t, result.Permitted)
+}
+
+func TestDcRoutingPolicyData_SimulateExports(t *testing.T) {
+	cidr := func(s string) net.IPNet {
+		_, n, err := net.ParseCIDR(s)
+		require.NoError(t, err)
+		return *n
+	}
+
+	policy := DcRoutingPolicyData{
+		ExportPolicy:      DcRoutingExportPolicy{L2EdgeSubnets: true},
+		AggregatePrefixes: []net.IPNet{cidr("10.0.0.0/16")},
+		ExtraExportRoutes: []PrefixFilter{
+			{Action: PrefixFilterActionDeny, Prefix: cidr("10.0.9.0/24")},
+			{Action: PrefixFilterActionPermit, Prefix: cidr("192.168.0.1/32")},
+		},
+	}
+
+	result, err := policy.SimulateExports([]RoutingPolicyRoute{
+		{Prefix: cidr("10.0.1.0/24"), Source: enum.RouteSourceL2EdgeSubnet, Description: "vn1"},
+		{Prefix: cidr("10.0.9.0/24"), Source: enum.RouteSourceL2EdgeSubnet, Description: "vn9"},
+		{Prefix: cidr("10.1.1.0/24"), Source: enum.RouteSourceL2EdgeSubnet, Description: "vn11"},
+		{Prefix: cidr("192.168.0.1/32"), Source: enum.RouteSourceLoopback, Description: "lo1"},
+		{Prefix: cidr("192.168.0.2/32"), Source: enum.RouteSourceLoopback, Description: "lo2"},
+		{Prefix: cidr("172.16.0.0/12"), Source: enum.RouteSourceExternal, Description: "ext"},
+	})
+	require.NoError(t, err)
+
+	permitted := make(map[string]bool)
+	for _, r := range result {
+		permitted[r.Route.Description] = r.Permitted
+	}
+	require.Equal(t, map[string]bool{
+		"vn1":  true,
+		"vn9":  false,
+		"vn11": true,
+		"lo1":  true,
+		"lo2":  false,
+		"ext":  false,
+	}, permitted)
+
+	require.NotNil(t, result[0].Aggregate)
+	require.Equal(t, "10.0.0.0/16", result[0].Aggregate.String())
+	require.Nil(t, result[2].Aggregate)
+
+	_, err = policy.SimulateExport(cidr("10.0.0.0/24"), enum.RouteSource{Value: "bogus"})
+	require.Error(t, err)
+}
+
+func TestDcRoutingPolicyData_Lint(t *testing.T) {
+	cidr := func(s string) net.IPNet {
+		_, n, err := net.ParseCIDR(s)
+		require.NoError(t, err)
+		return *n
+	}
+
+	policy := DcRoutingPolicyData{
+		ExtraImportRoutes: []PrefixFilter{
+			{Action: PrefixFilterActionPermit, Prefix: cidr("10.0.0.0/8"), LeMask: pointer.To(24)},
+			{Action: PrefixFilterActionDeny, Prefix: cidr("10.1.0.0/16"), LeMask: pointer.To(20)},     // unreachable
+			{Action: PrefixFilterActionDeny, Prefix: cidr("10.2.0.0/16"), GeMask: pointer.To(20)},     // overlapping
+			{Action: PrefixFilterActionPermit, Prefix: cidr("10.3.0.0/16"), GeMask: pointer.To(8)},    // invalid
+			{Action: PrefixFilterActionDeny, Prefix: cidr("0.0.0.0/0"), LeMask: pointer.To(32)},       // general rule after exceptions: fine
+			{Action: PrefixFilterActionPermit, Prefix: cidr("2001:db8::/32"), LeMask: pointer.To(64)}, // different family: fine
+		},
+		ExtraExportRoutes: []PrefixFilter{
+			{Action: PrefixFilterActionPermit, Prefix: cidr("192.168.0.0/16"), GeMask: pointer.To(24), LeMask: pointer.To(16)}, // invalid
+			{Action: PrefixFilterActionPermit, Prefix: cidr("192.168.1.0/24")},
+			{Action: PrefixFilterActionPermit, Prefix: cidr("192.168.1.0/24")}, // unreachable
+		},
+	}
+
+	type finding struct {
+		issue  enum.PrefixFilterIssue
+		export bool
+		index  int
+		by     int
+	}
+
+	var result []finding
+	for _, f := range policy.Lint() {
+		require.NotEmpty(t, f.String())
+		result = append(result, finding{issue: f.Issue, export: f.Export, index: f.FilterIndex, by: f.ByIndex})
+	}
+
+	require.Equal(t, []finding{
+		{enum.PrefixFilterIssueUnreachable, false, 1, 0},
+		{enum.PrefixFilterIssueOverlapping, false, 2, 0},
+		{enum.PrefixFilterIssueInvalid, false, 3, -1},
+		{enum.PrefixFilterIssueInvalid, true, 0, -1},
+		{enum.PrefixFilterIssueUnreachable, true, 2, 1},
+	}, result)
+}
@@ -0,0 +1,368 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+// SubnetPlannerState describes the address space consumers of a blueprint. It
+// is normally collected by TwoStageL3ClosClient.NewSubnetPlanner, but may be
+// assembled by the caller when the blueprint state is already at hand.
+type SubnetPlannerState struct {
+	VirtualNetworks []datacenter.VirtualNetwork
+	SecurityZones   []datacenter.SecurityZone
+	IpPools         []IpPool                 // both IPv4 and IPv6 pools
+	Allocations     ResourceGroupAllocations // pools assigned to the blueprint's resource groups
+}
+
+// SubnetUse is a prefix consumed by a virtual network or by fabric addressing
+// (loopbacks, link addresses, etc...) within a security zone.
+type SubnetUse struct {
+	Subnet         *net.IPNet
+	SecurityZoneID string // empty when the blueprint's default security zone is unknown
+	Owner          string // human readable description of the consumer
+}
+
+// SubnetConflict describes an overlap between a prefix and an existing SubnetUse.
+type SubnetConflict struct {
+	Subnet           *net.IPNet
+	Use              SubnetUse
+	SameSecurityZone bool
+
+	securityZone string // label of Use.SecurityZoneID, if known
+}
+
+// String explains why Subnet is unavailable.
+func (o SubnetConflict) String() string {
+	where := "in the same routing zone"
+	if !o.SameSecurityZone {
+		where = fmt.Sprintf("in routing zone %q", o.securityZone)
+	}
+	return fmt.Sprintf("%s overlaps %s used by %s %s", o.Subnet, o.Use.Subnet, o.Use.Owner, where)
+}
+
+// SubnetRequest describes the prefix sought from SubnetPlanner.NextSubnet.
+type SubnetRequest struct {
+	PoolId         ObjectId // IP pool from which the prefix is carved
+	PrefixLen      int
+	SecurityZoneID string // routing zone of the new virtual network
+
+	// AllowSecurityZoneOverlap permits allocation of prefixes which overlap
+	// subnets used in other routing zones.
+	AllowSecurityZoneOverlap bool
+}
+
+// SubnetPlanner allocates prefixes for new virtual networks and detects
+// overlapping subnets within and across routing zones. It is not safe for
+// concurrent use.
+type SubnetPlanner struct {
+	uses      []subnetUse
+	pools     map[ObjectId][]netip.Prefix
+	szLabels  map[string]string
+	defaultSz string
+}
+
+type subnetUse struct {
+	SubnetUse
+	prefix netip.Prefix
+}
+
+// NewSubnetPlanner returns a planner based on the blueprint's current virtual
+// networks, security zones and IP pool allocations.
+func (o *TwoStageL3ClosClient) NewSubnetPlanner(ctx context.Context) (*SubnetPlanner, error) {
+	var state SubnetPlannerState
+	var err error
+
+	state.VirtualNetworks, err = o.GetVirtualNetworks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching virtual networks from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	state.SecurityZones, err = o.GetSecurityZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching security zones from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	state.Allocations, err = o.GetResourceAllocations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching resource allocations from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	ip4Pools, err := o.client.GetIp4Pools(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching IPv4 pools - %w", err)
+	}
+
+	ip6Pools, err := o.client.GetIp6Pools(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching IPv6 pools - %w", err)
+	}
+
+	state.IpPools = append(ip4Pools, ip6Pools...)
+
+	return NewSubnetPlannerFromState(state)
+}
+
+// NewSubnetPlannerFromState returns a planner based on state.
+func NewSubnetPlannerFromState(state SubnetPlannerState) (*SubnetPlanner, error) {
+	result := SubnetPlanner{
+		pools:    make(map[ObjectId][]netip.Prefix, len(state.IpPools)),
+		szLabels: make(map[string]string, len(state.SecurityZones)),
+	}
+
+	for _, sz := range state.SecurityZones {
+		if sz.ID() == nil {
+			continue
+		}
+		result.szLabels[*sz.ID()] = sz.Label
+		if sz.Type == enum.SecurityZoneTypeL3Fabric {
+			result.defaultSz = *sz.ID()
+		}
+	}
+
+	for _, pool := range state.IpPools {
+		for _, subnet := range pool.Subnets {
+			p, err := prefixFromIPNet(subnet.Network)
+			if err != nil {
+				return nil, fmt.Errorf("pool %q - %w", pool.Id, err)
+			}
+			result.pools[pool.Id] = append(result.pools[pool.Id], p)
+		}
+	}
+
+	for _, vn := range state.VirtualNetworks {
+		szId := vn.SecurityZoneID
+		if szId == "" {
+			szId = result.defaultSz
+		}
+		for _, subnet := range []*net.IPNet{vn.IPv4Subnet, vn.IPv6Subnet} {
+			if subnet == nil {
+				continue
+			}
+			err := result.AddUse(SubnetUse{
+				Subnet:         subnet,
+				SecurityZoneID: szId,
+				Owner:          virtualNetworkOwner(vn),
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, rga := range state.Allocations {
+		if rga.ResourceGroup.Type != ResourceTypeIp4Pool && rga.ResourceGroup.Type != ResourceTypeIp6Pool {
+			continue
+		}
+		if rga.ResourceGroup.Name == ResourceGroupNameVirtualNetworkSviIpv4 || rga.ResourceGroup.Name == ResourceGroupNameVirtualNetworkSviIpv6 {
+			continue // SVI addresses live within virtual network subnets
+		}
+
+		szId := result.defaultSz
+		if rga.ResourceGroup.SecurityZoneId != nil {
+			szId = rga.ResourceGroup.SecurityZoneId.String()
+		}
+
+		for _, poolId := range rga.PoolIds {
+			for _, p := range result.pools[poolId] {
+				result.addUse(SubnetUse{
+					Subnet:         ipNetFromPrefix(p),
+					SecurityZoneID: szId,
+					Owner:          fmt.Sprintf("resource group %q (pool %q)", rga.ResourceGroup.Name, poolId),
+				}, p)
+			}
+		}
+	}
+
+	return &result, nil
+}
+
+func virtualNetworkOwner(vn datacenter.VirtualNetwork) string {
+	if vn.ID() == nil {
+		return fmt.Sprintf("virtual network %q", vn.Label)
+	}
+	return fmt.Sprintf("virtual network %q (%s)", vn.Label, *vn.ID())
+}
+
+// AddUse records an additional consumer of address space, e.g. a virtual
+// network created after the planner was built.
+func (o *SubnetPlanner) AddUse(use SubnetUse) error {
+	p, err := prefixFromIPNet(use.Subnet)
+	if err != nil {
+		return fmt.Errorf("%s - %w", use.Owner, err)
+	}
+	o.addUse(use, p)
+	return nil
+}
+
+func (o *SubnetPlanner) addUse(use SubnetUse, p netip.Prefix) {
+	o.uses = append(o.uses, subnetUse{SubnetUse: use, prefix: p})
+}
+
+// Uses returns the address space consumers known to the planner.
+func (o *SubnetPlanner) Uses() []SubnetUse {
+	result := make([]SubnetUse, len(o.uses))
+	for i, use := range o.uses {
+		result[i] = use.SubnetUse
+	}
+	return result
+}
+
+// Check returns the existing uses which overlap subnet. Overlaps with subnets
+// in securityZoneId are always fatal; overlaps with subnets in other routing
+// zones are reported with SameSecurityZone false so that the caller may decide.
+func (o *SubnetPlanner) Check(subnet *net.IPNet, securityZoneId string) ([]SubnetConflict, error) {
+	p, err := prefixFromIPNet(subnet)
+	if err != nil {
+		return nil, err
+	}
+	return o.conflicts(p, securityZoneId, true), nil
+}
+
+func (o *SubnetPlanner) conflicts(p netip.Prefix, securityZoneId string, crossSz bool) []SubnetConflict {
+	var result []SubnetConflict
+	for _, use := range o.uses {
+		if !use.prefix.Overlaps(p) {
+			continue
+		}
+		sameSz := use.SecurityZoneID == securityZoneId
+		if !sameSz && !crossSz {
+			continue
+		}
+		result = append(result, SubnetConflict{
+			Subnet:           ipNetFromPrefix(p),
+			Use:              use.SubnetUse,
+			SameSecurityZone: sameSz,
+			securityZone:     o.szLabels[use.SecurityZoneID],
+		})
+	}
+	return result
+}
+
+// Overlaps returns every pair of existing uses whose subnets overlap. Each
+// conflict names one member of the pair in Subnet and the other in Use.
+func (o *SubnetPlanner) Overlaps() []SubnetConflict {
+	var result []SubnetConflict
+	for i, a := range o.uses {
+		for _, b := range o.uses[i+1:] {
+			if !a.prefix.Overlaps(b.prefix) {
+				continue
+			}
+			result = append(result, SubnetConflict{
+				Subnet:           a.Subnet,
+				Use:              b.SubnetUse,
+				SameSecurityZone: a.SecurityZoneID == b.SecurityZoneID,
+				securityZone:     o.szLabels[b.SecurityZoneID],
+			})
+		}
+	}
+	return result
+}
+
+// NextSubnet returns the lowest prefix of the requested length within the
+// requested pool which does not overlap any existing use. The prefix is not
+// recorded; use AddUse once the virtual network has been created.
+func (o *SubnetPlanner) NextSubnet(req SubnetRequest) (*net.IPNet, error) {
+	pool, ok := o.pools[req.PoolId]
+	if !ok {
+		return nil, ClientErr{
+			errType: ErrNotfound,
+			err:     fmt.Errorf("IP pool %q not found", req.PoolId),
+		}
+	}
+
+	pool = slices.Clone(pool)
+	slices.SortFunc(pool, func(a, b netip.Prefix) int { return a.Addr().Compare(b.Addr()) })
+
+	var reasons []string
+	for _, poolSubnet := range pool {
+		if req.PrefixLen < poolSubnet.Bits() || req.PrefixLen > poolSubnet.Addr().BitLen() {
+			reasons = append(reasons, fmt.Sprintf("/%d cannot be carved from %s", req.PrefixLen, poolSubnet))
+			continue
+		}
+
+		start := poolSubnet.Addr()
+		for start.IsValid() && poolSubnet.Contains(start) {
+			candidate := netip.PrefixFrom(start, req.PrefixLen).Masked()
+			if candidate.Addr() != start {
+				start = lastAddr(candidate).Next() // align to the requested length
+				continue
+			}
+
+			conflicts := o.conflicts(candidate, req.SecurityZoneID, !req.AllowSecurityZoneOverlap)
+			if len(conflicts) == 0 {
+				return ipNetFromPrefix(candidate), nil
+			}
+
+			// skip past everything which collides with this candidate
+			last := lastAddr(candidate)
+			for _, c := range conflicts {
+				p, _ := prefixFromIPNet(c.Use.Subnet)
+				if l := lastAddr(p); l.Compare(last) > 0 {
+					last = l
+				}
+				if len(reasons) < 10 {
+					reasons = append(reasons, c.String())
+				}
+			}
+			start = last.Next()
+		}
+	}
+
+	return nil, ClientErr{
+		errType: ErrNotfound,
+		err:     fmt.Errorf("no free /%d in IP pool %q: %s", req.PrefixLen, req.PoolId, strings.Join(reasons, "; ")),
+	}
+}
+
+func prefixFromIPNet(in *net.IPNet) (netip.Prefix, error) {
+	if in == nil {
+		return netip.Prefix{}, errors.New("subnet is nil")
+	}
+
+	addr, ok := netip.AddrFromSlice(in.IP)
+	if !ok {
+		return netip.Prefix{}, fmt.Errorf("invalid IP address %q", in.IP)
+	}
+	addr = addr.Unmap()
+
+	ones, bits := in.Mask.Size()
+	if bits == 0 {
+		return netip.Prefix{}, fmt.Errorf("invalid mask for subnet %s", in)
+	}
+	if bits != addr.BitLen() {
+		ones -= bits - addr.BitLen() // IPv4 address with IPv4-in-IPv6 mask
+	}
+
+	return netip.PrefixFrom(addr, ones).Masked(), nil
+}
+
+func ipNetFromPrefix(p netip.Prefix) *net.IPNet {
+	return &net.IPNet{
+		IP:   p.Addr().AsSlice(),
+		Mask: net.CIDRMask(p.Bits(), p.Addr().BitLen()),
+	}
+}
+
+// lastAddr returns the highest address within p.
+func lastAddr(p netip.Prefix) netip.Addr {
+	b := p.Masked().Addr().AsSlice()
+	for i := range b {
+		covered := min(max(p.Bits()-8*i, 0), 8)
+		b[i] |= byte(0xff >> covered)
+	}
+	result, _ := netip.AddrFromSlice(b)
+	return result
+}
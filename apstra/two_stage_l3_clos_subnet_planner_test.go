@@ -0,0 +1,155 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"net"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubnetPlanner(t *testing.T) {
+	cidr := func(s string) *net.IPNet {
+		_, result, err := net.ParseCIDR(s)
+		require.NoError(t, err)
+		return result
+	}
+
+	sz := func(id, label string, szType enum.SecurityZoneType) datacenter.SecurityZone {
+		result := datacenter.SecurityZone{Label: label, Type: szType}
+		require.NoError(t, result.SetID(id))
+		return result
+	}
+
+	vn := func(id, label, szId, subnet string) datacenter.VirtualNetwork {
+		result := datacenter.VirtualNetwork{Label: label, SecurityZoneID: szId, IPv4Subnet: cidr(subnet)}
+		require.NoError(t, result.SetID(id))
+		return result
+	}
+
+	state := SubnetPlannerState{
+		SecurityZones: []datacenter.SecurityZone{
+			sz("default", "default", enum.SecurityZoneTypeL3Fabric),
+			sz("red", "red", enum.SecurityZoneTypeEVPN),
+			sz("blue", "blue", enum.SecurityZoneTypeEVPN),
+		},
+		VirtualNetworks: []datacenter.VirtualNetwork{
+			vn("vn1", "red1", "red", "10.1.0.0/24"),
+			vn("vn2", "red2", "red", "10.1.2.0/23"),
+			vn("vn3", "blue1", "blue", "10.1.1.0/24"),
+			vn("vn4", "blue2", "blue", "10.1.2.0/24"),
+		},
+		IpPools: []IpPool{
+			{Id: "vn-pool", Subnets: []IpSubnet{{Network: cidr("10.1.0.0/16")}}},
+			{Id: "v6-pool", Subnets: []IpSubnet{{Network: cidr("2001:db8::/56")}}},
+			{Id: "loopbacks", Subnets: []IpSubnet{{Network: cidr("10.1.8.0/22")}}},
+			{Id: "svi", Subnets: []IpSubnet{{Network: cidr("10.1.4.0/22")}}},
+		},
+		Allocations: ResourceGroupAllocations{
+			{ResourceGroup: ResourceGroup{Type: ResourceTypeIp4Pool, Name: ResourceGroupNameLeafIp4}, PoolIds: []ObjectId{"loopbacks"}},
+			{ResourceGroup: ResourceGroup{Type: ResourceTypeIp4Pool, Name: ResourceGroupNameVirtualNetworkSviIpv4}, PoolIds: []ObjectId{"svi"}},
+			{ResourceGroup: ResourceGroup{Type: ResourceTypeAsnPool, Name: ResourceGroupNameLeafAsn}, PoolIds: []ObjectId{"asns"}},
+		},
+	}
+
+	p, err := NewSubnetPlannerFromState(state)
+	require.NoError(t, err)
+	require.Len(t, p.Uses(), 5) // 4 VNs and the loopback pool
+
+	t.Run("next_subnet", func(t *testing.T) {
+		type testCase struct {
+			req      SubnetRequest
+			expected string
+		}
+
+		testCases := map[string]testCase{
+			"red": {
+				req:      SubnetRequest{PoolId: "vn-pool", PrefixLen: 24, SecurityZoneID: "red"},
+				expected: "10.1.4.0/24",
+			},
+			"red_allow_overlap": {
+				req:      SubnetRequest{PoolId: "vn-pool", PrefixLen: 24, SecurityZoneID: "red", AllowSecurityZoneOverlap: true},
+				expected: "10.1.1.0/24",
+			},
+			"blue_allow_overlap": {
+				req:      SubnetRequest{PoolId: "vn-pool", PrefixLen: 25, SecurityZoneID: "blue", AllowSecurityZoneOverlap: true},
+				expected: "10.1.0.0/25",
+			},
+			"default_skips_loopbacks": {
+				req:      SubnetRequest{PoolId: "vn-pool", PrefixLen: 21, SecurityZoneID: "default", AllowSecurityZoneOverlap: true},
+				expected: "10.1.0.0/21",
+			},
+			"large": {
+				req:      SubnetRequest{PoolId: "vn-pool", PrefixLen: 21, SecurityZoneID: "red"},
+				expected: "10.1.16.0/21",
+			},
+			"ipv6": {
+				req:      SubnetRequest{PoolId: "v6-pool", PrefixLen: 64, SecurityZoneID: "red"},
+				expected: "2001:db8::/64",
+			},
+		}
+
+		for tName, tCase := range testCases {
+			t.Run(tName, func(t *testing.T) {
+				t.Parallel()
+				result, err := p.NextSubnet(tCase.req)
+				require.NoError(t, err)
+				require.Equal(t, tCase.expected, result.String())
+			})
+		}
+	})
+
+	t.Run("next_subnet_errors", func(t *testing.T) {
+		_, err := p.NextSubnet(SubnetRequest{PoolId: "bogus", PrefixLen: 24})
+		require.Error(t, err)
+
+		_, err = p.NextSubnet(SubnetRequest{PoolId: "vn-pool", PrefixLen: 8})
+		require.ErrorContains(t, err, "cannot be carved")
+
+		_, err = p.NextSubnet(SubnetRequest{PoolId: "loopbacks", PrefixLen: 24, SecurityZoneID: "red"})
+		require.ErrorContains(t, err, `used by resource group "leaf_loopback_ips" (pool "loopbacks") in routing zone "default"`)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		conflicts, err := p.Check(cidr("10.1.2.128/25"), "red")
+		require.NoError(t, err)
+		require.Len(t, conflicts, 2)
+		require.Equal(t, `10.1.2.128/25 overlaps 10.1.2.0/23 used by virtual network "red2" (vn2) in the same routing zone`, conflicts[0].String())
+		require.Equal(t, `10.1.2.128/25 overlaps 10.1.2.0/24 used by virtual network "blue2" (vn4) in routing zone "blue"`, conflicts[1].String())
+		require.True(t, conflicts[0].SameSecurityZone)
+		require.False(t, conflicts[1].SameSecurityZone)
+
+		conflicts, err = p.Check(cidr("192.168.0.0/24"), "red")
+		require.NoError(t, err)
+		require.Empty(t, conflicts)
+	})
+
+	t.Run("overlaps", func(t *testing.T) {
+		overlaps := p.Overlaps()
+		require.Len(t, overlaps, 1) // red2 overlaps blue2
+		for _, o := range overlaps {
+			require.False(t, o.SameSecurityZone)
+			require.Equal(t, "10.1.2.0/23", o.Subnet.String())
+		}
+	})
+
+	t.Run("add_use", func(t *testing.T) {
+		p, err := NewSubnetPlannerFromState(state)
+		require.NoError(t, err)
+
+		next, err := p.NextSubnet(SubnetRequest{PoolId: "vn-pool", PrefixLen: 24, SecurityZoneID: "red"})
+		require.NoError(t, err)
+		require.NoError(t, p.AddUse(SubnetUse{Subnet: next, SecurityZoneID: "red", Owner: "new"}))
+
+		next, err = p.NextSubnet(SubnetRequest{PoolId: "vn-pool", PrefixLen: 24, SecurityZoneID: "red"})
+		require.NoError(t, err)
+		require.Equal(t, "10.1.5.0/24", next.String())
+
+		require.Error(t, p.AddUse(SubnetUse{Owner: "nil subnet"}))
+	})
+}
@@ -0,0 +1,531 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Juniper/apstra-go-sdk/compatibility"
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+// SwitchingZoneMigrationState describes the switching zones and virtual
+// networks of a blueprint.
+type SwitchingZoneMigrationState struct {
+	SwitchingZones  []datacenter.SwitchingZone
+	DefaultZoneID   string // virtual networks with an empty SwitchingZoneID belong here
+	VirtualNetworks []datacenter.VirtualNetwork
+
+	// RedundancyGroups is used to compare VLANs bound to a leaf or access
+	// redundancy group with VLANs bound to its member switches.
+	RedundancyGroups map[ObjectId]RedundancyGroupInfo
+}
+
+// SwitchingZoneLayout assigns virtual networks to a switching zone. Zones
+// which do not exist are created from Template (which may be nil), with the
+// template's label replaced by Label. Virtual networks not mentioned in any
+// layout remain in their current zone.
+type SwitchingZoneLayout struct {
+	Label                string
+	Template             *datacenter.SwitchingZone
+	VirtualNetworkLabels []string
+}
+
+// SwitchingZoneConflict describes virtual networks which would collide when
+// placed in the same switching zone.
+type SwitchingZoneConflict struct {
+	Issue                enum.SwitchingZoneIssue
+	ZoneLabel            string
+	Value                uint32 // the colliding VNI or VLAN
+	SystemID             string // vlan_conflict only
+	VirtualNetworkLabels []string
+}
+
+func (o SwitchingZoneConflict) String() string {
+	var what string
+	switch o.Issue {
+	case enum.SwitchingZoneIssueVniConflict:
+		what = fmt.Sprintf("VNI %d", o.Value)
+	case enum.SwitchingZoneIssueReservedVlanConflict:
+		what = fmt.Sprintf("reserved VLAN %d", o.Value)
+	default:
+		what = fmt.Sprintf("VLAN %d on system %q", o.Value, o.SystemID)
+	}
+	return fmt.Sprintf("switching zone %q: virtual networks %q collide on %s", o.ZoneLabel, o.VirtualNetworkLabels, what)
+}
+
+// SwitchingZoneMigrationStep either creates a switching zone (CreateZone is
+// non-nil) or moves a virtual network into a switching zone.
+type SwitchingZoneMigrationStep struct {
+	ZoneLabel           string
+	CreateZone          *datacenter.SwitchingZone
+	VirtualNetworkID    string
+	VirtualNetworkLabel string
+	FromZoneID          string
+	ToZoneID            string // empty when the zone is created by an earlier step
+}
+
+func (o SwitchingZoneMigrationStep) String() string {
+	if o.CreateZone != nil {
+		return fmt.Sprintf("create switching zone %q", o.ZoneLabel)
+	}
+	return fmt.Sprintf("move virtual network %q (%s) from switching zone %s to %q", o.VirtualNetworkLabel, o.VirtualNetworkID, o.FromZoneID, o.ZoneLabel)
+}
+
+// SwitchingZoneMigrationPlan is an ordered list of steps. Zones are created
+// before any virtual network is moved, and a virtual network leaving a zone
+// is moved before any virtual network which would collide with it enters that
+// zone.
+type SwitchingZoneMigrationPlan struct {
+	Steps     []SwitchingZoneMigrationStep
+	Conflicts []SwitchingZoneConflict // the plan cannot be applied when conflicts exist
+}
+
+func (o SwitchingZoneMigrationPlan) String() string {
+	var sb strings.Builder
+	for i, step := range o.Steps {
+		fmt.Fprintf(&sb, "%d: %s\n", i+1, step)
+	}
+	for _, conflict := range o.Conflicts {
+		fmt.Fprintf(&sb, "conflict: %s\n", conflict)
+	}
+	return sb.String()
+}
+
+// SwitchingZoneMigrationProgress records the progress of
+// ApplySwitchingZoneMigration. It may be persisted (it marshals to JSON) and
+// passed back to ApplySwitchingZoneMigration along with the same plan to
+// resume an interrupted migration.
+type SwitchingZoneMigrationProgress struct {
+	Completed int               `json:"completed"` // number of plan steps completed
+	ZoneIDs   map[string]string `json:"zone_ids"`  // IDs of created switching zones, keyed by label
+}
+
+// NewSwitchingZoneMigrationPlan calculates the steps required to arrange the
+// blueprint's virtual networks according to layout.
+func NewSwitchingZoneMigrationPlan(state SwitchingZoneMigrationState, layout []SwitchingZoneLayout) (*SwitchingZoneMigrationPlan, error) {
+	zoneIdByLabel := make(map[string]string, len(state.SwitchingZones))
+	zoneLabelById := make(map[string]string, len(state.SwitchingZones))
+	for _, zone := range state.SwitchingZones {
+		if zone.ID() == nil || zone.Label == nil {
+			continue
+		}
+		zoneIdByLabel[*zone.Label] = *zone.ID()
+		zoneLabelById[*zone.ID()] = *zone.Label
+	}
+
+	vnByLabel := make(map[string]datacenter.VirtualNetwork, len(state.VirtualNetworks))
+	for _, vn := range state.VirtualNetworks {
+		vnByLabel[vn.Label] = vn
+	}
+
+	zoneOf := func(vn datacenter.VirtualNetwork) string {
+		if vn.SwitchingZoneID == "" {
+			return state.DefaultZoneID
+		}
+		return vn.SwitchingZoneID
+	}
+
+	var result SwitchingZoneMigrationPlan
+	target := make(map[string]string) // VN ID -> zone label, per layout
+	seenZones := make(map[string]bool)
+	var moves []SwitchingZoneMigrationStep
+	var errs []error
+
+	for _, zl := range layout {
+		if zl.Label == "" {
+			errs = append(errs, errors.New("switching zone label is required"))
+			continue
+		}
+		if seenZones[zl.Label] {
+			errs = append(errs, fmt.Errorf("switching zone %q appears more than once", zl.Label))
+			continue
+		}
+		seenZones[zl.Label] = true
+
+		zoneId, exists := zoneIdByLabel[zl.Label]
+		if !exists {
+			var zone datacenter.SwitchingZone
+			if zl.Template != nil {
+				zone = *zl.Template
+			}
+			zone.Label = &zl.Label
+			result.Steps = append(result.Steps, SwitchingZoneMigrationStep{ZoneLabel: zl.Label, CreateZone: &zone})
+		}
+
+		for _, label := range zl.VirtualNetworkLabels {
+			vn, ok := vnByLabel[label]
+			if !ok || vn.ID() == nil {
+				errs = append(errs, ClientErr{
+					errType: ErrNotfound,
+					err:     fmt.Errorf("virtual network %q not found", label),
+				})
+				continue
+			}
+			if _, ok := target[*vn.ID()]; ok {
+				errs = append(errs, fmt.Errorf("virtual network %q is assigned to multiple switching zones", label))
+				continue
+			}
+			target[*vn.ID()] = zl.Label
+
+			if exists && zoneOf(vn) == zoneId {
+				continue // already in place
+			}
+			moves = append(moves, SwitchingZoneMigrationStep{
+				ZoneLabel:           zl.Label,
+				VirtualNetworkID:    *vn.ID(),
+				VirtualNetworkLabel: vn.Label,
+				FromZoneID:          zoneOf(vn),
+				ToZoneID:            zoneId,
+			})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// group VNs by the zone they occupy after the migration
+	finalZones := make(map[string][]datacenter.VirtualNetwork)
+	moved := make(map[string]bool, len(moves))
+	for _, vn := range state.VirtualNetworks {
+		if vn.ID() == nil {
+			continue
+		}
+		label, ok := target[*vn.ID()]
+		if !ok {
+			label = zoneLabelById[zoneOf(vn)]
+		}
+		finalZones[label] = append(finalZones[label], vn)
+	}
+	for _, move := range moves {
+		moved[move.VirtualNetworkLabel] = true
+	}
+
+	for _, zoneLabel := range slices.Sorted(maps.Keys(finalZones)) {
+		result.Conflicts = append(result.Conflicts, switchingZoneConflicts(zoneLabel, finalZones[zoneLabel], moved, state.RedundancyGroups)...)
+	}
+
+	ordered, err := orderSwitchingZoneMoves(moves, vnByLabel, state.RedundancyGroups)
+	if err != nil {
+		return nil, err
+	}
+	result.Steps = append(result.Steps, ordered...)
+
+	return &result, nil
+}
+
+// switchingZoneVlanKey identifies a VLAN on a specific system.
+type switchingZoneVlanKey struct {
+	systemId string
+	vlan     uint16
+}
+
+// switchingZoneVlans returns the VLANs used by vn's bindings. Redundancy
+// groups are expanded to their member switches, so that a VLAN bound to a
+// leaf pair collides with the same VLAN bound to either leaf.
+func switchingZoneVlans(vn datacenter.VirtualNetwork, rgs map[ObjectId]RedundancyGroupInfo) []switchingZoneVlanKey {
+	var result []switchingZoneVlanKey
+	for _, binding := range vn.Bindings {
+		if binding.VLAN == nil {
+			continue
+		}
+		for _, id := range expandRedundancyGroupIds(bindingSystemIds([]datacenter.VNBinding{binding}), rgs) {
+			result = append(result, switchingZoneVlanKey{systemId: id, vlan: *binding.VLAN})
+		}
+	}
+	return result
+}
+
+// switchingZoneConflicts returns collisions between the virtual networks which
+// will share a switching zone. Only collisions involving a moved virtual
+// network are reported: the migration is not responsible for others.
+func switchingZoneConflicts(zoneLabel string, vns []datacenter.VirtualNetwork, moved map[string]bool, rgs map[ObjectId]RedundancyGroupInfo) []SwitchingZoneConflict {
+	vnis := make(map[uint32][]string)
+	reserved := make(map[uint16][]string)
+	vlans := make(map[switchingZoneVlanKey][]string)
+	vlanUsers := make(map[uint16][]string) // VLAN -> VNs using it on any system
+
+	add := func(labels []string, label string) []string {
+		if slices.Contains(labels, label) {
+			return labels
+		}
+		return append(labels, label)
+	}
+
+	for _, vn := range vns {
+		if vn.VNI != nil {
+			vnis[*vn.VNI] = add(vnis[*vn.VNI], vn.Label)
+		}
+		if vn.ReservedVLAN != nil {
+			reserved[*vn.ReservedVLAN] = add(reserved[*vn.ReservedVLAN], vn.Label)
+		}
+		for _, key := range switchingZoneVlans(vn, rgs) {
+			vlans[key] = add(vlans[key], vn.Label)
+			vlanUsers[key.vlan] = add(vlanUsers[key.vlan], vn.Label)
+		}
+	}
+
+	involvesMove := func(labels []string) bool {
+		return len(labels) > 1 && slices.ContainsFunc(labels, func(l string) bool { return moved[l] })
+	}
+
+	var result []SwitchingZoneConflict
+	for vni, labels := range vnis {
+		if involvesMove(labels) {
+			result = append(result, SwitchingZoneConflict{Issue: enum.SwitchingZoneIssueVniConflict, ZoneLabel: zoneLabel, Value: vni, VirtualNetworkLabels: labels})
+		}
+	}
+
+	// a reserved VLAN collides with the same VLAN used by any other VN on any system
+	for vlan, labels := range reserved {
+		for _, label := range vlanUsers[vlan] {
+			labels = add(labels, label)
+		}
+		if involvesMove(labels) {
+			result = append(result, SwitchingZoneConflict{Issue: enum.SwitchingZoneIssueReservedVlanConflict, ZoneLabel: zoneLabel, Value: uint32(vlan), VirtualNetworkLabels: labels})
+		}
+	}
+
+	for key, labels := range vlans {
+		if _, ok := reserved[key.vlan]; ok {
+			continue // reported as a reserved VLAN conflict
+		}
+		if involvesMove(labels) {
+			result = append(result, SwitchingZoneConflict{Issue: enum.SwitchingZoneIssueVlanConflict, ZoneLabel: zoneLabel, Value: uint32(key.vlan), SystemID: key.systemId, VirtualNetworkLabels: labels})
+		}
+	}
+
+	for i := range result {
+		slices.Sort(result[i].VirtualNetworkLabels)
+	}
+	slices.SortFunc(result, func(a, b SwitchingZoneConflict) int {
+		return cmp.Or(
+			strings.Compare(a.Issue.String(), b.Issue.String()),
+			cmp.Compare(a.Value, b.Value),
+			strings.Compare(a.SystemID, b.SystemID),
+		)
+	})
+
+	return result
+}
+
+// switchingZoneCollide returns true when a and b cannot share a switching zone.
+func switchingZoneCollide(a, b datacenter.VirtualNetwork, rgs map[ObjectId]RedundancyGroupInfo) bool {
+	if a.VNI != nil && b.VNI != nil && *a.VNI == *b.VNI {
+		return true
+	}
+
+	aVlans, bVlans := switchingZoneVlans(a, rgs), switchingZoneVlans(b, rgs)
+	usesVlan := func(keys []switchingZoneVlanKey, vlan uint16) bool {
+		return slices.ContainsFunc(keys, func(k switchingZoneVlanKey) bool { return k.vlan == vlan })
+	}
+	if a.ReservedVLAN != nil && (b.ReservedVLAN != nil && *a.ReservedVLAN == *b.ReservedVLAN || usesVlan(bVlans, *a.ReservedVLAN)) {
+		return true
+	}
+	if b.ReservedVLAN != nil && usesVlan(aVlans, *b.ReservedVLAN) {
+		return true
+	}
+
+	return slices.ContainsFunc(aVlans, func(k switchingZoneVlanKey) bool { return slices.Contains(bVlans, k) })
+}
+
+// orderSwitchingZoneMoves sorts moves so that a virtual network leaving a zone
+// is moved before any virtual network which collides with it enters that
+// zone. Otherwise, moves retain their original order.
+func orderSwitchingZoneMoves(moves []SwitchingZoneMigrationStep, vnByLabel map[string]datacenter.VirtualNetwork, rgs map[ObjectId]RedundancyGroupInfo) ([]SwitchingZoneMigrationStep, error) {
+	// after[i] lists the moves which must wait for move i
+	after := make([][]int, len(moves))
+	waitingOn := make([]int, len(moves))
+	for i, leaving := range moves {
+		for j, entering := range moves {
+			if i == j || entering.ToZoneID == "" || leaving.FromZoneID != entering.ToZoneID {
+				continue
+			}
+			if switchingZoneCollide(vnByLabel[leaving.VirtualNetworkLabel], vnByLabel[entering.VirtualNetworkLabel], rgs) {
+				after[i] = append(after[i], j)
+				waitingOn[j]++
+			}
+		}
+	}
+
+	result := make([]SwitchingZoneMigrationStep, 0, len(moves))
+	done := make([]bool, len(moves))
+	for len(result) < len(moves) {
+		progress := false
+		for i := range moves {
+			if done[i] || waitingOn[i] > 0 {
+				continue
+			}
+			done[i] = true
+			progress = true
+			result = append(result, moves[i])
+			for _, j := range after[i] {
+				waitingOn[j]--
+			}
+			break // restart from the beginning to preserve the original order
+		}
+		if !progress {
+			var stuck []string
+			for i := range moves {
+				if !done[i] {
+					stuck = append(stuck, moves[i].VirtualNetworkLabel)
+				}
+			}
+			return nil, fmt.Errorf("virtual networks %q must trade places between switching zones; migrate them in separate passes", stuck)
+		}
+	}
+
+	return result, nil
+}
+
+// GetSwitchingZoneMigrationState collects the switching zones, virtual
+// networks and redundancy groups of the blueprint.
+func (o *TwoStageL3ClosClient) GetSwitchingZoneMigrationState(ctx context.Context) (*SwitchingZoneMigrationState, error) {
+	if !compatibility.DatacenterSwitchingZoneOK.Check(o.client.apiVersion) {
+		return nil, ClientErr{
+			errType: ErrCompatibility,
+			err:     fmt.Errorf("switching zones are not supported with Apstra %s", o.client.apiVersion),
+		}
+	}
+
+	var result SwitchingZoneMigrationState
+	var err error
+
+	result.SwitchingZones, err = o.GetSwitchingZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching switching zones from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	defaultId, err := o.DefaultSwitchingZoneID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if defaultId != nil {
+		result.DefaultZoneID = *defaultId
+	}
+
+	result.VirtualNetworks, err = o.GetVirtualNetworks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching virtual networks from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	result.RedundancyGroups, err = o.GetAllRedundancyGroupInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching redundancy groups from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	return &result, nil
+}
+
+// PlanSwitchingZoneMigration calculates the steps required to arrange the
+// blueprint's virtual networks according to layout.
+func (o *TwoStageL3ClosClient) PlanSwitchingZoneMigration(ctx context.Context, layout ...SwitchingZoneLayout) (*SwitchingZoneMigrationPlan, error) {
+	state, err := o.GetSwitchingZoneMigrationState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSwitchingZoneMigrationPlan(*state, layout)
+}
+
+// ApplySwitchingZoneMigration executes the plan's steps in order, beginning
+// after the steps already recorded in progress (which may be a zero value).
+// When checkpoint is not nil, it is invoked with the updated progress after
+// each step. An error returned by checkpoint stops the migration. Each step
+// tolerates having been completed previously, so a migration interrupted
+// between a step and its checkpoint may safely be resumed.
+func (o *TwoStageL3ClosClient) ApplySwitchingZoneMigration(ctx context.Context, plan *SwitchingZoneMigrationPlan, progress *SwitchingZoneMigrationProgress, checkpoint func(SwitchingZoneMigrationProgress) error) error {
+	if len(plan.Conflicts) > 0 {
+		errs := make([]error, len(plan.Conflicts))
+		for i, conflict := range plan.Conflicts {
+			errs[i] = errors.New(conflict.String())
+		}
+		return fmt.Errorf("switching zone migration plan has conflicts - %w", errors.Join(errs...))
+	}
+
+	if progress == nil {
+		progress = new(SwitchingZoneMigrationProgress)
+	}
+	if progress.ZoneIDs == nil {
+		progress.ZoneIDs = make(map[string]string)
+	}
+
+	for progress.Completed < len(plan.Steps) {
+		step := plan.Steps[progress.Completed]
+
+		var err error
+		if step.CreateZone != nil {
+			err = o.applySwitchingZoneCreate(ctx, step, progress)
+		} else {
+			err = o.applySwitchingZoneMove(ctx, step, progress)
+		}
+		if err != nil {
+			return fmt.Errorf("switching zone migration step %d (%s) failed - %w", progress.Completed+1, step, err)
+		}
+
+		progress.Completed++
+		if checkpoint != nil {
+			if err = checkpoint(*progress); err != nil {
+				return fmt.Errorf("switching zone migration checkpoint after step %d failed - %w", progress.Completed, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (o *TwoStageL3ClosClient) applySwitchingZoneCreate(ctx context.Context, step SwitchingZoneMigrationStep, progress *SwitchingZoneMigrationProgress) error {
+	// the zone may have been created before an interruption
+	existing, err := o.GetSwitchingZoneByLabel(ctx, step.ZoneLabel)
+	if err == nil {
+		progress.ZoneIDs[step.ZoneLabel] = *existing.ID()
+		return nil
+	}
+	var ace ClientErr
+	if !(errors.As(err, &ace) && ace.Type() == ErrNotfound) {
+		return err
+	}
+
+	id, err := o.CreateSwitchingZone(ctx, *step.CreateZone)
+	if err != nil {
+		return err
+	}
+
+	progress.ZoneIDs[step.ZoneLabel] = id
+	return nil
+}
+
+func (o *TwoStageL3ClosClient) applySwitchingZoneMove(ctx context.Context, step SwitchingZoneMigrationStep, progress *SwitchingZoneMigrationProgress) error {
+	toId := step.ToZoneID
+	if toId == "" {
+		toId = progress.ZoneIDs[step.ZoneLabel]
+	}
+	if toId == "" {
+		return fmt.Errorf("ID of switching zone %q is unknown", step.ZoneLabel)
+	}
+
+	vn, err := o.GetVirtualNetwork(ctx, step.VirtualNetworkID)
+	if err != nil {
+		return err
+	}
+
+	switch vn.SwitchingZoneID {
+	case toId:
+		return nil // moved before an interruption
+	case step.FromZoneID, "":
+	default:
+		return fmt.Errorf("virtual network %q is in unexpected switching zone %q", vn.Label, vn.SwitchingZoneID)
+	}
+
+	vn.SwitchingZoneID = toId
+	return o.UpdateVirtualNetwork(ctx, vn)
+}
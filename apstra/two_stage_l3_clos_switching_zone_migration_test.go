@@ -0,0 +1,179 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSwitchingZoneMigrationPlan(t *testing.T) {
+	zone := func(id, label string) datacenter.SwitchingZone {
+		z := datacenter.SwitchingZone{Label: pointer.To(label)}
+		require.NoError(t, z.SetID(id))
+		return z
+	}
+
+	vn := func(label, zoneId string, vni uint32, reserved *uint16, bindings ...datacenter.VNBinding) datacenter.VirtualNetwork {
+		v := datacenter.VirtualNetwork{Label: label, SwitchingZoneID: zoneId, VNI: &vni, ReservedVLAN: reserved, Bindings: bindings}
+		require.NoError(t, v.SetID("vn-"+label))
+		return v
+	}
+
+	binding := func(systemId string, vlan uint16) datacenter.VNBinding {
+		return datacenter.VNBinding{SystemID: systemId, VLAN: &vlan}
+	}
+
+	state := SwitchingZoneMigrationState{
+		SwitchingZones: []datacenter.SwitchingZone{zone("sz-default", "default"), zone("sz-a", "tenant-a")},
+		DefaultZoneID:  "sz-default",
+		VirtualNetworks: []datacenter.VirtualNetwork{
+			vn("web", "", 10001, nil, binding("leaf1", 100)),
+			vn("db", "sz-default", 10002, nil, binding("leaf1", 200)),
+			vn("app", "sz-a", 10003, nil, binding("leaf1", 100)),
+			vn("mgmt", "", 10004, pointer.To(uint16(300)), binding("leaf2", 300)),
+			vn("store", "sz-a", 10005, nil, binding("leaf1", 300)),
+			vn("legacy", "", 10003, nil),
+			vn("edge", "", 10006, nil, binding("rg3", 400)),          // bound to the leaf pair
+			vn("branch", "sz-a", 10007, nil, binding("leaf3a", 400)), // bound to one member of the pair
+		},
+		RedundancyGroups: map[ObjectId]RedundancyGroupInfo{
+			"rg3": {Id: "rg3", SystemIds: [2]ObjectId{"leaf3a", "leaf3b"}},
+		},
+	}
+
+	type testCase struct {
+		layout       []SwitchingZoneLayout
+		expSteps     []string
+		expConflicts []SwitchingZoneConflict
+		expErrs      []string
+		check        func(t *testing.T, plan *SwitchingZoneMigrationPlan)
+	}
+
+	testCases := map[string]testCase{
+		"split_default": {
+			layout: []SwitchingZoneLayout{
+				{Label: "tenant-b", Template: &datacenter.SwitchingZone{Label: pointer.To("ignored"), RouteTarget: pointer.To("1:1")}, VirtualNetworkLabels: []string{"web", "db"}},
+				{Label: "tenant-a", VirtualNetworkLabels: []string{"app"}}, // already in place
+			},
+			expSteps: []string{
+				`create switching zone "tenant-b"`,
+				`move virtual network "web" (vn-web) from switching zone sz-default to "tenant-b"`,
+				`move virtual network "db" (vn-db) from switching zone sz-default to "tenant-b"`,
+			},
+			check: func(t *testing.T, plan *SwitchingZoneMigrationPlan) {
+				require.Equal(t, "tenant-b", *plan.Steps[0].CreateZone.Label)
+				require.Equal(t, "1:1", *plan.Steps[0].CreateZone.RouteTarget)
+				require.Empty(t, plan.Steps[1].ToZoneID)
+			},
+		},
+		"leaving_before_entering": {
+			layout: []SwitchingZoneLayout{
+				{Label: "tenant-a", VirtualNetworkLabels: []string{"web"}},
+				{Label: "tenant-c", VirtualNetworkLabels: []string{"app"}},
+			},
+			expSteps: []string{
+				`create switching zone "tenant-c"`,
+				`move virtual network "app" (vn-app) from switching zone sz-a to "tenant-c"`,
+				`move virtual network "web" (vn-web) from switching zone sz-default to "tenant-a"`,
+			},
+			check: func(t *testing.T, plan *SwitchingZoneMigrationPlan) {
+				require.Equal(t, "sz-a", plan.Steps[2].ToZoneID)
+			},
+		},
+		"leaving_before_entering_redundancy_group": {
+			layout: []SwitchingZoneLayout{
+				{Label: "tenant-a", VirtualNetworkLabels: []string{"edge"}},
+				{Label: "tenant-c", VirtualNetworkLabels: []string{"branch"}},
+			},
+			expSteps: []string{
+				`create switching zone "tenant-c"`,
+				`move virtual network "branch" (vn-branch) from switching zone sz-a to "tenant-c"`,
+				`move virtual network "edge" (vn-edge) from switching zone sz-default to "tenant-a"`,
+			},
+		},
+		"swap": {
+			layout: []SwitchingZoneLayout{
+				{Label: "tenant-a", VirtualNetworkLabels: []string{"web"}},
+				{Label: "default", VirtualNetworkLabels: []string{"app"}},
+			},
+			expErrs: []string{`virtual networks ["web" "app"] must trade places`},
+		},
+		"conflicts": {
+			layout: []SwitchingZoneLayout{
+				{Label: "tenant-a", VirtualNetworkLabels: []string{"web", "mgmt", "legacy"}},
+			},
+			expSteps: []string{
+				`move virtual network "web" (vn-web) from switching zone sz-default to "tenant-a"`,
+				`move virtual network "mgmt" (vn-mgmt) from switching zone sz-default to "tenant-a"`,
+				`move virtual network "legacy" (vn-legacy) from switching zone sz-default to "tenant-a"`,
+			},
+			expConflicts: []SwitchingZoneConflict{
+				{Issue: enum.SwitchingZoneIssueReservedVlanConflict, ZoneLabel: "tenant-a", Value: 300, VirtualNetworkLabels: []string{"mgmt", "store"}},
+				{Issue: enum.SwitchingZoneIssueVlanConflict, ZoneLabel: "tenant-a", Value: 100, SystemID: "leaf1", VirtualNetworkLabels: []string{"app", "web"}},
+				{Issue: enum.SwitchingZoneIssueVniConflict, ZoneLabel: "tenant-a", Value: 10003, VirtualNetworkLabels: []string{"app", "legacy"}},
+			},
+			check: func(t *testing.T, plan *SwitchingZoneMigrationPlan) {
+				require.Contains(t, plan.String(), `conflict: switching zone "tenant-a": virtual networks ["app" "web"] collide on VLAN 100 on system "leaf1"`)
+
+				err := new(TwoStageL3ClosClient).ApplySwitchingZoneMigration(t.Context(), plan, nil, nil)
+				require.ErrorContains(t, err, "plan has conflicts")
+			},
+		},
+		"conflict_redundancy_group": {
+			layout: []SwitchingZoneLayout{
+				{Label: "tenant-a", VirtualNetworkLabels: []string{"edge"}},
+			},
+			expSteps: []string{
+				`move virtual network "edge" (vn-edge) from switching zone sz-default to "tenant-a"`,
+			},
+			expConflicts: []SwitchingZoneConflict{
+				{Issue: enum.SwitchingZoneIssueVlanConflict, ZoneLabel: "tenant-a", Value: 400, SystemID: "leaf3a", VirtualNetworkLabels: []string{"branch", "edge"}},
+			},
+		},
+		"errors": {
+			layout: []SwitchingZoneLayout{
+				{Label: "tenant-a", VirtualNetworkLabels: []string{"web", "nope"}},
+				{Label: "tenant-b", VirtualNetworkLabels: []string{"web"}},
+				{Label: "tenant-a"},
+				{},
+			},
+			expErrs: []string{
+				`virtual network "nope" not found`,
+				`virtual network "web" is assigned to multiple switching zones`,
+				`switching zone "tenant-a" appears more than once`,
+				"switching zone label is required",
+			},
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			plan, err := NewSwitchingZoneMigrationPlan(state, tCase.layout)
+			if len(tCase.expErrs) > 0 {
+				for _, expErr := range tCase.expErrs {
+					require.ErrorContains(t, err, expErr)
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			var steps []string
+			for _, step := range plan.Steps {
+				steps = append(steps, step.String())
+			}
+			require.Equal(t, tCase.expSteps, steps)
+			require.Equal(t, tCase.expConflicts, plan.Conflicts)
+
+			if tCase.check != nil {
+				tCase.check(t, plan)
+			}
+		})
+	}
+}
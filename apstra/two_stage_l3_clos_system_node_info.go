@@ -47,9 +47,9 @@ type rawSystemNodeInfo struct {
 	RackId *ObjectId `json:"rack_id"`
 	// LogicalVtep        interface{}   `json:"logical_vtep"`
 	// SuperspinePlaneId  interface{}   `json:"superspine_plane_id"`
-	LogicalDeviceId ObjectId `json:"logical_device_id"`
-	Tags            []string `json:"tags"`
-	// HypervisorId       interface{} `json:"hypervisor_id"`
+	LogicalDeviceId ObjectId  `json:"logical_device_id"`
+	Tags            []string  `json:"tags"`
+	HypervisorId    *ObjectId `json:"hypervisor_id"`
 	// RedundancyProtocol interface{} `json:"redundancy_protocol"`
 	External         bool `json:"external"`
 	PortChannelIdMin int  `json:"port_channel_id_min"`
@@ -119,6 +119,7 @@ func (o rawSystemNodeInfo) polish() (*SystemNodeInfo, error) {
 		External:          o.External,
 		GroupLabel:        o.GroupLabel,
 		Hostname:          o.Hostname,
+		HypervisorId:      o.HypervisorId,
 		Id:                o.Id,
 		InterfaceMapId:    o.InterfaceMapId,
 		Label:             o.Label,
@@ -144,6 +145,7 @@ type SystemNodeInfo struct {
 	External          bool
 	GroupLabel        *string
 	Hostname          string
+	HypervisorId      *ObjectId
 	Id                ObjectId
 	InterfaceMapId    *ObjectId
 	Label             string
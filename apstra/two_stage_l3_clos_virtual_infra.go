@@ -0,0 +1,478 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+const (
+	apiUrlBlueprintVirtualInfra       = apiUrlBlueprintById + apiUrlPathDelim + "virtual_infra"
+	apiUrlBlueprintVirtualInfraPrefix = apiUrlBlueprintVirtualInfra + apiUrlPathDelim
+	apiUrlBlueprintVirtualInfraById   = apiUrlBlueprintVirtualInfraPrefix + "%s"
+)
+
+// VirtualInfraAttachment is a Virtual Infrastructure Manager attached to a
+// blueprint. Id is the blueprint node ID; SystemId identifies the manager.
+type VirtualInfraAttachment struct {
+	Id               ObjectId              `json:"id"`
+	SystemId         ObjectId              `json:"system_id"`
+	VirtualInfraType enum.VirtualInfraType `json:"virtual_infra_type"`
+}
+
+// VirtualInfraHypervisor is a hypervisor learned from a Virtual Infrastructure
+// Manager. SystemNodeId is the generic system representing the hypervisor in
+// the fabric, if any.
+type VirtualInfraHypervisor struct {
+	Id           ObjectId
+	Label        string
+	Hostname     string
+	SystemNodeId *ObjectId
+}
+
+// VirtualInfraVm is a virtual machine learned from a Virtual Infrastructure
+// Manager.
+type VirtualInfraVm struct {
+	Id           ObjectId
+	Label        string
+	HypervisorId ObjectId
+	PortGroupIds []ObjectId
+}
+
+// VirtualInfraPortGroup is a port group (vnet) learned from a Virtual
+// Infrastructure Manager.
+type VirtualInfraPortGroup struct {
+	Id     ObjectId
+	Label  string
+	VlanId *uint16
+}
+
+// VirtualInfraInventory is everything Apstra has learned from the Virtual
+// Infrastructure Managers attached to a blueprint.
+type VirtualInfraInventory struct {
+	Hypervisors []VirtualInfraHypervisor
+	Vms         []VirtualInfraVm
+	PortGroups  []VirtualInfraPortGroup
+}
+
+// VmPortBinding describes where a VM's port group meets the fabric: the leaf
+// (or access switch) port facing the VM's hypervisor, and the virtual network
+// bound to that switch with the port group's VLAN. Leaf fields are empty when
+// the hypervisor is not cabled to the fabric; VirtualNetwork fields are empty
+// when no virtual network matches.
+type VmPortBinding struct {
+	VmId                ObjectId
+	VmLabel             string
+	HypervisorId        ObjectId
+	HypervisorLabel     string
+	PortGroupId         ObjectId
+	PortGroupLabel      string
+	VlanId              *uint16
+	LeafNodeId          ObjectId
+	LeafLabel           string
+	LeafInterface       string
+	ServerInterface     string
+	VirtualNetworkId    ObjectId
+	VirtualNetworkLabel string
+}
+
+// VmPortBindings is a list of VmPortBinding.
+type VmPortBindings []VmPortBinding
+
+// ForVm returns the bindings of the VM with the specified ID or label.
+func (o VmPortBindings) ForVm(idOrLabel string) VmPortBindings {
+	var result VmPortBindings
+	for _, b := range o {
+		if string(b.VmId) == idOrLabel || b.VmLabel == idOrLabel {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// VmPortBindingState is the blueprint data from which VM port bindings are
+// derived.
+type VmPortBindingState struct {
+	Inventory       VirtualInfraInventory
+	Links           []CablingMapLink
+	VirtualNetworks []datacenter.VirtualNetwork
+
+	// RedundancyGroups is used to match VN bindings on a leaf or access
+	// redundancy group to the group's member switches.
+	RedundancyGroups map[ObjectId]RedundancyGroupInfo
+}
+
+// fabricPort is the switch side of a link facing a hypervisor.
+type fabricPort struct {
+	switchId, switchLabel, switchIf, serverIf string
+}
+
+// NewVmPortBindings correlates each VM port group with the fabric ports facing
+// the VM's hypervisor and the virtual network carrying the port group's VLAN
+// on each of those switches. A virtual network bound to a redundancy group is
+// carried on each of the group's member switches. Results are sorted by VM
+// label, port group label, then switch label and interface.
+func NewVmPortBindings(state VmPortBindingState) VmPortBindings {
+	hypervisors := make(map[ObjectId]VirtualInfraHypervisor, len(state.Inventory.Hypervisors))
+	for _, h := range state.Inventory.Hypervisors {
+		hypervisors[h.Id] = h
+	}
+
+	portGroups := make(map[ObjectId]VirtualInfraPortGroup, len(state.Inventory.PortGroups))
+	for _, pg := range state.Inventory.PortGroups {
+		portGroups[pg.Id] = pg
+	}
+
+	// fabric ports facing each generic system
+	portsBySystem := make(map[string][]fabricPort)
+	for _, link := range state.Links {
+		for i, ep := range link.Endpoints {
+			peer := link.Endpoints[1-i]
+			if ep.System == nil || peer.System == nil {
+				continue
+			}
+			if ep.System.Role != enum.SystemNodeRoleLeaf && ep.System.Role != enum.SystemNodeRoleAccess {
+				continue
+			}
+			port := fabricPort{switchId: ep.System.ID}
+			if ep.System.Label != nil {
+				port.switchLabel = *ep.System.Label
+			}
+			if ep.Interface.Name != nil {
+				port.switchIf = *ep.Interface.Name
+			}
+			if peer.Interface.Name != nil {
+				port.serverIf = *peer.Interface.Name
+			}
+			portsBySystem[peer.System.ID] = append(portsBySystem[peer.System.ID], port)
+		}
+	}
+
+	// redundancy group of each member switch
+	rgBySwitch := make(map[string]string)
+	for rgId, rg := range state.RedundancyGroups {
+		for _, systemId := range rg.SystemIds {
+			rgBySwitch[string(systemId)] = string(rgId)
+		}
+	}
+
+	// virtual network carrying vlan on a switch, bound either to the switch
+	// itself or to its redundancy group
+	vnFor := func(switchId string, vlan *uint16) (ObjectId, string) {
+		if vlan == nil {
+			return "", ""
+		}
+		rgId := rgBySwitch[switchId]
+		boundTo := func(id string) bool { return id == switchId || (rgId != "" && id == rgId) }
+		for _, vn := range state.VirtualNetworks {
+			for _, binding := range vn.Bindings {
+				if binding.VLAN == nil || *binding.VLAN != *vlan {
+					continue
+				}
+				if boundTo(binding.SystemID) || slices.ContainsFunc(binding.AccessSwitchNodeIDs, boundTo) {
+					var id ObjectId
+					if vn.ID() != nil {
+						id = ObjectId(*vn.ID())
+					}
+					return id, vn.Label
+				}
+			}
+		}
+		return "", ""
+	}
+
+	var result VmPortBindings
+	for _, vm := range state.Inventory.Vms {
+		hypervisor := hypervisors[vm.HypervisorId]
+		var ports []fabricPort
+		if hypervisor.SystemNodeId != nil {
+			ports = portsBySystem[string(*hypervisor.SystemNodeId)]
+		}
+		if len(ports) == 0 {
+			ports = []fabricPort{{}}
+		}
+
+		pgIds := vm.PortGroupIds
+		if len(pgIds) == 0 {
+			pgIds = []ObjectId{""}
+		}
+
+		for _, pgId := range pgIds {
+			pg := portGroups[pgId]
+			for _, port := range ports {
+				binding := VmPortBinding{
+					VmId:            vm.Id,
+					VmLabel:         vm.Label,
+					HypervisorId:    vm.HypervisorId,
+					HypervisorLabel: hypervisor.Label,
+					PortGroupId:     pgId,
+					PortGroupLabel:  pg.Label,
+					VlanId:          pg.VlanId,
+					LeafNodeId:      ObjectId(port.switchId),
+					LeafLabel:       port.switchLabel,
+					LeafInterface:   port.switchIf,
+					ServerInterface: port.serverIf,
+				}
+				if port.switchId != "" {
+					binding.VirtualNetworkId, binding.VirtualNetworkLabel = vnFor(port.switchId, pg.VlanId)
+				}
+				result = append(result, binding)
+			}
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b VmPortBinding) int {
+		return cmp.Or(
+			strings.Compare(a.VmLabel, b.VmLabel),
+			strings.Compare(a.PortGroupLabel, b.PortGroupLabel),
+			strings.Compare(a.LeafLabel, b.LeafLabel),
+			strings.Compare(a.LeafInterface, b.LeafInterface),
+		)
+	})
+
+	return result
+}
+
+// AttachVirtualInfraMgr attaches the Virtual Infrastructure Manager identified
+// by systemId to the blueprint, and returns the new attachment node ID.
+func (o *TwoStageL3ClosClient) AttachVirtualInfraMgr(ctx context.Context, systemId ObjectId, infraType enum.VirtualInfraType) (ObjectId, error) {
+	response := &objectIdResponse{}
+	err := o.client.talkToApstra(ctx, &talkToApstraIn{
+		method: http.MethodPost,
+		urlStr: fmt.Sprintf(apiUrlBlueprintVirtualInfra, o.blueprintId),
+		apiInput: &struct {
+			SystemId         ObjectId              `json:"system_id"`
+			VirtualInfraType enum.VirtualInfraType `json:"virtual_infra_type"`
+		}{SystemId: systemId, VirtualInfraType: infraType},
+		apiResponse: response,
+	})
+	if err != nil {
+		return "", convertTtaeToAceWherePossible(err)
+	}
+
+	return response.Id, nil
+}
+
+// DetachVirtualInfraMgr removes the Virtual Infrastructure Manager attachment
+// node id from the blueprint.
+func (o *TwoStageL3ClosClient) DetachVirtualInfraMgr(ctx context.Context, id ObjectId) error {
+	err := o.client.talkToApstra(ctx, &talkToApstraIn{
+		method: http.MethodDelete,
+		urlStr: fmt.Sprintf(apiUrlBlueprintVirtualInfraById, o.blueprintId, id),
+	})
+	if err != nil {
+		return convertTtaeToAceWherePossible(err)
+	}
+
+	return nil
+}
+
+// GetVirtualInfraAttachments returns the Virtual Infrastructure Managers
+// attached to the blueprint.
+func (o *TwoStageL3ClosClient) GetVirtualInfraAttachments(ctx context.Context) ([]VirtualInfraAttachment, error) {
+	query := new(PathQuery).
+		SetBlueprintId(o.blueprintId).
+		SetClient(o.client).
+		Node([]QEEAttribute{NodeTypeVirtualInfra.QEEAttribute(), {Key: "name", Value: QEStringVal("n_virtual_infra")}})
+
+	var queryResult struct {
+		Items []struct {
+			VirtualInfra VirtualInfraAttachment `json:"n_virtual_infra"`
+		} `json:"items"`
+	}
+
+	err := query.Do(ctx, &queryResult)
+	if err != nil {
+		return nil, fmt.Errorf("graph query %q failed - %w", query, err)
+	}
+
+	result := make([]VirtualInfraAttachment, len(queryResult.Items))
+	for i, item := range queryResult.Items {
+		result[i] = item.VirtualInfra
+	}
+
+	return result, nil
+}
+
+// GetVirtualInfraInventory returns the hypervisors, VMs and port groups
+// learned from the Virtual Infrastructure Managers attached to the blueprint.
+func (o *TwoStageL3ClosClient) GetVirtualInfraInventory(ctx context.Context) (*VirtualInfraInventory, error) {
+	var result VirtualInfraInventory
+
+	// hypervisors
+	nodes, err := o.GetAllSystemNodeInfos(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching system nodes - %w", err)
+	}
+
+	hypervisorQuery := new(PathQuery).
+		SetBlueprintId(o.blueprintId).
+		SetClient(o.client).
+		Node([]QEEAttribute{NodeTypeHypervisor.QEEAttribute(), {Key: "name", Value: QEStringVal("n_hypervisor")}})
+
+	var hypervisorResult struct {
+		Items []struct {
+			Hypervisor struct {
+				Id       ObjectId `json:"id"`
+				Label    string   `json:"label"`
+				Hostname string   `json:"hostname"`
+			} `json:"n_hypervisor"`
+		} `json:"items"`
+	}
+
+	err = hypervisorQuery.Do(ctx, &hypervisorResult)
+	if err != nil {
+		return nil, fmt.Errorf("graph query %q failed - %w", hypervisorQuery, err)
+	}
+
+	for _, item := range hypervisorResult.Items {
+		h := VirtualInfraHypervisor{
+			Id:       item.Hypervisor.Id,
+			Label:    item.Hypervisor.Label,
+			Hostname: item.Hypervisor.Hostname,
+		}
+		for id, node := range nodes {
+			if node.HypervisorId != nil && *node.HypervisorId == h.Id {
+				h.SystemNodeId = &id
+				break
+			}
+		}
+		result.Hypervisors = append(result.Hypervisors, h)
+	}
+
+	// VMs
+	vmQuery := new(PathQuery).
+		SetBlueprintId(o.blueprintId).
+		SetClient(o.client).
+		Node([]QEEAttribute{NodeTypeHypervisor.QEEAttribute(), {Key: "name", Value: QEStringVal("n_hypervisor")}}).
+		Out([]QEEAttribute{RelationshipTypeHostedVms.QEEAttribute()}).
+		Node([]QEEAttribute{NodeTypeVm.QEEAttribute(), {Key: "name", Value: QEStringVal("n_vm")}})
+
+	var vmResult struct {
+		Items []struct {
+			Hypervisor struct {
+				Id ObjectId `json:"id"`
+			} `json:"n_hypervisor"`
+			Vm struct {
+				Id    ObjectId `json:"id"`
+				Label string   `json:"label"`
+			} `json:"n_vm"`
+		} `json:"items"`
+	}
+
+	err = vmQuery.Do(ctx, &vmResult)
+	if err != nil {
+		return nil, fmt.Errorf("graph query %q failed - %w", vmQuery, err)
+	}
+
+	vmIndex := make(map[ObjectId]int, len(vmResult.Items))
+	for _, item := range vmResult.Items {
+		vmIndex[item.Vm.Id] = len(result.Vms)
+		result.Vms = append(result.Vms, VirtualInfraVm{
+			Id:           item.Vm.Id,
+			Label:        item.Vm.Label,
+			HypervisorId: item.Hypervisor.Id,
+		})
+	}
+
+	// port groups, including those without member VMs
+	pgQuery := new(PathQuery).
+		SetBlueprintId(o.blueprintId).
+		SetClient(o.client).
+		Node([]QEEAttribute{NodeTypeVnet.QEEAttribute(), {Key: "name", Value: QEStringVal("n_vnet")}})
+
+	var pgResult struct {
+		Items []struct {
+			Vnet struct {
+				Id     ObjectId `json:"id"`
+				Label  string   `json:"label"`
+				VlanId *uint16  `json:"vlan_id"`
+			} `json:"n_vnet"`
+		} `json:"items"`
+	}
+
+	err = pgQuery.Do(ctx, &pgResult)
+	if err != nil {
+		return nil, fmt.Errorf("graph query %q failed - %w", pgQuery, err)
+	}
+
+	for _, item := range pgResult.Items {
+		result.PortGroups = append(result.PortGroups, VirtualInfraPortGroup{
+			Id:     item.Vnet.Id,
+			Label:  item.Vnet.Label,
+			VlanId: item.Vnet.VlanId,
+		})
+	}
+
+	// port group membership of each VM
+	memberQuery := new(PathQuery).
+		SetBlueprintId(o.blueprintId).
+		SetClient(o.client).
+		Node([]QEEAttribute{NodeTypeVnet.QEEAttribute(), {Key: "name", Value: QEStringVal("n_vnet")}}).
+		Out([]QEEAttribute{RelationshipTypeMemberVms.QEEAttribute()}).
+		Node([]QEEAttribute{NodeTypeVm.QEEAttribute(), {Key: "name", Value: QEStringVal("n_vm")}})
+
+	var memberResult struct {
+		Items []struct {
+			Vnet struct {
+				Id ObjectId `json:"id"`
+			} `json:"n_vnet"`
+			Vm struct {
+				Id ObjectId `json:"id"`
+			} `json:"n_vm"`
+		} `json:"items"`
+	}
+
+	err = memberQuery.Do(ctx, &memberResult)
+	if err != nil {
+		return nil, fmt.Errorf("graph query %q failed - %w", memberQuery, err)
+	}
+
+	for _, item := range memberResult.Items {
+		if i, ok := vmIndex[item.Vm.Id]; ok {
+			result.Vms[i].PortGroupIds = append(result.Vms[i].PortGroupIds, item.Vnet.Id)
+		}
+	}
+
+	return &result, nil
+}
+
+// GetVmPortBindings answers "which leaf port and VLAN does this VM land on?"
+// for every VM learned from the attached Virtual Infrastructure Managers. See
+// NewVmPortBindings.
+func (o *TwoStageL3ClosClient) GetVmPortBindings(ctx context.Context) (VmPortBindings, error) {
+	var state VmPortBindingState
+
+	inventory, err := o.GetVirtualInfraInventory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	state.Inventory = *inventory
+
+	state.Links, err = o.GetCablingMapLinks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching cabling map - %w", err)
+	}
+
+	state.VirtualNetworks, err = o.GetVirtualNetworks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching virtual networks - %w", err)
+	}
+
+	state.RedundancyGroups, err = o.GetAllRedundancyGroupInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching redundancy groups - %w", err)
+	}
+
+	return NewVmPortBindings(state), nil
+}
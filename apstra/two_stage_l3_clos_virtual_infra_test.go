@@ -0,0 +1,101 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVmPortBindings(t *testing.T) {
+	link := func(switchId, switchLabel, switchIf, serverId, serverIf string) CablingMapLink {
+		return CablingMapLink{Endpoints: [2]CablingMapLinkEndpoint{
+			{
+				System:    &CablingMapLinkEndpointSystem{ID: serverId, Role: enum.SystemNodeRoleGeneric},
+				Interface: CablingMapLinkEndpointInterface{Name: pointer.To(serverIf)},
+			},
+			{
+				System:    &CablingMapLinkEndpointSystem{ID: switchId, Role: enum.SystemNodeRoleLeaf, Label: pointer.To(switchLabel)},
+				Interface: CablingMapLinkEndpointInterface{Name: pointer.To(switchIf)},
+			},
+		}}
+	}
+
+	vn := func(id, label string, bindings ...datacenter.VNBinding) datacenter.VirtualNetwork {
+		result := datacenter.VirtualNetwork{Label: label, Bindings: bindings}
+		require.NoError(t, result.SetID(id))
+		return result
+	}
+
+	state := VmPortBindingState{
+		Inventory: VirtualInfraInventory{
+			Hypervisors: []VirtualInfraHypervisor{
+				{Id: "hv1", Label: "esxi1", SystemNodeId: pointer.To(ObjectId("server1"))},
+				{Id: "hv2", Label: "esxi2"}, // not cabled to the fabric
+				{Id: "hv3", Label: "esxi3", SystemNodeId: pointer.To(ObjectId("server3"))},
+			},
+			Vms: []VirtualInfraVm{
+				{Id: "vm1", Label: "web", HypervisorId: "hv1", PortGroupIds: []ObjectId{"pg10", "pg30"}},
+				{Id: "vm2", Label: "db", HypervisorId: "hv2", PortGroupIds: []ObjectId{"pg10"}},
+				{Id: "vm3", Label: "mq", HypervisorId: "hv3", PortGroupIds: []ObjectId{"pg10"}},
+			},
+			PortGroups: []VirtualInfraPortGroup{
+				{Id: "pg10", Label: "pg-vlan10", VlanId: pointer.To(uint16(10))},
+				{Id: "pg30", Label: "pg-vlan30", VlanId: pointer.To(uint16(30))},
+			},
+		},
+		Links: []CablingMapLink{
+			link("leaf1", "leaf_1", "xe-0/0/1", "server1", "vmnic0"),
+			link("leaf2", "leaf_2", "xe-0/0/1", "server1", "vmnic1"),
+			link("leaf1", "leaf_1", "xe-0/0/2", "server9", "eth0"),
+			link("leaf3a", "leaf_3a", "xe-0/0/1", "server3", "vmnic0"),
+			link("leaf3b", "leaf_3b", "xe-0/0/1", "server3", "vmnic1"),
+		},
+		VirtualNetworks: []datacenter.VirtualNetwork{
+			vn("vn10", "blue",
+				datacenter.VNBinding{SystemID: "leaf1", VLAN: pointer.To(uint16(10))},
+				datacenter.VNBinding{SystemID: "leaf2", VLAN: pointer.To(uint16(10))},
+				datacenter.VNBinding{SystemID: "rg3", VLAN: pointer.To(uint16(10))}, // bound via the redundancy group
+			),
+			vn("vn30", "red",
+				datacenter.VNBinding{SystemID: "leaf1", VLAN: pointer.To(uint16(30))},
+			),
+		},
+		RedundancyGroups: map[ObjectId]RedundancyGroupInfo{
+			"rg3": {Id: "rg3", SystemIds: [2]ObjectId{"leaf3a", "leaf3b"}},
+		},
+	}
+
+	result := NewVmPortBindings(state)
+
+	type summary struct {
+		vm, pg, leaf, leafIf, serverIf, vn string
+	}
+	summaries := make([]summary, len(result))
+	for i, b := range result {
+		summaries[i] = summary{b.VmLabel, b.PortGroupLabel, b.LeafLabel, b.LeafInterface, b.ServerInterface, b.VirtualNetworkLabel}
+	}
+
+	require.Equal(t, []summary{
+		{"db", "pg-vlan10", "", "", "", ""},
+		{"mq", "pg-vlan10", "leaf_3a", "xe-0/0/1", "vmnic0", "blue"},
+		{"mq", "pg-vlan10", "leaf_3b", "xe-0/0/1", "vmnic1", "blue"},
+		{"web", "pg-vlan10", "leaf_1", "xe-0/0/1", "vmnic0", "blue"},
+		{"web", "pg-vlan10", "leaf_2", "xe-0/0/1", "vmnic1", "blue"},
+		{"web", "pg-vlan30", "leaf_1", "xe-0/0/1", "vmnic0", "red"},
+		{"web", "pg-vlan30", "leaf_2", "xe-0/0/1", "vmnic1", ""}, // vlan 30 not bound on leaf2
+	}, summaries)
+
+	require.Equal(t, ObjectId("vn10"), result[3].VirtualNetworkId)
+	require.Equal(t, uint16(30), *result[5].VlanId)
+
+	require.Len(t, result.ForVm("web"), 4)
+	require.Len(t, result.ForVm("vm2"), 1)
+	require.Empty(t, result.ForVm("bogus"))
+}
@@ -0,0 +1,523 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+// VNBindingSystem is a leaf or access switch which may be bound to a virtual
+// network.
+type VNBindingSystem struct {
+	Id      ObjectId
+	Label   string
+	Role    enum.SystemNodeRole
+	Tags    []string
+	LeafIds []ObjectId // access switches only: the leaf switches to which the access switch is connected
+}
+
+// VNBindingState is the blueprint information needed to translate leaf and
+// access switch labels and tags into VNBinding targets.
+type VNBindingState struct {
+	Systems          []VNBindingSystem
+	RedundancyGroups map[ObjectId]RedundancyGroupInfo
+}
+
+// VNBindingSelection identifies leaf and access switches by label or by tag.
+// A switch is selected when its label appears in SystemLabels or when it has
+// any of the tags in Tags.
+type VNBindingSelection struct {
+	SystemLabels []string
+	Tags         []string
+}
+
+// VNBindingRequest describes switches to be bound to and unbound from a
+// virtual network. Selecting a member of an MLAG or ESI pair selects the whole
+// pair. Selecting an access switch binds it beneath the leaf (or leaf pair) to
+// which it is connected. Unbinding a leaf removes its binding entirely, along
+// with any access switches beneath it; unbinding an access switch removes only
+// the access switch.
+//
+// The VLAN for new bindings is chosen from, in order of preference: VLAN, the
+// virtual network's reserved VLAN, the VLAN used by all of the virtual
+// network's existing bindings, and (when AutoVLAN is set) the lowest VLAN
+// free on all of the bindings' switches. When none of these applies, Apstra
+// chooses. A non-nil VLAN is also applied to existing bindings.
+type VNBindingRequest struct {
+	VnId     ObjectId
+	Bind     VNBindingSelection
+	Unbind   VNBindingSelection
+	VLAN     *uint16
+	AutoVLAN bool
+}
+
+// VNBindingPlan is the set of changes needed to fulfil a VNBindingRequest.
+type VNBindingPlan struct {
+	VnId   ObjectId
+	Add    map[ObjectId]*datacenter.VNBinding     // new and modified bindings, keyed by leaf or leaf redundancy group ID
+	Remove []ObjectId                             // leaf or leaf redundancy group IDs
+	SviIps map[ObjectId]*datacenter.SVIAddressing // existing SVI addressing retained for modified bindings
+
+	vlanReservation *VlanVniReservation // automatically selected VLAN, held until the plan is applied or released
+}
+
+// Release returns any automatically selected VLAN held by the plan to the
+// planner which selected it. Plans which will not be applied should be
+// released so that their VLAN becomes available to other plans.
+func (o *VNBindingPlan) Release() {
+	if o.vlanReservation != nil {
+		o.vlanReservation.Release()
+		o.vlanReservation = nil
+	}
+}
+
+// commit marks any automatically selected VLAN held by the plan as in use.
+func (o *VNBindingPlan) commit() {
+	if o.vlanReservation != nil {
+		o.vlanReservation.Commit()
+		o.vlanReservation = nil
+	}
+}
+
+// Empty returns true when the plan makes no changes.
+func (o *VNBindingPlan) Empty() bool {
+	return len(o.Add) == 0 && len(o.Remove) == 0
+}
+
+func (o *VNBindingPlan) request() VirtualNetworkBindingsRequest {
+	result := VirtualNetworkBindingsRequest{
+		VnId:       o.VnId,
+		VnBindings: make(map[ObjectId]*datacenter.VNBinding, len(o.Add)+len(o.Remove)),
+		SviIps:     o.SviIps,
+	}
+	for k, v := range o.Add {
+		result.VnBindings[k] = v
+	}
+	for _, k := range o.Remove {
+		result.VnBindings[k] = nil
+	}
+	return result
+}
+
+// vnBindingTarget collects the selected switches beneath a single binding.
+type vnBindingTarget struct {
+	leaf   bool       // the leaf (or leaf pair) itself was selected
+	access []ObjectId // access switch or access redundancy group IDs
+}
+
+// redundancyGroupIds maps system IDs to the ID of their redundancy group.
+func (o *VNBindingState) redundancyGroupIds() map[ObjectId]ObjectId {
+	result := make(map[ObjectId]ObjectId)
+	for id, rg := range o.RedundancyGroups {
+		for _, systemId := range rg.SystemIds {
+			result[systemId] = id
+		}
+	}
+	return result
+}
+
+// targets resolves sel into binding targets keyed by leaf or leaf redundancy
+// group ID.
+func (o *VNBindingState) targets(sel VNBindingSelection) (map[ObjectId]*vnBindingTarget, error) {
+	rgIds := o.redundancyGroupIds()
+	bindingId := func(id ObjectId) ObjectId {
+		if rgId, ok := rgIds[id]; ok {
+			return rgId
+		}
+		return id
+	}
+
+	labels := make(map[string]bool, len(sel.SystemLabels))
+	for _, label := range sel.SystemLabels {
+		labels[label] = false
+	}
+
+	result := make(map[ObjectId]*vnBindingTarget)
+	target := func(id ObjectId) *vnBindingTarget {
+		if result[id] == nil {
+			result[id] = new(vnBindingTarget)
+		}
+		return result[id]
+	}
+
+	for _, system := range o.Systems {
+		_, byLabel := labels[system.Label]
+		byTag := slices.ContainsFunc(sel.Tags, func(tag string) bool { return slices.Contains(system.Tags, tag) })
+		if !byLabel && !byTag {
+			continue
+		}
+		if byLabel {
+			labels[system.Label] = true
+		}
+
+		switch system.Role {
+		case enum.SystemNodeRoleLeaf:
+			target(bindingId(system.Id)).leaf = true
+		case enum.SystemNodeRoleAccess:
+			var leafBindingIds []ObjectId
+			for _, leafId := range system.LeafIds {
+				if id := bindingId(leafId); !slices.Contains(leafBindingIds, id) {
+					leafBindingIds = append(leafBindingIds, id)
+				}
+			}
+			if len(leafBindingIds) != 1 {
+				return nil, fmt.Errorf("access switch %q must be connected to exactly one leaf or leaf pair, found %d", system.Label, len(leafBindingIds))
+			}
+
+			t := target(leafBindingIds[0])
+			if id := bindingId(system.Id); !slices.Contains(t.access, id) {
+				t.access = append(t.access, id)
+			}
+		default:
+			if byLabel {
+				return nil, fmt.Errorf("system %q has role %q; only leaf and access switches can be bound to virtual networks", system.Label, system.Role)
+			}
+		}
+	}
+
+	for label, found := range labels {
+		if !found {
+			return nil, ClientErr{
+				errType: ErrNotfound,
+				err:     fmt.Errorf("leaf or access switch with label %q not found", label),
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Plan computes the changes to vn's bindings required by req. planner is
+// consulted only when req.AutoVLAN is set and no other VLAN source applies.
+// A VLAN selected by planner is reserved by the plan so that subsequent plans
+// made with the same planner do not choose it. The reservation is committed
+// when ApplyVNBindingPlans applies the plan, and is returned to the planner
+// by Release.
+func (o *VNBindingState) Plan(vn datacenter.VirtualNetwork, req VNBindingRequest, planner *VlanVniPlanner) (*VNBindingPlan, error) {
+	bind, err := o.targets(req.Bind)
+	if err != nil {
+		return nil, err
+	}
+
+	unbind, err := o.targets(req.Unbind)
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[ObjectId]datacenter.VNBinding, len(vn.Bindings))
+	for _, binding := range vn.Bindings {
+		current[ObjectId(binding.SystemID)] = binding
+	}
+
+	desired := make(map[ObjectId]*datacenter.VNBinding, len(current))
+	for id, binding := range current {
+		desired[id] = &datacenter.VNBinding{
+			SystemID:            binding.SystemID,
+			AccessSwitchNodeIDs: slices.Clone(binding.AccessSwitchNodeIDs),
+			VLAN:                binding.VLAN,
+		}
+	}
+
+	var added []*datacenter.VNBinding
+	for id, t := range bind {
+		binding, ok := desired[id]
+		if !ok {
+			binding = &datacenter.VNBinding{SystemID: id.String()}
+			desired[id] = binding
+			added = append(added, binding)
+		}
+		for _, accessId := range t.access {
+			if !slices.Contains(binding.AccessSwitchNodeIDs, accessId.String()) {
+				binding.AccessSwitchNodeIDs = append(binding.AccessSwitchNodeIDs, accessId.String())
+			}
+		}
+	}
+
+	for id, t := range unbind {
+		binding, ok := desired[id]
+		switch {
+		case !ok:
+			continue
+		case t.leaf:
+			delete(desired, id)
+		default:
+			binding.AccessSwitchNodeIDs = slices.DeleteFunc(binding.AccessSwitchNodeIDs, func(s string) bool {
+				return slices.Contains(t.access, ObjectId(s))
+			})
+		}
+	}
+
+	vlan, reservation, err := o.chooseVlan(vn, req, added, planner)
+	if err != nil {
+		return nil, err
+	}
+
+	for id, binding := range desired {
+		if _, ok := current[id]; !ok || req.VLAN != nil {
+			binding.VLAN = vlan
+		}
+		slices.Sort(binding.AccessSwitchNodeIDs)
+	}
+
+	result := VNBindingPlan{
+		VnId:            req.VnId,
+		Add:             make(map[ObjectId]*datacenter.VNBinding),
+		SviIps:          make(map[ObjectId]*datacenter.SVIAddressing),
+		vlanReservation: reservation,
+	}
+
+	for id, binding := range desired {
+		existing, ok := current[id]
+		if ok && vnBindingsEqual(existing, *binding) {
+			continue
+		}
+
+		result.Add[id] = binding
+		if !ok {
+			continue
+		}
+
+		// UpdateVirtualNetworkLeafBindings discards SVI addressing of modified bindings unless it is resupplied
+		for _, svi := range vn.SVIIPs {
+			if ObjectId(svi.SystemID) == id {
+				result.SviIps[id] = &svi
+			}
+		}
+	}
+
+	for id := range current {
+		if _, ok := desired[id]; !ok {
+			result.Remove = append(result.Remove, id)
+		}
+	}
+	slices.Sort(result.Remove)
+
+	return &result, nil
+}
+
+func (o *VNBindingState) chooseVlan(vn datacenter.VirtualNetwork, req VNBindingRequest, added []*datacenter.VNBinding, planner *VlanVniPlanner) (*uint16, *VlanVniReservation, error) {
+	if req.VLAN != nil {
+		return req.VLAN, nil, nil
+	}
+
+	if vn.ReservedVLAN != nil {
+		return vn.ReservedVLAN, nil, nil
+	}
+
+	var common *uint16
+	for i, binding := range vn.Bindings {
+		if binding.VLAN == nil || (i > 0 && (common == nil || *common != *binding.VLAN)) {
+			common = nil
+			break
+		}
+		common = binding.VLAN
+	}
+	if common != nil {
+		return common, nil, nil
+	}
+
+	if !req.AutoVLAN || len(added) == 0 {
+		return nil, nil, nil
+	}
+
+	if planner == nil {
+		return nil, nil, fmt.Errorf("automatic VLAN selection for virtual network %q requires a VLAN planner", req.VnId)
+	}
+
+	bindings := make([]datacenter.VNBinding, len(added))
+	for i, binding := range added {
+		bindings[i] = *binding
+	}
+
+	reservation, err := planner.ReserveVLAN(0, bindingSystemIds(bindings)...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed selecting VLAN for virtual network %q - %w", req.VnId, err)
+	}
+
+	return reservation.VLAN, reservation, nil
+}
+
+func vnBindingsEqual(a, b datacenter.VNBinding) bool {
+	switch {
+	case a.SystemID != b.SystemID:
+		return false
+	case (a.VLAN == nil) != (b.VLAN == nil):
+		return false
+	case a.VLAN != nil && *a.VLAN != *b.VLAN:
+		return false
+	}
+
+	aIds := slices.Sorted(slices.Values(a.AccessSwitchNodeIDs))
+	bIds := slices.Sorted(slices.Values(b.AccessSwitchNodeIDs))
+	return slices.Equal(aIds, bIds)
+}
+
+// GetVNBindingState collects the leaf and access switches, their tags, access
+// switch uplinks and redundancy groups needed to resolve VN binding targets.
+func (o *TwoStageL3ClosClient) GetVNBindingState(ctx context.Context) (*VNBindingState, error) {
+	systemQuery := new(PathQuery).
+		SetBlueprintId(o.blueprintId).
+		SetBlueprintType(BlueprintTypeStaging).
+		SetClient(o.client).
+		Node([]QEEAttribute{
+			NodeTypeSystem.QEEAttribute(),
+			{Key: "role", Value: QEStringValIsIn{enum.SystemNodeRoleLeaf.String(), enum.SystemNodeRoleAccess.String()}},
+			{Key: "name", Value: QEStringVal("n_system")},
+		})
+
+	var systemResult struct {
+		Items []struct {
+			System struct {
+				Id    ObjectId `json:"id"`
+				Label string   `json:"label"`
+				Role  string   `json:"role"`
+			} `json:"n_system"`
+		} `json:"items"`
+	}
+	err := systemQuery.Do(ctx, &systemResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying switches in blueprint %q - %w", o.blueprintId, err)
+	}
+
+	uplinkQuery := new(PathQuery).
+		SetBlueprintId(o.blueprintId).
+		SetBlueprintType(BlueprintTypeStaging).
+		SetClient(o.client).
+		Node([]QEEAttribute{
+			NodeTypeSystem.QEEAttribute(),
+			{Key: "role", Value: QEStringVal(enum.SystemNodeRoleAccess.String())},
+			{Key: "name", Value: QEStringVal("n_access")},
+		}).
+		Out([]QEEAttribute{RelationshipTypeHostedInterfaces.QEEAttribute()}).
+		Node([]QEEAttribute{NodeTypeInterface.QEEAttribute()}).
+		Out([]QEEAttribute{RelationshipTypeLink.QEEAttribute()}).
+		Node([]QEEAttribute{NodeTypeLink.QEEAttribute()}).
+		In([]QEEAttribute{RelationshipTypeLink.QEEAttribute()}).
+		Node([]QEEAttribute{NodeTypeInterface.QEEAttribute()}).
+		In([]QEEAttribute{RelationshipTypeHostedInterfaces.QEEAttribute()}).
+		Node([]QEEAttribute{
+			NodeTypeSystem.QEEAttribute(),
+			{Key: "role", Value: QEStringVal(enum.SystemNodeRoleLeaf.String())},
+			{Key: "name", Value: QEStringVal("n_leaf")},
+		})
+
+	var uplinkResult struct {
+		Items []struct {
+			Access struct {
+				Id ObjectId `json:"id"`
+			} `json:"n_access"`
+			Leaf struct {
+				Id ObjectId `json:"id"`
+			} `json:"n_leaf"`
+		} `json:"items"`
+	}
+	err = uplinkQuery.Do(ctx, &uplinkResult)
+	if err != nil {
+		return nil, fmt.Errorf("failed querying access switch uplinks in blueprint %q - %w", o.blueprintId, err)
+	}
+
+	leafIds := make(map[ObjectId][]ObjectId)
+	for _, item := range uplinkResult.Items {
+		if !slices.Contains(leafIds[item.Access.Id], item.Leaf.Id) {
+			leafIds[item.Access.Id] = append(leafIds[item.Access.Id], item.Leaf.Id)
+		}
+	}
+
+	tags, err := o.getNodeTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result VNBindingState
+	result.RedundancyGroups, err = o.GetAllRedundancyGroupInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching redundancy groups from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	result.Systems = make([]VNBindingSystem, len(systemResult.Items))
+	for i, item := range systemResult.Items {
+		result.Systems[i] = VNBindingSystem{
+			Id:      item.System.Id,
+			Label:   item.System.Label,
+			Role:    enum.SystemNodeRole{Value: item.System.Role},
+			Tags:    tags[item.System.Id],
+			LeafIds: leafIds[item.System.Id],
+		}
+	}
+
+	return &result, nil
+}
+
+// PlanVNBindings computes the binding changes required by each of reqs. The
+// plans share a single VLAN planner, so automatically selected VLANs do not
+// collide with one another. The plans can be reviewed and then applied with
+// ApplyVNBindingPlans; plans which will not be applied should be released.
+func (o *TwoStageL3ClosClient) PlanVNBindings(ctx context.Context, reqs ...VNBindingRequest) ([]*VNBindingPlan, error) {
+	state, err := o.GetVNBindingState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var planner *VlanVniPlanner
+	if slices.ContainsFunc(reqs, func(req VNBindingRequest) bool { return req.AutoVLAN }) {
+		planner, err = o.NewVlanVniPlanner(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]*VNBindingPlan, 0, len(reqs))
+	release := func() {
+		for _, plan := range result {
+			plan.Release()
+		}
+	}
+
+	for _, req := range reqs {
+		vn, err := o.GetVirtualNetwork(ctx, req.VnId.String())
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("failed fetching virtual network %q from blueprint %q - %w", req.VnId, o.blueprintId, err)
+		}
+
+		plan, err := state.Plan(vn, req, planner)
+		if err != nil {
+			release()
+			return nil, fmt.Errorf("failed planning bindings for virtual network %q - %w", req.VnId, err)
+		}
+		result = append(result, plan)
+	}
+
+	return result, nil
+}
+
+// ApplyVNBindingPlans applies plans using UpdateVirtualNetworkLeafBindings.
+// Empty plans are skipped. The VLAN reservation of each applied plan is
+// committed. When a plan fails, the reservations of it and of the plans not
+// yet applied are released.
+func (o *TwoStageL3ClosClient) ApplyVNBindingPlans(ctx context.Context, plans ...*VNBindingPlan) error {
+	for i, plan := range plans {
+		if plan.Empty() {
+			plan.Release()
+			continue
+		}
+
+		err := o.UpdateVirtualNetworkLeafBindings(ctx, plan.request())
+		if err != nil {
+			for _, p := range plans[i:] {
+				p.Release()
+			}
+			return fmt.Errorf("failed updating bindings of virtual network %q - %w", plan.VnId, err)
+		}
+		plan.commit()
+	}
+
+	return nil
+}
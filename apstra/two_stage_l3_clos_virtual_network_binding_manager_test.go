@@ -0,0 +1,136 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func testVNBindingState() *VNBindingState {
+	return &VNBindingState{
+		Systems: []VNBindingSystem{
+			{Id: "leaf1", Label: "leaf1", Role: enum.SystemNodeRoleLeaf, Tags: []string{"rack1"}},
+			{Id: "leaf2", Label: "leaf2", Role: enum.SystemNodeRoleLeaf, Tags: []string{"rack1"}},
+			{Id: "leaf3", Label: "leaf3", Role: enum.SystemNodeRoleLeaf, Tags: []string{"rack2"}},
+			{Id: "access1", Label: "access1", Role: enum.SystemNodeRoleAccess, LeafIds: []ObjectId{"leaf1", "leaf2"}, Tags: []string{"pod"}},
+			{Id: "access2", Label: "access2", Role: enum.SystemNodeRoleAccess, LeafIds: []ObjectId{"leaf1", "leaf2"}},
+			{Id: "access3", Label: "access3", Role: enum.SystemNodeRoleAccess, LeafIds: []ObjectId{"leaf3"}, Tags: []string{"pod"}},
+			{Id: "orphan", Label: "orphan", Role: enum.SystemNodeRoleAccess},
+			{Id: "spine1", Label: "spine1", Role: enum.SystemNodeRoleSpine, Tags: []string{"rack1"}},
+		},
+		RedundancyGroups: map[ObjectId]RedundancyGroupInfo{
+			"rg_leaf":   {Id: "rg_leaf", Type: enum.RedundancyGroupTypeEsi, SystemIds: [2]ObjectId{"leaf1", "leaf2"}},
+			"rg_access": {Id: "rg_access", Type: enum.RedundancyGroupTypeEsi, SystemIds: [2]ObjectId{"access1", "access2"}},
+		},
+	}
+}
+
+func TestVNBindingState_Plan(t *testing.T) {
+	state := testVNBindingState()
+
+	vn := datacenter.VirtualNetwork{
+		Bindings: []datacenter.VNBinding{
+			{SystemID: "leaf3", AccessSwitchNodeIDs: []string{"access3"}, VLAN: pointer.To(uint16(10))},
+		},
+		SVIIPs: []datacenter.SVIAddressing{{SystemID: "leaf3"}},
+	}
+
+	t.Run("bind_by_tag_uses_common_vlan", func(t *testing.T) {
+		plan, err := state.Plan(vn, VNBindingRequest{VnId: "vn1", Bind: VNBindingSelection{Tags: []string{"rack1"}}}, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[ObjectId]*datacenter.VNBinding{
+			"rg_leaf": {SystemID: "rg_leaf", VLAN: pointer.To(uint16(10))},
+		}, plan.Add)
+		require.Empty(t, plan.Remove)
+		require.Empty(t, plan.SviIps)
+	})
+
+	t.Run("bind_access_pair_by_member_label", func(t *testing.T) {
+		plan, err := state.Plan(vn, VNBindingRequest{VnId: "vn1", Bind: VNBindingSelection{SystemLabels: []string{"access2"}}, VLAN: pointer.To(uint16(20))}, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[ObjectId]*datacenter.VNBinding{
+			"rg_leaf": {SystemID: "rg_leaf", AccessSwitchNodeIDs: []string{"rg_access"}, VLAN: pointer.To(uint16(20))},
+			"leaf3":   {SystemID: "leaf3", AccessSwitchNodeIDs: []string{"access3"}, VLAN: pointer.To(uint16(20))},
+		}, plan.Add)
+		require.Contains(t, plan.SviIps, ObjectId("leaf3")) // modified binding retains its SVI addressing
+
+		req := plan.request()
+		require.Len(t, req.VnBindings, 2)
+	})
+
+	t.Run("unbind_access_only", func(t *testing.T) {
+		plan, err := state.Plan(vn, VNBindingRequest{VnId: "vn1", Unbind: VNBindingSelection{SystemLabels: []string{"access3"}}}, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[ObjectId]*datacenter.VNBinding{
+			"leaf3": {SystemID: "leaf3", AccessSwitchNodeIDs: []string{}, VLAN: pointer.To(uint16(10))},
+		}, plan.Add)
+		require.Empty(t, plan.Remove)
+	})
+
+	t.Run("unbind_leaf", func(t *testing.T) {
+		plan, err := state.Plan(vn, VNBindingRequest{VnId: "vn1", Unbind: VNBindingSelection{Tags: []string{"rack2"}}}, nil)
+		require.NoError(t, err)
+		require.Empty(t, plan.Add)
+		require.Equal(t, []ObjectId{"leaf3"}, plan.Remove)
+
+		req := plan.request()
+		require.Contains(t, req.VnBindings, ObjectId("leaf3"))
+		require.Nil(t, req.VnBindings["leaf3"])
+	})
+
+	t.Run("no_change", func(t *testing.T) {
+		plan, err := state.Plan(vn, VNBindingRequest{VnId: "vn1", Bind: VNBindingSelection{SystemLabels: []string{"access3"}}}, nil)
+		require.NoError(t, err)
+		require.True(t, plan.Empty())
+	})
+
+	t.Run("auto_vlan", func(t *testing.T) {
+		other := datacenter.VirtualNetwork{Bindings: []datacenter.VNBinding{{SystemID: "rg_leaf", VLAN: pointer.To(uint16(1))}}}
+		planner := NewVlanVniPlannerFromState(VlanVniPlannerState{VirtualNetworks: []datacenter.VirtualNetwork{other}})
+
+		req := VNBindingRequest{VnId: "vn2", Bind: VNBindingSelection{Tags: []string{"rack1"}}, AutoVLAN: true}
+		plan, err := state.Plan(datacenter.VirtualNetwork{}, req, planner)
+		require.NoError(t, err)
+		require.Equal(t, uint16(2), *plan.Add["rg_leaf"].VLAN)
+
+		// the selected VLAN is reserved, so the next plan gets a different one
+		req.VnId = "vn3"
+		plan3, err := state.Plan(datacenter.VirtualNetwork{}, req, planner)
+		require.NoError(t, err)
+		require.Equal(t, uint16(3), *plan3.Add["rg_leaf"].VLAN)
+
+		// a released plan returns its VLAN to the planner
+		plan3.Release()
+		vlan, err := planner.NextVLAN("rg_leaf")
+		require.NoError(t, err)
+		require.Equal(t, uint16(3), vlan)
+
+		// a committed plan's VLAN stays in use after its reservation is gone
+		plan.commit()
+		plan.Release()
+		plan, err = state.Plan(datacenter.VirtualNetwork{}, req, planner)
+		require.NoError(t, err)
+		require.Equal(t, uint16(3), *plan.Add["rg_leaf"].VLAN)
+
+		_, err = state.Plan(datacenter.VirtualNetwork{}, req, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, sel := range []VNBindingSelection{
+			{SystemLabels: []string{"nonexistent"}},
+			{SystemLabels: []string{"spine1"}},
+			{SystemLabels: []string{"orphan"}},
+		} {
+			_, err := state.Plan(vn, VNBindingRequest{VnId: "vn1", Bind: sel}, nil)
+			require.Error(t, err)
+		}
+	})
+}
@@ -0,0 +1,417 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+)
+
+const (
+	vlanMin = 1
+	vlanMax = 4094
+
+	DefaultVlanVniReservationTTL = 5 * time.Minute
+)
+
+// VlanVniPlannerState describes the VLAN and VNI usage of a blueprint. It is
+// normally collected by TwoStageL3ClosClient.NewVlanVniPlanner, but may be
+// assembled by the caller when the blueprint state is already at hand.
+type VlanVniPlannerState struct {
+	VirtualNetworks []datacenter.VirtualNetwork
+	SecurityZones   []datacenter.SecurityZone
+	VniRanges       IntRanges // ranges of the VNI pools assigned to the blueprint's virtual networks
+
+	// RedundancyGroups is used to translate VN bindings on a leaf or access
+	// redundancy group into the VLAN usage of its member switches.
+	RedundancyGroups map[ObjectId]RedundancyGroupInfo
+}
+
+// VlanVniReservation holds a VNI and/or VLAN on behalf of a caller until the
+// reservation is committed, released, or expires.
+type VlanVniReservation struct {
+	VNI       *uint32
+	VLAN      *uint16
+	SystemIDs []string // switches on which VLAN is reserved, with redundancy groups expanded to their members
+	Expires   time.Time
+
+	planner *VlanVniPlanner
+}
+
+// Release returns the reserved IDs to the planner.
+func (o *VlanVniReservation) Release() {
+	o.planner.mu.Lock()
+	defer o.planner.mu.Unlock()
+	delete(o.planner.reservations, o)
+}
+
+// Commit marks the reserved IDs as in use, typically after the virtual network
+// which uses them has been created. Committed IDs remain unavailable until the
+// planner is refreshed from a blueprint which no longer uses them. The IDs are
+// marked in use even when the reservation has expired (or been released) in
+// the meantime, because the caller has consumed them regardless.
+func (o *VlanVniReservation) Commit() {
+	o.planner.mu.Lock()
+	defer o.planner.mu.Unlock()
+	delete(o.planner.reservations, o)
+	o.planner.addUsed(o.VNI, o.VLAN, o.SystemIDs)
+}
+
+// VlanVniPlanner selects VNIs and VLANs which do not collide with those in use
+// by a blueprint's virtual networks and security zones, or with those reserved
+// by other users of the planner. It is safe for concurrent use.
+//
+// VNI uniqueness is per blueprint only: VNI pools may be shared by several
+// blueprints, and a VNI allocated from the pool by another blueprint is not
+// considered in use. Creation of a virtual network with such a VNI fails, in
+// which case the planner should be refreshed and another VNI chosen.
+type VlanVniPlanner struct {
+	mu           sync.Mutex
+	vniRanges    IntRanges
+	usedVnis     map[uint32]struct{}
+	globalVlans  map[uint16]struct{}            // VLANs unavailable on every leaf
+	leafVlans    map[string]map[uint16]struct{} // VLANs unavailable on specific leafs
+	rgs          map[ObjectId]RedundancyGroupInfo
+	reservations map[*VlanVniReservation]struct{}
+	refresh      func(context.Context) (*VlanVniPlannerState, error)
+	now          func() time.Time
+}
+
+// NewVlanVniPlanner returns a planner based on the blueprint's current
+// virtual networks, security zones and VNI pool allocations.
+func (o *TwoStageL3ClosClient) NewVlanVniPlanner(ctx context.Context) (*VlanVniPlanner, error) {
+	result := NewVlanVniPlannerFromState(VlanVniPlannerState{})
+	result.refresh = o.vlanVniPlannerState
+
+	err := result.Refresh(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (o *TwoStageL3ClosClient) vlanVniPlannerState(ctx context.Context) (*VlanVniPlannerState, error) {
+	var result VlanVniPlannerState
+	var err error
+
+	result.VirtualNetworks, err = o.GetVirtualNetworks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching virtual networks from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	result.SecurityZones, err = o.GetSecurityZones(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching security zones from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	rgaSlice, err := o.GetResourceAllocations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching resource allocations from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	for _, rga := range rgaSlice {
+		if rga.ResourceGroup.Type != ResourceTypeVniPool || rga.ResourceGroup.Name != ResourceGroupNameVxlanVnIds {
+			continue
+		}
+		for _, poolId := range rga.PoolIds {
+			pool, err := o.client.GetVniPool(ctx, poolId)
+			if err != nil {
+				return nil, fmt.Errorf("failed fetching VNI pool %q - %w", poolId, err)
+			}
+			result.VniRanges = append(result.VniRanges, pool.Ranges...)
+		}
+	}
+
+	result.RedundancyGroups, err = o.GetAllRedundancyGroupInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching redundancy groups from blueprint %q - %w", o.blueprintId, err)
+	}
+
+	return &result, nil
+}
+
+// NewVlanVniPlannerFromState returns a planner based on state. Planners created
+// this way cannot be refreshed.
+func NewVlanVniPlannerFromState(state VlanVniPlannerState) *VlanVniPlanner {
+	result := VlanVniPlanner{
+		reservations: make(map[*VlanVniReservation]struct{}),
+		now:          time.Now,
+	}
+	result.load(state)
+	return &result
+}
+
+// load replaces the planner's view of the blueprint with state. Reservations
+// are not affected. The caller must hold the lock or have exclusive access.
+func (o *VlanVniPlanner) load(state VlanVniPlannerState) {
+	o.vniRanges = slices.Clone(state.VniRanges)
+	o.usedVnis = make(map[uint32]struct{})
+	o.globalVlans = make(map[uint16]struct{})
+	o.leafVlans = make(map[string]map[uint16]struct{})
+	o.rgs = maps.Clone(state.RedundancyGroups)
+
+	for _, sz := range state.SecurityZones {
+		if sz.VNI != nil {
+			o.usedVnis[uint32(*sz.VNI)] = struct{}{}
+		}
+		if sz.VLAN != nil {
+			o.globalVlans[*sz.VLAN] = struct{}{}
+		}
+	}
+
+	for _, vn := range state.VirtualNetworks {
+		if vn.VNI != nil {
+			o.usedVnis[*vn.VNI] = struct{}{}
+		}
+		if vn.ReservedVLAN != nil {
+			o.globalVlans[*vn.ReservedVLAN] = struct{}{}
+		}
+		for _, binding := range vn.Bindings {
+			if binding.VLAN == nil {
+				continue
+			}
+			for _, id := range expandRedundancyGroupIds(bindingSystemIds([]datacenter.VNBinding{binding}), o.rgs) {
+				o.addLeafVlan(*binding.VLAN, id)
+			}
+		}
+	}
+}
+
+func (o *VlanVniPlanner) addLeafVlan(vlan uint16, systemId string) {
+	if o.leafVlans[systemId] == nil {
+		o.leafVlans[systemId] = make(map[uint16]struct{})
+	}
+	o.leafVlans[systemId][vlan] = struct{}{}
+}
+
+func (o *VlanVniPlanner) addUsed(vni *uint32, vlan *uint16, systemIds []string) {
+	if vni != nil {
+		o.usedVnis[*vni] = struct{}{}
+	}
+	if vlan != nil {
+		for _, id := range expandRedundancyGroupIds(systemIds, o.rgs) {
+			o.addLeafVlan(*vlan, id)
+		}
+	}
+}
+
+// Refresh re-reads the blueprint state. Outstanding reservations are retained.
+func (o *VlanVniPlanner) Refresh(ctx context.Context) error {
+	if o.refresh == nil {
+		return errors.New("planner was not created from a blueprint and cannot be refreshed")
+	}
+
+	state, err := o.refresh(ctx)
+	if err != nil {
+		return err
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.load(*state)
+	return nil
+}
+
+// expire drops reservations which have timed out. The caller must hold the lock.
+func (o *VlanVniPlanner) expire() {
+	now := o.now()
+	for r := range o.reservations {
+		if !now.Before(r.Expires) {
+			delete(o.reservations, r)
+		}
+	}
+}
+
+func (o *VlanVniPlanner) vniAvailable(vni uint32) bool {
+	if _, ok := o.usedVnis[vni]; ok {
+		return false
+	}
+	for r := range o.reservations {
+		if r.VNI != nil && *r.VNI == vni {
+			return false
+		}
+	}
+	return true
+}
+
+// vlanAvailable checks vlan against systemIds, which the caller must have
+// expanded with expandRedundancyGroupIds.
+func (o *VlanVniPlanner) vlanAvailable(vlan uint16, systemIds []string) bool {
+	if _, ok := o.globalVlans[vlan]; ok {
+		return false
+	}
+	for _, id := range systemIds {
+		if _, ok := o.leafVlans[id][vlan]; ok {
+			return false
+		}
+	}
+	for r := range o.reservations {
+		if r.VLAN == nil || *r.VLAN != vlan {
+			continue
+		}
+		for _, id := range systemIds {
+			if slices.Contains(r.SystemIDs, id) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (o *VlanVniPlanner) nextVni() (uint32, error) {
+	if len(o.vniRanges) == 0 {
+		return 0, ClientErr{
+			errType: ErrNotfound,
+			err:     errors.New("no VNI pools are assigned to the blueprint's virtual networks"),
+		}
+	}
+
+	ranges := slices.Clone(o.vniRanges)
+	slices.SortFunc(ranges, func(a, b IntRange) int { return cmp.Compare(a.First, b.First) })
+	for _, r := range ranges {
+		for vni := r.First; vni <= r.Last && vni >= r.First; vni++ {
+			if o.vniAvailable(vni) {
+				return vni, nil
+			}
+		}
+	}
+
+	return 0, ClientErr{
+		errType: ErrNotfound,
+		err:     errors.New("no free VNI in the blueprint's VNI pools"),
+	}
+}
+
+func (o *VlanVniPlanner) nextVlan(systemIds []string) (uint16, error) {
+	systemIds = expandRedundancyGroupIds(systemIds, o.rgs)
+	for vlan := uint16(vlanMin); vlan <= vlanMax; vlan++ {
+		if o.vlanAvailable(vlan, systemIds) {
+			return vlan, nil
+		}
+	}
+
+	return 0, ClientErr{
+		errType: ErrNotfound,
+		err:     fmt.Errorf("no VLAN is free on all of systems %v", systemIds),
+	}
+}
+
+// NextVNI returns the lowest VNI from the blueprint's VNI pools which is
+// neither in use nor reserved. The VNI is not reserved.
+func (o *VlanVniPlanner) NextVNI() (uint32, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.expire()
+	return o.nextVni()
+}
+
+// NextVLAN returns the lowest VLAN which is neither in use nor reserved on
+// any of the specified leaf switches. Redundancy group IDs stand for both
+// member switches. The VLAN is not reserved.
+func (o *VlanVniPlanner) NextVLAN(systemIds ...string) (uint16, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.expire()
+	return o.nextVlan(systemIds)
+}
+
+// NextVLANForBindings is a convenience wrapper around NextVLAN which considers
+// the leaf and access switches in bindings.
+func (o *VlanVniPlanner) NextVLANForBindings(bindings []datacenter.VNBinding) (uint16, error) {
+	return o.NextVLAN(bindingSystemIds(bindings)...)
+}
+
+// ReserveVNI reserves the next free VNI for ttl. A zero ttl selects
+// DefaultVlanVniReservationTTL.
+func (o *VlanVniPlanner) ReserveVNI(ttl time.Duration) (*VlanVniReservation, error) {
+	return o.reserve(true, nil, ttl)
+}
+
+// ReserveVLAN reserves, for ttl, the next VLAN which is free on all of the
+// specified leaf switches. A zero ttl selects DefaultVlanVniReservationTTL.
+func (o *VlanVniPlanner) ReserveVLAN(ttl time.Duration, systemIds ...string) (*VlanVniReservation, error) {
+	if len(systemIds) == 0 {
+		return nil, errors.New("at least one system ID is required to reserve a VLAN")
+	}
+	return o.reserve(false, systemIds, ttl)
+}
+
+// ReserveVirtualNetwork reserves, for ttl, both the next free VNI and the next
+// VLAN which is free on all switches in bindings. A zero ttl selects
+// DefaultVlanVniReservationTTL.
+func (o *VlanVniPlanner) ReserveVirtualNetwork(ttl time.Duration, bindings []datacenter.VNBinding) (*VlanVniReservation, error) {
+	return o.reserve(true, bindingSystemIds(bindings), ttl)
+}
+
+func (o *VlanVniPlanner) reserve(vni bool, systemIds []string, ttl time.Duration) (*VlanVniReservation, error) {
+	if ttl == 0 {
+		ttl = DefaultVlanVniReservationTTL
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.expire()
+
+	result := VlanVniReservation{
+		SystemIDs: expandRedundancyGroupIds(systemIds, o.rgs),
+		Expires:   o.now().Add(ttl),
+		planner:   o,
+	}
+
+	if vni {
+		v, err := o.nextVni()
+		if err != nil {
+			return nil, err
+		}
+		result.VNI = &v
+	}
+
+	if len(systemIds) > 0 {
+		v, err := o.nextVlan(systemIds)
+		if err != nil {
+			return nil, err
+		}
+		result.VLAN = &v
+	}
+
+	o.reservations[&result] = struct{}{}
+	return &result, nil
+}
+
+func bindingSystemIds(bindings []datacenter.VNBinding) []string {
+	var result []string
+	for _, binding := range bindings {
+		result = append(result, binding.SystemID)
+		result = append(result, binding.AccessSwitchNodeIDs...)
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// expandRedundancyGroupIds returns the sorted, de-duplicated ids with each
+// redundancy group ID replaced by the IDs of its member systems. VLANs bound
+// to a redundancy group are in use on both members, so comparisons between
+// bindings must be made on member IDs.
+func expandRedundancyGroupIds(ids []string, rgs map[ObjectId]RedundancyGroupInfo) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if rg, ok := rgs[ObjectId(id)]; ok {
+			result = append(result, rg.SystemIds[0].String(), rg.SystemIds[1].String())
+			continue
+		}
+		result = append(result, id)
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
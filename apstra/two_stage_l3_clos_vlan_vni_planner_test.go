@@ -0,0 +1,212 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package apstra
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func testVlanVniPlanner() *VlanVniPlanner {
+	return NewVlanVniPlannerFromState(VlanVniPlannerState{
+		VirtualNetworks: []datacenter.VirtualNetwork{
+			{
+				VNI: pointer.To(uint32(10000)),
+				Bindings: []datacenter.VNBinding{
+					{SystemID: "leaf1", VLAN: pointer.To(uint16(1))},
+					{SystemID: "leaf2", VLAN: pointer.To(uint16(2)), AccessSwitchNodeIDs: []string{"access1"}},
+				},
+			},
+			{
+				VNI:          pointer.To(uint32(10002)),
+				ReservedVLAN: pointer.To(uint16(4)),
+			},
+		},
+		SecurityZones: []datacenter.SecurityZone{
+			{VNI: pointer.To(10001), VLAN: pointer.To(uint16(3))},
+		},
+		VniRanges: IntRanges{
+			{First: 20000, Last: 20001},
+			{First: 10000, Last: 10004},
+		},
+	})
+}
+
+func TestVlanVniPlanner(t *testing.T) {
+	t.Run("next", func(t *testing.T) {
+		p := testVlanVniPlanner()
+
+		vni, err := p.NextVNI()
+		require.NoError(t, err)
+		require.Equal(t, uint32(10003), vni)
+
+		vlan, err := p.NextVLAN("leaf1")
+		require.NoError(t, err)
+		require.Equal(t, uint16(2), vlan)
+
+		vlan, err = p.NextVLAN("leaf1", "leaf2")
+		require.NoError(t, err)
+		require.Equal(t, uint16(5), vlan) // 3 and 4 are used fabric-wide
+
+		vlan, err = p.NextVLANForBindings([]datacenter.VNBinding{{SystemID: "leaf3", AccessSwitchNodeIDs: []string{"access1"}}})
+		require.NoError(t, err)
+		require.Equal(t, uint16(1), vlan)
+	})
+
+	t.Run("reserve_release_commit", func(t *testing.T) {
+		p := testVlanVniPlanner()
+
+		r1, err := p.ReserveVirtualNetwork(0, []datacenter.VNBinding{{SystemID: "leaf1"}})
+		require.NoError(t, err)
+		require.Equal(t, uint32(10003), *r1.VNI)
+		require.Equal(t, uint16(2), *r1.VLAN)
+
+		r2, err := p.ReserveVirtualNetwork(0, []datacenter.VNBinding{{SystemID: "leaf1"}})
+		require.NoError(t, err)
+		require.Equal(t, uint32(10004), *r2.VNI)
+		require.Equal(t, uint16(5), *r2.VLAN)
+
+		// reserved VLANs are per-leaf
+		vlan, err := p.NextVLAN("leaf3")
+		require.NoError(t, err)
+		require.Equal(t, uint16(1), vlan)
+
+		vni, err := p.NextVNI()
+		require.NoError(t, err)
+		require.Equal(t, uint32(20000), vni)
+
+		r1.Release()
+		vni, err = p.NextVNI()
+		require.NoError(t, err)
+		require.Equal(t, uint32(10003), vni)
+
+		r2.Commit()
+		r2.Release() // no effect after commit
+		vlan, err = p.NextVLAN("leaf1")
+		require.NoError(t, err)
+		require.Equal(t, uint16(2), vlan)
+		vlan, err = p.NextVLAN("leaf1", "leaf2")
+		require.NoError(t, err)
+		require.Equal(t, uint16(6), vlan)
+	})
+
+	t.Run("expiry", func(t *testing.T) {
+		p := testVlanVniPlanner()
+		now := time.Now()
+		p.now = func() time.Time { return now }
+
+		_, err := p.ReserveVLAN(time.Minute, "leaf1")
+		require.NoError(t, err)
+		vlan, err := p.NextVLAN("leaf1")
+		require.NoError(t, err)
+		require.Equal(t, uint16(5), vlan)
+
+		now = now.Add(time.Minute)
+		vlan, err = p.NextVLAN("leaf1")
+		require.NoError(t, err)
+		require.Equal(t, uint16(2), vlan)
+	})
+
+	t.Run("commit_after_expiry", func(t *testing.T) {
+		p := testVlanVniPlanner()
+		now := time.Now()
+		p.now = func() time.Time { return now }
+
+		r, err := p.ReserveVirtualNetwork(time.Minute, []datacenter.VNBinding{{SystemID: "leaf1"}})
+		require.NoError(t, err)
+		require.Equal(t, uint32(10003), *r.VNI)
+		require.Equal(t, uint16(2), *r.VLAN)
+
+		// the reservation expires while the virtual network is being created
+		now = now.Add(time.Minute)
+		r.Commit()
+
+		vni, err := p.NextVNI()
+		require.NoError(t, err)
+		require.Equal(t, uint32(10004), vni)
+		vlan, err := p.NextVLAN("leaf1")
+		require.NoError(t, err)
+		require.Equal(t, uint16(5), vlan)
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		p := NewVlanVniPlannerFromState(VlanVniPlannerState{VniRanges: IntRanges{{First: 1, Last: 1}}})
+
+		_, err := p.ReserveVNI(0)
+		require.NoError(t, err)
+
+		_, err = p.ReserveVNI(0)
+		var ace ClientErr
+		require.True(t, errors.As(err, &ace))
+		require.Equal(t, ErrNotfound, ace.Type())
+
+		_, err = NewVlanVniPlannerFromState(VlanVniPlannerState{}).NextVNI()
+		require.Error(t, err)
+
+		_, err = p.ReserveVLAN(0)
+		require.Error(t, err)
+
+		require.Error(t, p.Refresh(t.Context()))
+	})
+
+	t.Run("redundancy_group", func(t *testing.T) {
+		p := NewVlanVniPlannerFromState(VlanVniPlannerState{
+			VirtualNetworks: []datacenter.VirtualNetwork{
+				{Bindings: []datacenter.VNBinding{{SystemID: "rg1", VLAN: pointer.To(uint16(1))}}},
+				{Bindings: []datacenter.VNBinding{{SystemID: "leaf2a", VLAN: pointer.To(uint16(2))}}},
+			},
+			RedundancyGroups: map[ObjectId]RedundancyGroupInfo{
+				"rg1": {Id: "rg1", SystemIds: [2]ObjectId{"leaf1a", "leaf1b"}},
+				"rg2": {Id: "rg2", SystemIds: [2]ObjectId{"leaf2a", "leaf2b"}},
+			},
+		})
+
+		// VLAN bound on the group is in use on its members
+		vlan, err := p.NextVLAN("leaf1b")
+		require.NoError(t, err)
+		require.Equal(t, uint16(2), vlan)
+
+		// VLAN bound on a member is in use on its group
+		vlan, err = p.NextVLAN("rg2")
+		require.NoError(t, err)
+		require.Equal(t, uint16(1), vlan)
+
+		r, err := p.ReserveVLAN(0, "rg2")
+		require.NoError(t, err)
+		require.Equal(t, uint16(1), *r.VLAN)
+		require.Equal(t, []string{"leaf2a", "leaf2b"}, r.SystemIDs)
+		vlan, err = p.NextVLAN("leaf2b")
+		require.NoError(t, err)
+		require.Equal(t, uint16(2), vlan) // 1 is reserved via the group
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		p := NewVlanVniPlannerFromState(VlanVniPlannerState{VniRanges: IntRanges{{First: 5000, Last: 5999}}})
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		seen := make(map[uint32]bool)
+		for range 100 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				r, err := p.ReserveVNI(0)
+				require.NoError(t, err)
+				mu.Lock()
+				defer mu.Unlock()
+				require.False(t, seen[*r.VNI])
+				seen[*r.VNI] = true
+			}()
+		}
+		wg.Wait()
+		require.Len(t, seen, 100)
+	})
+}
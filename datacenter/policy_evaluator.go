@@ -0,0 +1,150 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package datacenter
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+// Flow describes traffic between two application points which is to be
+// evaluated against security policies. SrcIP and DstIP are optional and are
+// used only to enforce the policies' AddressFamily.
+type Flow struct {
+	SrcApplicationPoint string
+	DstApplicationPoint string
+	SrcIP               net.IP
+	DstIP               net.IP
+	Protocol            enum.PolicyRuleProtocol
+	SrcPort             uint16 // TCP and UDP only
+	DstPort             uint16 // TCP and UDP only
+	Established         bool   // TCP only; matches rules with TcpStateQualifierEstablished
+}
+
+func (f Flow) validate() error {
+	if f.SrcApplicationPoint == "" || f.DstApplicationPoint == "" {
+		return errors.New("flow requires both source and destination application points")
+	}
+
+	switch f.Protocol {
+	case enum.PolicyRuleProtocolTcp, enum.PolicyRuleProtocolUdp, enum.PolicyRuleProtocolIcmp:
+	default:
+		return fmt.Errorf("flow protocol must be one of %s, %s or %s, got %q",
+			enum.PolicyRuleProtocolTcp, enum.PolicyRuleProtocolUdp, enum.PolicyRuleProtocolIcmp, f.Protocol)
+	}
+
+	if f.SrcIP != nil && f.DstIP != nil && (f.SrcIP.To4() == nil) != (f.DstIP.To4() == nil) {
+		return fmt.Errorf("flow source %s and destination %s IP addresses belong to different families", f.SrcIP, f.DstIP)
+	}
+
+	return nil
+}
+
+// PolicyRuleRef identifies a rule by its position within a policy.
+type PolicyRuleRef struct {
+	Policy *Policy
+	Index  int
+}
+
+// Rule returns the referenced rule.
+func (o PolicyRuleRef) Rule() PolicyRule {
+	return o.Policy.Rules[o.Index]
+}
+
+func (o PolicyRuleRef) String() string {
+	return fmt.Sprintf("policy %q rule %d (%q)", o.Policy.Label, o.Index, o.Policy.Rules[o.Index].Label)
+}
+
+// PolicyVerdict is the outcome of evaluating a Flow.
+type PolicyVerdict struct {
+	Action enum.PolicyRuleAction
+	Rule   *PolicyRuleRef // nil when no rule matched and the default action applies
+}
+
+// PolicyEvaluator evaluates flows against a set of security policies without
+// involving Apstra. Disabled policies are ignored. Policies are considered in
+// the order supplied, and rules within each policy in order; the first
+// matching rule determines the action. A nil application point in a policy
+// matches any application point.
+type PolicyEvaluator struct {
+	Policies      []Policy
+	DefaultAction enum.PolicyRuleAction // applied when no rule matches; zero value selects permit
+}
+
+// Evaluate returns the verdict for flow.
+func (o PolicyEvaluator) Evaluate(flow Flow) (PolicyVerdict, error) {
+	err := flow.validate()
+	if err != nil {
+		return PolicyVerdict{}, err
+	}
+
+	for i := range o.Policies {
+		policy := &o.Policies[i]
+		if !policy.appliesTo(flow) {
+			continue
+		}
+
+		for j, rule := range policy.Rules {
+			if rule.matches(flow) {
+				return PolicyVerdict{Action: rule.Action, Rule: &PolicyRuleRef{Policy: policy, Index: j}}, nil
+			}
+		}
+	}
+
+	result := PolicyVerdict{Action: o.DefaultAction}
+	if result.Action.Value == "" {
+		result.Action = enum.PolicyRuleActionPermit
+	}
+
+	return result, nil
+}
+
+func (p Policy) appliesTo(flow Flow) bool {
+	if !p.Enabled {
+		return false
+	}
+
+	if p.SrcApplicationPoint != nil && *p.SrcApplicationPoint != flow.SrcApplicationPoint {
+		return false
+	}
+
+	if p.DstApplicationPoint != nil && *p.DstApplicationPoint != flow.DstApplicationPoint {
+		return false
+	}
+
+	if p.AddressFamily != nil && flow.SrcIP != nil {
+		switch *p.AddressFamily {
+		case enum.PolicyAddressFamilyIPv4:
+			return flow.SrcIP.To4() != nil
+		case enum.PolicyAddressFamilyIPv6:
+			return flow.SrcIP.To4() == nil
+		}
+	}
+
+	return true
+}
+
+func (pr PolicyRule) matches(flow Flow) bool {
+	if pr.Protocol == enum.PolicyRuleProtocolIp {
+		return true
+	}
+
+	if pr.Protocol != flow.Protocol {
+		return false
+	}
+
+	if pr.Protocol == enum.PolicyRuleProtocolIcmp {
+		return true
+	}
+
+	if pr.established() && !flow.Established {
+		return false
+	}
+
+	return pr.SrcPort.Contains(flow.SrcPort) && pr.DstPort.Contains(flow.DstPort)
+}
@@ -0,0 +1,150 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package datacenter_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+var testPolicies = []datacenter.Policy{
+	{
+		Enabled:             true,
+		Label:               "a_to_b",
+		SrcApplicationPoint: pointer.To("vn:a"),
+		DstApplicationPoint: pointer.To("vn:b"),
+		Rules: []datacenter.PolicyRule{
+			{Label: "https", Protocol: enum.PolicyRuleProtocolTcp, Action: enum.PolicyRuleActionPermit, DstPort: datacenter.PortRanges{{First: 443, Last: 443}}},
+			{Label: "established", Protocol: enum.PolicyRuleProtocolTcp, Action: enum.PolicyRuleActionPermit, TcpStateQualifier: &enum.TcpStateQualifierEstablished},
+			{Label: "dns", Protocol: enum.PolicyRuleProtocolUdp, Action: enum.PolicyRuleActionPermitLog, DstPort: datacenter.PortRanges{{First: 53, Last: 53}}},
+			{Label: "deny_rest", Protocol: enum.PolicyRuleProtocolIp, Action: enum.PolicyRuleActionDenyLog},
+		},
+	},
+	{
+		Enabled:             false,
+		Label:               "disabled",
+		SrcApplicationPoint: pointer.To("vn:c"),
+		Rules: []datacenter.PolicyRule{
+			{Label: "deny_all", Protocol: enum.PolicyRuleProtocolIp, Action: enum.PolicyRuleActionDeny},
+		},
+	},
+	{
+		Enabled:             true,
+		Label:               "from_c_v6",
+		SrcApplicationPoint: pointer.To("vn:c"),
+		AddressFamily:       &enum.PolicyAddressFamilyIPv6,
+		Rules: []datacenter.PolicyRule{
+			{Label: "icmp", Protocol: enum.PolicyRuleProtocolIcmp, Action: enum.PolicyRuleActionDeny},
+		},
+	},
+}
+
+func TestPolicyEvaluator_Evaluate(t *testing.T) {
+	type testCase struct {
+		flow       datacenter.Flow
+		evaluator  datacenter.PolicyEvaluator
+		expAction  enum.PolicyRuleAction
+		expPolicy  string
+		expRuleIdx int
+		expErr     bool
+	}
+
+	evaluator := datacenter.PolicyEvaluator{Policies: testPolicies}
+
+	testCases := map[string]testCase{
+		"https_permitted": {
+			flow:       datacenter.Flow{SrcApplicationPoint: "vn:a", DstApplicationPoint: "vn:b", Protocol: enum.PolicyRuleProtocolTcp, SrcPort: 50000, DstPort: 443},
+			evaluator:  evaluator,
+			expAction:  enum.PolicyRuleActionPermit,
+			expPolicy:  "a_to_b",
+			expRuleIdx: 0,
+		},
+		"http_denied": {
+			flow:       datacenter.Flow{SrcApplicationPoint: "vn:a", DstApplicationPoint: "vn:b", Protocol: enum.PolicyRuleProtocolTcp, SrcPort: 50000, DstPort: 80},
+			evaluator:  evaluator,
+			expAction:  enum.PolicyRuleActionDenyLog,
+			expPolicy:  "a_to_b",
+			expRuleIdx: 3,
+		},
+		"established_permitted": {
+			flow:       datacenter.Flow{SrcApplicationPoint: "vn:a", DstApplicationPoint: "vn:b", Protocol: enum.PolicyRuleProtocolTcp, SrcPort: 50000, DstPort: 80, Established: true},
+			evaluator:  evaluator,
+			expAction:  enum.PolicyRuleActionPermit,
+			expPolicy:  "a_to_b",
+			expRuleIdx: 1,
+		},
+		"dns": {
+			flow:       datacenter.Flow{SrcApplicationPoint: "vn:a", DstApplicationPoint: "vn:b", Protocol: enum.PolicyRuleProtocolUdp, SrcPort: 50000, DstPort: 53},
+			evaluator:  evaluator,
+			expAction:  enum.PolicyRuleActionPermitLog,
+			expPolicy:  "a_to_b",
+			expRuleIdx: 2,
+		},
+		"reverse_direction_default": {
+			flow:      datacenter.Flow{SrcApplicationPoint: "vn:b", DstApplicationPoint: "vn:a", Protocol: enum.PolicyRuleProtocolTcp, SrcPort: 443, DstPort: 50000},
+			evaluator: evaluator,
+			expAction: enum.PolicyRuleActionPermit,
+		},
+		"explicit_default": {
+			flow:      datacenter.Flow{SrcApplicationPoint: "vn:b", DstApplicationPoint: "vn:a", Protocol: enum.PolicyRuleProtocolIcmp},
+			evaluator: datacenter.PolicyEvaluator{Policies: testPolicies, DefaultAction: enum.PolicyRuleActionDeny},
+			expAction: enum.PolicyRuleActionDeny,
+		},
+		"wildcard_destination_ipv6": {
+			flow:       datacenter.Flow{SrcApplicationPoint: "vn:c", DstApplicationPoint: "vn:z", SrcIP: net.ParseIP("2001:db8::1"), DstIP: net.ParseIP("2001:db8::2"), Protocol: enum.PolicyRuleProtocolIcmp},
+			evaluator:  evaluator,
+			expAction:  enum.PolicyRuleActionDeny,
+			expPolicy:  "from_c_v6",
+			expRuleIdx: 0,
+		},
+		"wrong_address_family": {
+			flow:      datacenter.Flow{SrcApplicationPoint: "vn:c", DstApplicationPoint: "vn:z", SrcIP: net.ParseIP("10.0.0.1"), Protocol: enum.PolicyRuleProtocolIcmp},
+			evaluator: evaluator,
+			expAction: enum.PolicyRuleActionPermit,
+		},
+		"missing_application_point": {
+			flow:      datacenter.Flow{SrcApplicationPoint: "vn:a", Protocol: enum.PolicyRuleProtocolIcmp},
+			evaluator: evaluator,
+			expErr:    true,
+		},
+		"ip_protocol_flow": {
+			flow:      datacenter.Flow{SrcApplicationPoint: "vn:a", DstApplicationPoint: "vn:b", Protocol: enum.PolicyRuleProtocolIp},
+			evaluator: evaluator,
+			expErr:    true,
+		},
+		"mixed_families": {
+			flow:      datacenter.Flow{SrcApplicationPoint: "vn:a", DstApplicationPoint: "vn:b", SrcIP: net.ParseIP("10.0.0.1"), DstIP: net.ParseIP("2001:db8::1"), Protocol: enum.PolicyRuleProtocolIcmp},
+			evaluator: evaluator,
+			expErr:    true,
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tCase.evaluator.Evaluate(tCase.flow)
+			if tCase.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tCase.expAction, result.Action)
+
+			if tCase.expPolicy == "" {
+				require.Nil(t, result.Rule)
+				return
+			}
+			require.NotNil(t, result.Rule)
+			require.Equal(t, tCase.expPolicy, result.Rule.Policy.Label)
+			require.Equal(t, tCase.expRuleIdx, result.Rule.Index)
+		})
+	}
+}
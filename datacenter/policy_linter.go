@@ -0,0 +1,128 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package datacenter
+
+import (
+	"fmt"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+)
+
+// PolicyFinding describes a rule which is shadowed by, redundant with, or
+// conflicts with an earlier rule.
+type PolicyFinding struct {
+	Issue enum.PolicyRuleIssue
+	Rule  PolicyRuleRef // the rule with the problem
+	By    PolicyRuleRef // the earlier rule responsible for the problem
+}
+
+func (o PolicyFinding) String() string {
+	switch o.Issue {
+	case enum.PolicyRuleIssueShadowed:
+		return fmt.Sprintf("%s is never matched because %s matches the same traffic with action %q", o.Rule, o.By, o.By.Rule().Action)
+	case enum.PolicyRuleIssueRedundant:
+		return fmt.Sprintf("%s is redundant because %s matches the same traffic with the same action", o.Rule, o.By)
+	case enum.PolicyRuleIssueConflicting:
+		return fmt.Sprintf("%s partially overlaps %s which takes action %q", o.Rule, o.By, o.By.Rule().Action)
+	}
+	return fmt.Sprintf("%s: %s by %s", o.Rule, o.Issue, o.By)
+}
+
+// LintPolicies inspects the rules of enabled policies for problems. Rules are
+// compared with earlier rules in the same policy and in earlier policies which
+// have identical application points and address family, in the order used by
+// PolicyEvaluator. Each rule is compared against earlier rules individually,
+// so a rule covered only by the combination of several earlier rules is not
+// reported as shadowed or redundant. A rule which completely covers an earlier
+// rule with a different action (a specific exception followed by a general
+// rule) is not considered to conflict with it.
+func LintPolicies(policies []Policy) []PolicyFinding {
+	type scope struct {
+		src, dst, af string
+	}
+
+	deref := func(s *string) string {
+		if s == nil {
+			return "*"
+		}
+		return *s
+	}
+
+	var result []PolicyFinding
+	earlier := make(map[scope][]PolicyRuleRef)
+	for i := range policies {
+		policy := &policies[i]
+		if !policy.Enabled {
+			continue
+		}
+
+		key := scope{src: deref(policy.SrcApplicationPoint), dst: deref(policy.DstApplicationPoint)}
+		if policy.AddressFamily != nil {
+			key.af = policy.AddressFamily.String()
+		}
+
+		for j := range policy.Rules {
+			ref := PolicyRuleRef{Policy: policy, Index: j}
+			result = append(result, lintRule(ref, earlier[key])...)
+			earlier[key] = append(earlier[key], ref)
+		}
+	}
+
+	return result
+}
+
+// lintRule compares ref with the earlier rules which take precedence over it.
+func lintRule(ref PolicyRuleRef, earlier []PolicyRuleRef) []PolicyFinding {
+	rule := ref.Rule()
+
+	var conflicts []PolicyFinding
+	for _, e := range earlier {
+		earlierRule := e.Rule()
+		switch {
+		case earlierRule.covers(rule) && earlierRule.Action == rule.Action:
+			return []PolicyFinding{{Issue: enum.PolicyRuleIssueRedundant, Rule: ref, By: e}}
+		case earlierRule.covers(rule):
+			return []PolicyFinding{{Issue: enum.PolicyRuleIssueShadowed, Rule: ref, By: e}}
+		case earlierRule.overlaps(rule) && earlierRule.Action != rule.Action && !rule.covers(earlierRule):
+			conflicts = append(conflicts, PolicyFinding{Issue: enum.PolicyRuleIssueConflicting, Rule: ref, By: e})
+		}
+	}
+
+	return conflicts
+}
+
+func (pr PolicyRule) established() bool {
+	return pr.TcpStateQualifier != nil && *pr.TcpStateQualifier == enum.TcpStateQualifierEstablished
+}
+
+// covers returns true when every flow matched by other is also matched by pr.
+func (pr PolicyRule) covers(other PolicyRule) bool {
+	switch {
+	case pr.Protocol == enum.PolicyRuleProtocolIp:
+		return true
+	case pr.Protocol != other.Protocol:
+		return false
+	case pr.Protocol == enum.PolicyRuleProtocolIcmp:
+		return true
+	case pr.established() && !other.established():
+		return false
+	}
+
+	return pr.SrcPort.Covers(other.SrcPort) && pr.DstPort.Covers(other.DstPort)
+}
+
+// overlaps returns true when at least one flow is matched by both pr and other.
+func (pr PolicyRule) overlaps(other PolicyRule) bool {
+	switch {
+	case pr.Protocol == enum.PolicyRuleProtocolIp || other.Protocol == enum.PolicyRuleProtocolIp:
+		return true
+	case pr.Protocol != other.Protocol:
+		return false
+	case pr.Protocol == enum.PolicyRuleProtocolIcmp:
+		return true
+	}
+
+	return pr.SrcPort.Overlaps(other.SrcPort) && pr.DstPort.Overlaps(other.DstPort)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package datacenter_test
+
+import (
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintPolicies(t *testing.T) {
+	tcp := func(label string, action enum.PolicyRuleAction, dst ...datacenter.PortRange) datacenter.PolicyRule {
+		return datacenter.PolicyRule{Label: label, Protocol: enum.PolicyRuleProtocolTcp, Action: action, DstPort: dst}
+	}
+
+	policies := []datacenter.Policy{
+		{
+			Enabled:             true,
+			Label:               "first",
+			SrcApplicationPoint: pointer.To("vn:a"),
+			DstApplicationPoint: pointer.To("vn:b"),
+			Rules: []datacenter.PolicyRule{
+				tcp("web", enum.PolicyRuleActionPermit, datacenter.PortRange{First: 80, Last: 443}),
+				tcp("https", enum.PolicyRuleActionPermit, datacenter.PortRange{First: 443, Last: 443}),         // redundant with web
+				tcp("block_http", enum.PolicyRuleActionDeny, datacenter.PortRange{First: 80, Last: 80}),        // shadowed by web
+				tcp("block_high", enum.PolicyRuleActionDeny, datacenter.PortRange{First: 400, Last: 500}),      // conflicts with web
+				tcp("ssh", enum.PolicyRuleActionPermit, datacenter.PortRange{First: 22, Last: 22}),             // fine
+				{Label: "dns", Protocol: enum.PolicyRuleProtocolUdp, Action: enum.PolicyRuleActionDeny},        // fine
+				{Label: "ping", Protocol: enum.PolicyRuleProtocolIcmp, Action: enum.PolicyRuleActionPermitLog}, // fine
+				{Label: "deny_all", Protocol: enum.PolicyRuleProtocolIp, Action: enum.PolicyRuleActionDenyLog}, // general rule after exceptions: fine
+			},
+		},
+		{
+			Enabled:             true,
+			Label:               "second",
+			SrcApplicationPoint: pointer.To("vn:a"),
+			DstApplicationPoint: pointer.To("vn:b"),
+			Rules: []datacenter.PolicyRule{
+				tcp("late_ssh", enum.PolicyRuleActionDeny, datacenter.PortRange{First: 22, Last: 22}), // shadowed by ssh in first
+			},
+		},
+		{
+			Enabled:             true,
+			Label:               "other_scope",
+			SrcApplicationPoint: pointer.To("vn:b"),
+			DstApplicationPoint: pointer.To("vn:a"),
+			Rules: []datacenter.PolicyRule{
+				tcp("ssh", enum.PolicyRuleActionDeny, datacenter.PortRange{First: 22, Last: 22}), // different direction: fine
+			},
+		},
+		{
+			Label:               "disabled",
+			SrcApplicationPoint: pointer.To("vn:a"),
+			DstApplicationPoint: pointer.To("vn:b"),
+			Rules: []datacenter.PolicyRule{
+				tcp("ignored", enum.PolicyRuleActionDeny, datacenter.PortRange{First: 22, Last: 22}),
+			},
+		},
+	}
+
+	type finding struct {
+		issue            enum.PolicyRuleIssue
+		policy, rule     string
+		byPolicy, byRule string
+	}
+
+	var result []finding
+	for _, f := range datacenter.LintPolicies(policies) {
+		require.NotEmpty(t, f.String())
+		result = append(result, finding{
+			issue:    f.Issue,
+			policy:   f.Rule.Policy.Label,
+			rule:     f.Rule.Rule().Label,
+			byPolicy: f.By.Policy.Label,
+			byRule:   f.By.Rule().Label,
+		})
+	}
+
+	require.Equal(t, []finding{
+		{enum.PolicyRuleIssueRedundant, "first", "https", "first", "web"},
+		{enum.PolicyRuleIssueShadowed, "first", "block_http", "first", "web"},
+		{enum.PolicyRuleIssueConflicting, "first", "block_high", "first", "web"},
+		{enum.PolicyRuleIssueShadowed, "second", "late_ssh", "first", "ssh"},
+	}, result)
+}
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"encoding"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 )
@@ -129,3 +130,70 @@ func (prs PortRanges) validate() error {
 
 	return nil
 }
+
+// Contains returns true when port falls within prs. Empty PortRanges match any port.
+func (prs PortRanges) Contains(port uint16) bool {
+	if len(prs) == 0 {
+		return true
+	}
+	for _, pr := range prs {
+		if pr.First <= port && port <= pr.Last || pr.Last <= port && port <= pr.First {
+			return true
+		}
+	}
+	return false
+}
+
+// Covers returns true when every port matched by other is also matched by prs.
+func (prs PortRanges) Covers(other PortRanges) bool {
+	outer := prs.normalized()
+	for _, pr := range other.normalized() {
+		covered := false
+		for _, o := range outer {
+			if o.First <= pr.First && pr.Last <= o.Last {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// Overlaps returns true when at least one port is matched by both prs and other.
+func (prs PortRanges) Overlaps(other PortRanges) bool {
+	for _, a := range prs.normalized() {
+		for _, b := range other.normalized() {
+			if a.First <= b.Last && b.First <= a.Last {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalized returns a sorted copy of prs with adjacent and overlapping
+// ranges merged. Empty PortRanges (any port) are returned as 1-65535.
+func (prs PortRanges) normalized() PortRanges {
+	if len(prs) == 0 {
+		return PortRanges{{First: 1, Last: math.MaxUint16}}
+	}
+
+	clone := make(PortRanges, len(prs))
+	copy(clone, prs)
+	clone.canonicalize()
+
+	result := PortRanges{clone[0]}
+	for _, pr := range clone[1:] {
+		last := &result[len(result)-1]
+		if uint32(pr.First) <= uint32(last.Last)+1 {
+			last.Last = max(last.Last, pr.Last)
+			continue
+		}
+		result = append(result, pr)
+	}
+
+	return result
+}
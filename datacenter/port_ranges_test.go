@@ -306,3 +306,62 @@ func TestPortRanges_canonicalize(t *testing.T) {
 		})
 	}
 }
+
+func TestPortRanges_Algebra(t *testing.T) {
+	type testCase struct {
+		a, b     datacenter.PortRanges
+		covers   bool
+		overlaps bool
+	}
+
+	testCases := map[string]testCase{
+		"any_any": {
+			covers:   true,
+			overlaps: true,
+		},
+		"any_covers_range": {
+			b:        datacenter.PortRanges{{First: 80, Last: 90}},
+			covers:   true,
+			overlaps: true,
+		},
+		"range_does_not_cover_any": {
+			a:        datacenter.PortRanges{{First: 80, Last: 90}},
+			overlaps: true,
+		},
+		"full_span_covers_any": {
+			a:        datacenter.PortRanges{{First: 1, Last: 1000}, {First: 1001, Last: 65535}},
+			covers:   true,
+			overlaps: true,
+		},
+		"adjacent_ranges_merge": {
+			a:        datacenter.PortRanges{{First: 80, Last: 84}, {First: 85, Last: 90}},
+			b:        datacenter.PortRanges{{First: 82, Last: 88}},
+			covers:   true,
+			overlaps: true,
+		},
+		"partial_overlap": {
+			a:        datacenter.PortRanges{{First: 80, Last: 90}},
+			b:        datacenter.PortRanges{{First: 85, Last: 95}},
+			overlaps: true,
+		},
+		"disjoint": {
+			a: datacenter.PortRanges{{First: 80, Last: 90}},
+			b: datacenter.PortRanges{{First: 443, Last: 443}},
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tCase.covers, tCase.a.Covers(tCase.b))
+			require.Equal(t, tCase.overlaps, tCase.a.Overlaps(tCase.b))
+			require.Equal(t, tCase.overlaps, tCase.b.Overlaps(tCase.a))
+		})
+	}
+
+	prs := datacenter.PortRanges{{First: 20, Last: 21}, {First: 443, Last: 443}}
+	require.True(t, prs.Contains(21))
+	require.True(t, prs.Contains(443))
+	require.False(t, prs.Contains(80))
+	require.True(t, datacenter.PortRanges(nil).Contains(80))
+}
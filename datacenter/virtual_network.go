@@ -36,7 +36,7 @@ type VirtualNetwork struct {
 	Label                     string             `json:"label"`
 	ReservedVLAN              *uint16            `json:"reserved_vlan_id,omitempty"`
 	RTPolicy                  *RTPolicy          `json:"rt_policy"`
-	SVIIPs                    []SVIAddressing    `json:"svi_ips"`
+	SVIIPs                    []SVIAddressing    `json:"svi_ips" yaml:"svi_ips"`
 	SecurityZoneID            string             `json:"security_zone_id,omitempty"`
 	SwitchingZoneID           string             `json:"switching_zone_id,omitempty"`
 	Tags                      []string           `json:"tags"`
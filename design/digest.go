@@ -6,6 +6,7 @@ package design
 
 import (
 	"bytes"
+	"crypto/md5"
 	"encoding/json"
 	"fmt"
 	"hash"
@@ -72,6 +73,27 @@ func mustHashForComparison(a any, h hash.Hash) []byte {
 	return result
 }
 
+// ContentEqual reports whether a and b carry identical content. Metadata (IDs
+// and timestamps) is not considered. Both values must be structs (or struct
+// pointers) of the same type.
+func ContentEqual(a, b any) (bool, error) {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false, fmt.Errorf("cannot compare %T with %T", a, b)
+	}
+
+	aDigest, err := hashForComparison(a, md5.New())
+	if err != nil {
+		return false, err
+	}
+
+	bDigest, err := hashForComparison(b, md5.New())
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(aDigest, bDigest), nil
+}
+
 func orderedMarshalJSON(keys []string, values map[string]json.RawMessage) ([]byte, error) {
 	if len(keys) != len(values) {
 		return nil, fmt.Errorf("orderedMarshalJSON: mismatch — %d keys but %d values", len(keys), len(values))
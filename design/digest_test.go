@@ -213,3 +213,54 @@ func TestOrderedMarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestContentEqual(t *testing.T) {
+	type testCase struct {
+		a      any
+		b      any
+		exp    bool
+		expErr bool
+	}
+
+	testCases := map[string]testCase{
+		"tags_differ_only_by_metadata": {
+			a:   Tag{Label: "label", Description: "description", id: "one"},
+			b:   Tag{Label: "label", Description: "description", id: "two", createdAt: pointer.To(time.Now())},
+			exp: true,
+		},
+		"tags_differ_by_description": {
+			a:   Tag{Label: "label", Description: "one"},
+			b:   Tag{Label: "label", Description: "two"},
+			exp: false,
+		},
+		"rack_types_with_and_without_id": {
+			a:   railCollapsedSmall.Racks[0].RackType,
+			b:   railCollapsedSmall.Racks[0].RackType.Replicate(),
+			exp: true,
+		},
+		"templates_with_and_without_id": {
+			a:   railCollapsedSmall,
+			b:   TemplateRailCollapsed{Label: railCollapsedSmall.Label, Racks: railCollapsedSmall.Racks, DHCPServiceIntent: railCollapsedSmall.DHCPServiceIntent, VirtualNetworkPolicy: railCollapsedSmall.VirtualNetworkPolicy},
+			exp: true,
+		},
+		"mismatched_types": {
+			a:      Tag{},
+			b:      &Tag{},
+			expErr: true,
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := ContentEqual(tCase.a, tCase.b)
+			if tCase.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tCase.exp, result)
+		})
+	}
+}
@@ -1,4 +1,4 @@
-// Copyright (c) Juniper Networks, Inc., 2025-2025.
+// Copyright (c) Juniper Networks, Inc., 2025-2026.
 // All rights reserved.
 // SPDX-License-Identifier: Apache-2.0
 
@@ -22,7 +22,7 @@ var (
 
 type RackTypeAccessSwitch struct {
 	Count         int
-	ESILAGInfo    *RackTypeAccessSwitchESILAGInfo
+	ESILAGInfo    *RackTypeAccessSwitchESILAGInfo `yaml:"esi_lag_info"`
 	Label         string
 	Links         []RackTypeLink
 	LogicalDevice LogicalDevice
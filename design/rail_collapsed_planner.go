@@ -0,0 +1,245 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package design
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/Juniper/apstra-go-sdk/policy"
+	"github.com/Juniper/apstra-go-sdk/speed"
+)
+
+const (
+	railCollapsedDefaultServerLabel = "gpu_server"
+	railCollapsedLeafLabelFmt       = "leaf_%d"
+	railCollapsedLinkLabelFmt       = "link_%d"
+)
+
+// RailCollapsedPlanRequest describes an AI/GPU fabric built with the
+// rail-collapsed design, where NIC N of every GPU server is cabled to rail N.
+type RailCollapsedPlanRequest struct {
+	// Label is used for both the rack type and the template.
+	Label string
+
+	GPUServerCount int
+	NICsPerServer  int
+	NICSpeed       speed.Speed
+
+	// LeafLogicalDevice must offer ports at NICSpeed with the "generic" role.
+	LeafLogicalDevice LogicalDevice
+
+	// ServerLabel is the label of the GPU server generic system. Defaults to
+	// "gpu_server" when empty.
+	ServerLabel string
+
+	// ServerLogicalDevice is optional. When nil, a logical device with
+	// NICsPerServer ports at NICSpeed is generated.
+	ServerLogicalDevice *LogicalDevice
+}
+
+// RailCollapsedPortMapping describes a single GPU server NIC cable.
+// All indexes are 1-based.
+type RailCollapsedPortMapping struct {
+	Server    int
+	NIC       int
+	RailIndex int
+	LeafLabel string
+	LeafPort  int // absolute port index within the leaf logical device
+}
+
+// RailCollapsedPlan is the result of PlanRailCollapsed. GenericSystem and
+// LeafSwitches are also embedded in RackType, and RackType is embedded in
+// Template. They're broken out here for callers who need them individually.
+type RailCollapsedPlan struct {
+	RailCount     int
+	RailsPerLeaf  int
+	LeafSwitches  []RackTypeLeafSwitch
+	GenericSystem RackTypeGenericSystem
+	RackType      RackType
+	Template      TemplateRailCollapsed
+	PortMap       []RailCollapsedPortMapping
+}
+
+// RailLeafLabel returns the label of the leaf switch serving the given
+// (1-based) rail index, or an empty string if the rail is out of range.
+func (p RailCollapsedPlan) RailLeafLabel(railIndex int) string {
+	if railIndex < 1 || railIndex > p.RailCount || p.RailsPerLeaf < 1 {
+		return ""
+	}
+	return fmt.Sprintf(railCollapsedLeafLabelFmt, (railIndex-1)/p.RailsPerLeaf+1)
+}
+
+// PlanRailCollapsed works out rail and leaf counts for a GPU fabric and
+// returns the rack type, generic system and template which implement it
+// along with a rail-to-port mapping table. Rails are packed onto as few
+// leaf switches as possible: when every NIC in the fabric fits on a single
+// leaf, all rails land on that leaf. A single rail must fit on one leaf.
+func PlanRailCollapsed(in RailCollapsedPlanRequest) (*RailCollapsedPlan, error) {
+	if in.GPUServerCount < 1 {
+		return nil, fmt.Errorf("GPU server count must be at least 1, got %d", in.GPUServerCount)
+	}
+	if in.NICsPerServer < 1 {
+		return nil, fmt.Errorf("NICs per server must be at least 1, got %d", in.NICsPerServer)
+	}
+	if in.NICSpeed.BitsPerSecond() == 0 {
+		return nil, fmt.Errorf("NIC speed %q is not valid", in.NICSpeed)
+	}
+
+	leafPorts := in.LeafLogicalDevice.portIndexes(in.NICSpeed, enum.PortRoleGeneric)
+	if len(leafPorts) < in.GPUServerCount {
+		return nil, fmt.Errorf("leaf logical device %q has %d generic ports at %s, rail of %d GPU servers cannot be collapsed onto one leaf",
+			in.LeafLogicalDevice.Label, len(leafPorts), in.NICSpeed, in.GPUServerCount)
+	}
+
+	var serverLD LogicalDevice
+	if in.ServerLogicalDevice == nil {
+		serverLD = railCollapsedServerLogicalDevice(in.NICsPerServer, in.NICSpeed)
+	} else {
+		serverLD = in.ServerLogicalDevice.Replicate()
+		if ports := serverLD.portIndexes(in.NICSpeed, enum.PortRoleLeaf); len(ports) < in.NICsPerServer {
+			return nil, fmt.Errorf("server logical device %q has %d leaf-facing ports at %s, need %d",
+				serverLD.Label, len(ports), in.NICSpeed, in.NICsPerServer)
+		}
+	}
+
+	railsPerLeaf := min(in.NICsPerServer, len(leafPorts)/in.GPUServerCount)
+	leafCount := (in.NICsPerServer + railsPerLeaf - 1) / railsPerLeaf
+	railsPerLeaf = (in.NICsPerServer + leafCount - 1) / leafCount // balance rails across leafs
+
+	result := RailCollapsedPlan{
+		RailCount:    in.NICsPerServer,
+		RailsPerLeaf: railsPerLeaf,
+		LeafSwitches: make([]RackTypeLeafSwitch, leafCount),
+		PortMap:      make([]RailCollapsedPortMapping, 0, in.GPUServerCount*in.NICsPerServer),
+	}
+
+	for i := range result.LeafSwitches {
+		result.LeafSwitches[i] = RackTypeLeafSwitch{
+			Label:         fmt.Sprintf(railCollapsedLeafLabelFmt, i+1),
+			LogicalDevice: in.LeafLogicalDevice.Replicate(),
+			Tags:          []Tag{},
+		}
+	}
+
+	serverLabel := in.ServerLabel
+	if serverLabel == "" {
+		serverLabel = railCollapsedDefaultServerLabel
+	}
+
+	result.GenericSystem = RackTypeGenericSystem{
+		ASNDomain:       pointer.To(enum.FeatureSwitchDisabled),
+		Count:           in.GPUServerCount,
+		Label:           serverLabel,
+		Links:           make([]RackTypeLink, in.NICsPerServer),
+		LogicalDevice:   serverLD,
+		Loopback:        pointer.To(enum.FeatureSwitchDisabled),
+		ManagementLevel: enum.SystemManagementLevelUnmanaged,
+		Tags:            []Tag{},
+	}
+
+	for nic := 1; nic <= in.NICsPerServer; nic++ {
+		leafLabel := result.RailLeafLabel(nic)
+		result.GenericSystem.Links[nic-1] = RackTypeLink{
+			Label:              fmt.Sprintf(railCollapsedLinkLabelFmt, nic),
+			TargetSwitchLabel:  leafLabel,
+			LinkPerSwitchCount: 1,
+			Speed:              in.NICSpeed,
+			AttachmentType:     enum.LinkAttachmentTypeSingle,
+			RailIndex:          pointer.To(nic),
+			Tags:               []Tag{},
+		}
+
+		// rails occupy contiguous blocks of leaf ports, one port per server
+		railOffset := (nic - 1) % railsPerLeaf * in.GPUServerCount
+		for server := 1; server <= in.GPUServerCount; server++ {
+			result.PortMap = append(result.PortMap, RailCollapsedPortMapping{
+				Server:    server,
+				NIC:       nic,
+				RailIndex: nic,
+				LeafLabel: leafLabel,
+				LeafPort:  leafPorts[railOffset+server-1],
+			})
+		}
+	}
+
+	// order the port map the way cabling teams walk a rack: server by server
+	slices.SortStableFunc(result.PortMap, func(a, b RailCollapsedPortMapping) int {
+		if a.Server != b.Server {
+			return a.Server - b.Server
+		}
+		return a.NIC - b.NIC
+	})
+
+	result.RackType = RackType{
+		Label:                    in.Label,
+		FabricConnectivityDesign: enum.FabricConnectivityDesignRailCollapsed,
+		LeafSwitches:             result.LeafSwitches,
+		GenericSystems:           []RackTypeGenericSystem{result.GenericSystem},
+	}
+
+	result.Template = TemplateRailCollapsed{
+		Label:                in.Label,
+		Racks:                []RackTypeWithCount{{Count: 1, RackType: result.RackType}},
+		DHCPServiceIntent:    policy.DHCPServiceIntent{Active: true},
+		VirtualNetworkPolicy: &policy.VirtualNetwork{OverlayControlProtocol: enum.OverlayControlProtocolNone},
+	}
+
+	return &result, nil
+}
+
+// portIndexes returns the absolute (1-based) indexes of ports which run at
+// the given speed and permit the given role. Ports are numbered in panel and
+// port group order.
+func (l LogicalDevice) portIndexes(s speed.Speed, role enum.PortRole) []int {
+	var result []int
+	var idx int
+	for _, panel := range l.Panels {
+		for _, portGroup := range panel.PortGroups {
+			eligible := portGroup.Speed.Equal(s) && slices.Contains(portGroup.Roles, role)
+			for range portGroup.Count {
+				idx++
+				if eligible {
+					result = append(result, idx)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// railCollapsedServerLogicalDevice returns a GPU server logical device with
+// the given number of ports at the given speed.
+func railCollapsedServerLogicalDevice(portCount int, s speed.Speed) LogicalDevice {
+	layout := LogicalDevicePanelLayout{RowCount: 1, ColumnCount: portCount}
+	if portCount > 1 && portCount%2 == 0 {
+		layout = LogicalDevicePanelLayout{RowCount: 2, ColumnCount: portCount / 2}
+	}
+
+	// built-in logical device labels express speed in Gbps without a unit
+	speedLabel := fmt.Sprintf("%dM", s.BitsPerSecond()/1_000_000)
+	if s.BitsPerSecond()%1_000_000_000 == 0 {
+		speedLabel = fmt.Sprintf("%d", s.BitsPerSecond()/1_000_000_000)
+	}
+
+	return LogicalDevice{
+		Label: fmt.Sprintf("AOS-%dx%s-1", portCount, speedLabel),
+		Panels: []LogicalDevicePanel{
+			{
+				PanelLayout: layout,
+				PortGroups: []LogicalDevicePanelPortGroup{
+					{
+						Count: portCount,
+						Speed: s,
+						Roles: LogicalDevicePortRoles{enum.PortRoleLeaf, enum.PortRoleGeneric, enum.PortRolePeer, enum.PortRoleAccess},
+					},
+				},
+				PortIndexing: enum.DesignLogicalDevicePanelPortIndexingTBLR,
+			},
+		},
+	}
+}
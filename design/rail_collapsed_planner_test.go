@@ -0,0 +1,148 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package design
+
+import (
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/speed"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanRailCollapsed(t *testing.T) {
+	leaf128x400 := railCollapsedSmall.Racks[0].RackType.LeafSwitches[0].LogicalDevice
+	leaf32x400 := railCollapsedServerLogicalDevice(32, "400G")
+	leaf32x400.Label = "AOS-32x400-1"
+	leaf32x400.Panels[0].PortGroups[0].Roles = LogicalDevicePortRoles{enum.PortRoleGeneric, enum.PortRoleSpine}
+
+	type testCase struct {
+		request          RailCollapsedPlanRequest
+		expRackType      *RackType
+		expLeafCount     int
+		expRailsPerLeaf  int
+		expRailLeafLabel map[int]string
+		expPortMap       map[int]RailCollapsedPortMapping // keyed by index into PortMap
+		expErr           bool
+	}
+
+	testCases := map[string]testCase{
+		"matches_rail_collapsed_small": {
+			request: RailCollapsedPlanRequest{
+				Label:             "Collapsed 128GPU",
+				GPUServerCount:    16,
+				NICsPerServer:     8,
+				NICSpeed:          "400G",
+				LeafLogicalDevice: leaf128x400,
+				ServerLabel:       "server",
+			},
+			expRackType:      &railCollapsedSmall.Racks[0].RackType,
+			expLeafCount:     1,
+			expRailsPerLeaf:  8,
+			expRailLeafLabel: map[int]string{1: "leaf_1", 8: "leaf_1", 9: ""},
+			expPortMap: map[int]RailCollapsedPortMapping{
+				0:   {Server: 1, NIC: 1, RailIndex: 1, LeafLabel: "leaf_1", LeafPort: 1},
+				1:   {Server: 1, NIC: 2, RailIndex: 2, LeafLabel: "leaf_1", LeafPort: 17},
+				8:   {Server: 2, NIC: 1, RailIndex: 1, LeafLabel: "leaf_1", LeafPort: 2},
+				127: {Server: 16, NIC: 8, RailIndex: 8, LeafLabel: "leaf_1", LeafPort: 128},
+			},
+		},
+		"rails_spread_across_leafs": {
+			request: RailCollapsedPlanRequest{
+				Label:             "spread",
+				GPUServerCount:    12,
+				NICsPerServer:     8,
+				NICSpeed:          "400G",
+				LeafLogicalDevice: leaf32x400,
+			},
+			expLeafCount:     4,
+			expRailsPerLeaf:  2,
+			expRailLeafLabel: map[int]string{1: "leaf_1", 2: "leaf_1", 3: "leaf_2", 8: "leaf_4"},
+			expPortMap: map[int]RailCollapsedPortMapping{
+				0:  {Server: 1, NIC: 1, RailIndex: 1, LeafLabel: "leaf_1", LeafPort: 1},
+				1:  {Server: 1, NIC: 2, RailIndex: 2, LeafLabel: "leaf_1", LeafPort: 13},
+				2:  {Server: 1, NIC: 3, RailIndex: 3, LeafLabel: "leaf_2", LeafPort: 1},
+				95: {Server: 12, NIC: 8, RailIndex: 8, LeafLabel: "leaf_4", LeafPort: 24},
+			},
+		},
+		"rail_too_wide_for_leaf": {
+			request: RailCollapsedPlanRequest{
+				GPUServerCount:    33,
+				NICsPerServer:     8,
+				NICSpeed:          "400G",
+				LeafLogicalDevice: leaf32x400,
+			},
+			expErr: true,
+		},
+		"wrong_speed": {
+			request: RailCollapsedPlanRequest{
+				GPUServerCount:    4,
+				NICsPerServer:     8,
+				NICSpeed:          "200G",
+				LeafLogicalDevice: leaf32x400,
+			},
+			expErr: true,
+		},
+		"undersized_server_logical_device": {
+			request: RailCollapsedPlanRequest{
+				GPUServerCount:      4,
+				NICsPerServer:       8,
+				NICSpeed:            "400G",
+				LeafLogicalDevice:   leaf32x400,
+				ServerLogicalDevice: &leaf32x400, // no leaf-facing ports
+			},
+			expErr: true,
+		},
+		"no_servers": {
+			request: RailCollapsedPlanRequest{
+				NICsPerServer:     8,
+				NICSpeed:          "400G",
+				LeafLogicalDevice: leaf32x400,
+			},
+			expErr: true,
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			t.Parallel()
+
+			plan, err := PlanRailCollapsed(tCase.request)
+			if tCase.expErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Equal(t, tCase.request.NICsPerServer, plan.RailCount)
+			require.Equal(t, tCase.expRailsPerLeaf, plan.RailsPerLeaf)
+			require.Len(t, plan.LeafSwitches, tCase.expLeafCount)
+			require.Len(t, plan.PortMap, tCase.request.GPUServerCount*tCase.request.NICsPerServer)
+			for railIndex, expLabel := range tCase.expRailLeafLabel {
+				require.Equal(t, expLabel, plan.RailLeafLabel(railIndex))
+			}
+			for i, exp := range tCase.expPortMap {
+				require.Equal(t, exp, plan.PortMap[i])
+			}
+
+			// every leaf port is used at most once
+			used := make(map[RailCollapsedPortMapping]struct{}, len(plan.PortMap))
+			for _, m := range plan.PortMap {
+				key := RailCollapsedPortMapping{LeafLabel: m.LeafLabel, LeafPort: m.LeafPort}
+				require.NotContains(t, used, key)
+				used[key] = struct{}{}
+			}
+
+			require.Equal(t, enum.FabricConnectivityDesignRailCollapsed, plan.RackType.FabricConnectivityDesign)
+			require.Len(t, plan.Template.Racks, 1)
+			require.Equal(t, plan.RackType, plan.Template.Racks[0].RackType)
+			require.True(t, plan.GenericSystem.Links[0].Speed.Equal(speed.Speed("400G")))
+
+			if tCase.expRackType != nil {
+				require.Equal(t, tCase.expRackType.Replicate(), plan.RackType.Replicate())
+			}
+		})
+	}
+}
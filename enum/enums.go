@@ -90,6 +90,22 @@ var (
 	DesignLogicalDevicePanelPortIndexingTBLR = DesignLogicalDevicePanelPortIndexing{Value: "T-B, L-R"}
 )
 
+type DeviceOsImageChecksumType oenum.Member[string]
+
+var (
+	DeviceOsImageChecksumTypeMd5    = DeviceOsImageChecksumType{Value: "md5"}
+	DeviceOsImageChecksumTypeSha512 = DeviceOsImageChecksumType{Value: "sha512"}
+)
+
+type DeviceOsPlatform oenum.Member[string]
+
+var (
+	DeviceOsPlatformEos   = DeviceOsPlatform{Value: "eos"}
+	DeviceOsPlatformJunos = DeviceOsPlatform{Value: "junos"}
+	DeviceOsPlatformNxos  = DeviceOsPlatform{Value: "nxos"}
+	DeviceOsPlatformSonic = DeviceOsPlatform{Value: "sonic"}
+)
+
 type DeviceProfileType oenum.Member[string]
 
 var (
@@ -112,6 +128,14 @@ var (
 	EndpointPolicyStatusReady      = EndpointPolicyStatus{Value: "ready"}
 )
 
+type EnsureAction oenum.Member[string]
+
+var (
+	EnsureActionCreated = EnsureAction{Value: "created"}
+	EnsureActionNone    = EnsureAction{Value: "none"}
+	EnsureActionUpdated = EnsureAction{Value: "updated"}
+)
+
 type FFResourceType oenum.Member[string]
 
 var (
@@ -244,6 +268,16 @@ var (
 	InterfaceTypeUnicastVtep       = InterfaceType{Value: "unicast_vtep"}
 )
 
+type InventoryIssue oenum.Member[string]
+
+var (
+	InventoryIssueAgentState        = InventoryIssue{Value: "agent_state"}
+	InventoryIssueHostnameMismatch  = InventoryIssue{Value: "hostname_mismatch"}
+	InventoryIssueMissing           = InventoryIssue{Value: "missing"}
+	InventoryIssueOsVersionMismatch = InventoryIssue{Value: "os_version_mismatch"}
+	InventoryIssueUnexpected        = InventoryIssue{Value: "unexpected"}
+)
+
 type JunosEVPNIRBMode oenum.Member[string]
 
 var (
@@ -373,6 +407,26 @@ var (
 	NodeRoleSuperspine    = NodeRole{Value: "superspine"}
 )
 
+type OnboardingStep oenum.Member[string]
+
+var (
+	OnboardingStepCreateAgent       = OnboardingStep{Value: "create_agent"}
+	OnboardingStepInstallAgent      = OnboardingStep{Value: "install_agent"}
+	OnboardingStepAwaitConnection   = OnboardingStep{Value: "await_connection"}
+	OnboardingStepAcknowledgeSystem = OnboardingStep{Value: "acknowledge_system"}
+	OnboardingStepAssignSystem      = OnboardingStep{Value: "assign_system"}
+	OnboardingStepSetDeployMode     = OnboardingStep{Value: "set_deploy_mode"}
+)
+
+type OsUpgradeStatus oenum.Member[string]
+
+var (
+	OsUpgradeStatusFailed       = OsUpgradeStatus{Value: "failed"}
+	OsUpgradeStatusNotAttempted = OsUpgradeStatus{Value: "not_attempted"}
+	OsUpgradeStatusSkipped      = OsUpgradeStatus{Value: "skipped"}
+	OsUpgradeStatusSuccess      = OsUpgradeStatus{Value: "success"}
+)
+
 type OverlayControlProtocol oenum.Member[string]
 
 var (
@@ -409,6 +463,14 @@ var (
 	PolicyRuleActionPermitLog = PolicyRuleAction{Value: "permit_log"}
 )
 
+type PolicyRuleIssue oenum.Member[string]
+
+var (
+	PolicyRuleIssueConflicting = PolicyRuleIssue{Value: "conflicting"}
+	PolicyRuleIssueRedundant   = PolicyRuleIssue{Value: "redundant"}
+	PolicyRuleIssueShadowed    = PolicyRuleIssue{Value: "shadowed"}
+)
+
 type PolicyRuleProtocol oenum.Member[string]
 
 var (
@@ -431,6 +493,14 @@ var (
 	PortRoleUnused     = PortRole{Value: "unused"}
 )
 
+type PrefixFilterIssue oenum.Member[string]
+
+var (
+	PrefixFilterIssueInvalid     = PrefixFilterIssue{Value: "invalid"}
+	PrefixFilterIssueOverlapping = PrefixFilterIssue{Value: "overlapping"}
+	PrefixFilterIssueUnreachable = PrefixFilterIssue{Value: "unreachable"}
+)
+
 type RedundancyGroupType oenum.Member[string]
 
 var (
@@ -478,6 +548,27 @@ var (
 	ResourcePoolTypeVni  = ResourcePoolType{Value: "vni"}
 )
 
+type RouteSource oenum.Member[string]
+
+var (
+	RouteSourceExternal            = RouteSource{Value: "external"}
+	RouteSourceL2EdgeSubnet        = RouteSource{Value: "l2edge_subnet"}
+	RouteSourceL3EdgeServerLink    = RouteSource{Value: "l3edge_server_link"}
+	RouteSourceLoopback            = RouteSource{Value: "loopback"}
+	RouteSourceSpineLeafLink       = RouteSource{Value: "spine_leaf_link"}
+	RouteSourceSpineSuperspineLink = RouteSource{Value: "spine_superspine_link"}
+	RouteSourceStaticRoute         = RouteSource{Value: "static_route"}
+)
+
+type RouteTargetIssue oenum.Member[string]
+
+var (
+	RouteTargetIssueAsymmetricImport = RouteTargetIssue{Value: "asymmetric_import"}
+	RouteTargetIssueOrphanExport     = RouteTargetIssue{Value: "orphan_export"}
+	RouteTargetIssueOrphanImport     = RouteTargetIssue{Value: "orphan_import"}
+	RouteTargetIssueUnintendedLeak   = RouteTargetIssue{Value: "unintended_leak"}
+)
+
 type RoutingZoneConstraintMode oenum.Member[string]
 
 var (
@@ -528,6 +619,14 @@ var (
 	StorageSchemaPathXcvr              = StorageSchemaPath{Value: "aos.sdk.telemetry.schemas.xcvr"}
 )
 
+type SwitchingZoneIssue oenum.Member[string]
+
+var (
+	SwitchingZoneIssueReservedVlanConflict = SwitchingZoneIssue{Value: "reserved_vlan_conflict"}
+	SwitchingZoneIssueVlanConflict         = SwitchingZoneIssue{Value: "vlan_conflict"}
+	SwitchingZoneIssueVniConflict          = SwitchingZoneIssue{Value: "vni_conflict"}
+)
+
 type SwitchingZoneMACVRFServiceType oenum.Member[string]
 
 var (
@@ -582,6 +681,13 @@ var (
 	TemplateTypeRailCollapsed = TemplateType{Value: "rail_collapsed"}
 )
 
+type VirtualInfraType oenum.Member[string]
+
+var (
+	VirtualInfraTypeNsxt    = VirtualInfraType{Value: "nsxt"}
+	VirtualInfraTypeVcenter = VirtualInfraType{Value: "vcenter"}
+)
+
 type VnType oenum.Member[string]
 
 var (
@@ -22,6 +22,10 @@ func (o ASNAllocationScheme) String() string {
 	return o.Value
 }
 
+func (o ASNAllocationScheme) Values() []string {
+	return ASNAllocationSchemes.Values()
+}
+
 func (o *ASNAllocationScheme) FromString(s string) error {
 	if ASNAllocationSchemes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -53,6 +57,10 @@ func (o AddressingScheme) String() string {
 	return o.Value
 }
 
+func (o AddressingScheme) Values() []string {
+	return AddressingSchemes.Values()
+}
+
 func (o *AddressingScheme) FromString(s string) error {
 	if AddressingSchemes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -84,6 +92,10 @@ func (o AntiAffinityMode) String() string {
 	return o.Value
 }
 
+func (o AntiAffinityMode) Values() []string {
+	return AntiAffinityModes.Values()
+}
+
 func (o *AntiAffinityMode) FromString(s string) error {
 	if AntiAffinityModes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -115,6 +127,10 @@ func (o ApiFeature) String() string {
 	return o.Value
 }
 
+func (o ApiFeature) Values() []string {
+	return ApiFeatures.Values()
+}
+
 func (o *ApiFeature) FromString(s string) error {
 	if ApiFeatures.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -146,6 +162,10 @@ func (o ConfigletSection) String() string {
 	return o.Value
 }
 
+func (o ConfigletSection) Values() []string {
+	return ConfigletSections.Values()
+}
+
 func (o *ConfigletSection) FromString(s string) error {
 	if ConfigletSections.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -177,6 +197,10 @@ func (o ConfigletStyle) String() string {
 	return o.Value
 }
 
+func (o ConfigletStyle) Values() []string {
+	return ConfigletStyles.Values()
+}
+
 func (o *ConfigletStyle) FromString(s string) error {
 	if ConfigletStyles.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -208,6 +232,10 @@ func (o DeployMode) String() string {
 	return o.Value
 }
 
+func (o DeployMode) Values() []string {
+	return DeployModes.Values()
+}
+
 func (o *DeployMode) FromString(s string) error {
 	if DeployModes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -239,6 +267,10 @@ func (o DesignLogicalDevicePanelPortIndexing) String() string {
 	return o.Value
 }
 
+func (o DesignLogicalDevicePanelPortIndexing) Values() []string {
+	return DesignLogicalDevicePanelPortIndexings.Values()
+}
+
 func (o *DesignLogicalDevicePanelPortIndexing) FromString(s string) error {
 	if DesignLogicalDevicePanelPortIndexings.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -260,6 +292,76 @@ func (o *DesignLogicalDevicePanelPortIndexing) UnmarshalJSON(bytes []byte) error
 	return o.FromString(s)
 }
 
+var (
+	_ enum             = (*DeviceOsImageChecksumType)(nil)
+	_ json.Marshaler   = (*DeviceOsImageChecksumType)(nil)
+	_ json.Unmarshaler = (*DeviceOsImageChecksumType)(nil)
+)
+
+func (o DeviceOsImageChecksumType) String() string {
+	return o.Value
+}
+
+func (o DeviceOsImageChecksumType) Values() []string {
+	return DeviceOsImageChecksumTypes.Values()
+}
+
+func (o *DeviceOsImageChecksumType) FromString(s string) error {
+	if DeviceOsImageChecksumTypes.Parse(s) == nil {
+		return newEnumParseError(o, s)
+	}
+	o.Value = s
+	return nil
+}
+
+func (o DeviceOsImageChecksumType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o *DeviceOsImageChecksumType) UnmarshalJSON(bytes []byte) error {
+	var s string
+	err := json.Unmarshal(bytes, &s)
+	if err != nil {
+		return err
+	}
+	return o.FromString(s)
+}
+
+var (
+	_ enum             = (*DeviceOsPlatform)(nil)
+	_ json.Marshaler   = (*DeviceOsPlatform)(nil)
+	_ json.Unmarshaler = (*DeviceOsPlatform)(nil)
+)
+
+func (o DeviceOsPlatform) String() string {
+	return o.Value
+}
+
+func (o DeviceOsPlatform) Values() []string {
+	return DeviceOsPlatforms.Values()
+}
+
+func (o *DeviceOsPlatform) FromString(s string) error {
+	if DeviceOsPlatforms.Parse(s) == nil {
+		return newEnumParseError(o, s)
+	}
+	o.Value = s
+	return nil
+}
+
+func (o DeviceOsPlatform) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o *DeviceOsPlatform) UnmarshalJSON(bytes []byte) error {
+	var s string
+	err := json.Unmarshal(bytes, &s)
+	if err != nil {
+		return err
+	}
+	return o.FromString(s)
+}
+
 var (
 	_ enum             = (*DeviceProfileType)(nil)
 	_ json.Marshaler   = (*DeviceProfileType)(nil)
@@ -270,6 +372,10 @@ func (o DeviceProfileType) String() string {
 	return o.Value
 }
 
+func (o DeviceProfileType) Values() []string {
+	return DeviceProfileTypes.Values()
+}
+
 func (o *DeviceProfileType) FromString(s string) error {
 	if DeviceProfileTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -301,6 +407,10 @@ func (o DhcpServiceMode) String() string {
 	return o.Value
 }
 
+func (o DhcpServiceMode) Values() []string {
+	return DhcpServiceModes.Values()
+}
+
 func (o *DhcpServiceMode) FromString(s string) error {
 	if DhcpServiceModes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -332,6 +442,10 @@ func (o EndpointPolicyStatus) String() string {
 	return o.Value
 }
 
+func (o EndpointPolicyStatus) Values() []string {
+	return EndpointPolicyStatuses.Values()
+}
+
 func (o *EndpointPolicyStatus) FromString(s string) error {
 	if EndpointPolicyStatuses.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -353,6 +467,41 @@ func (o *EndpointPolicyStatus) UnmarshalJSON(bytes []byte) error {
 	return o.FromString(s)
 }
 
+var (
+	_ enum             = (*EnsureAction)(nil)
+	_ json.Marshaler   = (*EnsureAction)(nil)
+	_ json.Unmarshaler = (*EnsureAction)(nil)
+)
+
+func (o EnsureAction) String() string {
+	return o.Value
+}
+
+func (o EnsureAction) Values() []string {
+	return EnsureActions.Values()
+}
+
+func (o *EnsureAction) FromString(s string) error {
+	if EnsureActions.Parse(s) == nil {
+		return newEnumParseError(o, s)
+	}
+	o.Value = s
+	return nil
+}
+
+func (o EnsureAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o *EnsureAction) UnmarshalJSON(bytes []byte) error {
+	var s string
+	err := json.Unmarshal(bytes, &s)
+	if err != nil {
+		return err
+	}
+	return o.FromString(s)
+}
+
 var (
 	_ enum             = (*FFResourceType)(nil)
 	_ json.Marshaler   = (*FFResourceType)(nil)
@@ -363,6 +512,10 @@ func (o FFResourceType) String() string {
 	return o.Value
 }
 
+func (o FFResourceType) Values() []string {
+	return FFResourceTypes.Values()
+}
+
 func (o *FFResourceType) FromString(s string) error {
 	if FFResourceTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -394,6 +547,10 @@ func (o FabricConnectivityDesign) String() string {
 	return o.Value
 }
 
+func (o FabricConnectivityDesign) Values() []string {
+	return FabricConnectivityDesigns.Values()
+}
+
 func (o *FabricConnectivityDesign) FromString(s string) error {
 	if FabricConnectivityDesigns.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -425,6 +582,10 @@ func (o FeatureSwitch) String() string {
 	return o.Value
 }
 
+func (o FeatureSwitch) Values() []string {
+	return FeatureSwitches.Values()
+}
+
 func (o *FeatureSwitch) FromString(s string) error {
 	if FeatureSwitches.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -456,6 +617,10 @@ func (o IPv4SVIMode) String() string {
 	return o.Value
 }
 
+func (o IPv4SVIMode) Values() []string {
+	return IPv4SVIModes.Values()
+}
+
 func (o *IPv4SVIMode) FromString(s string) error {
 	if IPv4SVIModes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -487,6 +652,10 @@ func (o IPv6SVIMode) String() string {
 	return o.Value
 }
 
+func (o IPv6SVIMode) Values() []string {
+	return IPv6SVIModes.Values()
+}
+
 func (o *IPv6SVIMode) FromString(s string) error {
 	if IPv6SVIModes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -518,6 +687,10 @@ func (o IbaWidgetAggregationType) String() string {
 	return o.Value
 }
 
+func (o IbaWidgetAggregationType) Values() []string {
+	return IbaWidgetAggregationTypes.Values()
+}
+
 func (o *IbaWidgetAggregationType) FromString(s string) error {
 	if IbaWidgetAggregationTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -549,6 +722,10 @@ func (o IbaWidgetCombineGraph) String() string {
 	return o.Value
 }
 
+func (o IbaWidgetCombineGraph) Values() []string {
+	return IbaWidgetCombineGraphs.Values()
+}
+
 func (o *IbaWidgetCombineGraph) FromString(s string) error {
 	if IbaWidgetCombineGraphs.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -580,6 +757,10 @@ func (o IbaWidgetDataSource) String() string {
 	return o.Value
 }
 
+func (o IbaWidgetDataSource) Values() []string {
+	return IbaWidgetDataSources.Values()
+}
+
 func (o *IbaWidgetDataSource) FromString(s string) error {
 	if IbaWidgetDataSources.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -611,6 +792,10 @@ func (o IbaWidgetType) String() string {
 	return o.Value
 }
 
+func (o IbaWidgetType) Values() []string {
+	return IbaWidgetTypes.Values()
+}
+
 func (o *IbaWidgetType) FromString(s string) error {
 	if IbaWidgetTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -642,6 +827,10 @@ func (o InterfaceMapInterfaceState) String() string {
 	return o.Value
 }
 
+func (o InterfaceMapInterfaceState) Values() []string {
+	return InterfaceMapInterfaceStates.Values()
+}
+
 func (o *InterfaceMapInterfaceState) FromString(s string) error {
 	if InterfaceMapInterfaceStates.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -673,6 +862,10 @@ func (o InterfaceNumberingIpv4Type) String() string {
 	return o.Value
 }
 
+func (o InterfaceNumberingIpv4Type) Values() []string {
+	return InterfaceNumberingIpv4Types.Values()
+}
+
 func (o *InterfaceNumberingIpv4Type) FromString(s string) error {
 	if InterfaceNumberingIpv4Types.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -704,6 +897,10 @@ func (o InterfaceNumberingIpv6Type) String() string {
 	return o.Value
 }
 
+func (o InterfaceNumberingIpv6Type) Values() []string {
+	return InterfaceNumberingIpv6Types.Values()
+}
+
 func (o *InterfaceNumberingIpv6Type) FromString(s string) error {
 	if InterfaceNumberingIpv6Types.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -735,6 +932,10 @@ func (o InterfaceOperationState) String() string {
 	return o.Value
 }
 
+func (o InterfaceOperationState) Values() []string {
+	return InterfaceOperationStates.Values()
+}
+
 func (o *InterfaceOperationState) FromString(s string) error {
 	if InterfaceOperationStates.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -766,6 +967,10 @@ func (o InterfaceState) String() string {
 	return o.Value
 }
 
+func (o InterfaceState) Values() []string {
+	return InterfaceStates.Values()
+}
+
 func (o *InterfaceState) FromString(s string) error {
 	if InterfaceStates.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -797,6 +1002,10 @@ func (o InterfaceType) String() string {
 	return o.Value
 }
 
+func (o InterfaceType) Values() []string {
+	return InterfaceTypes.Values()
+}
+
 func (o *InterfaceType) FromString(s string) error {
 	if InterfaceTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -818,6 +1027,41 @@ func (o *InterfaceType) UnmarshalJSON(bytes []byte) error {
 	return o.FromString(s)
 }
 
+var (
+	_ enum             = (*InventoryIssue)(nil)
+	_ json.Marshaler   = (*InventoryIssue)(nil)
+	_ json.Unmarshaler = (*InventoryIssue)(nil)
+)
+
+func (o InventoryIssue) String() string {
+	return o.Value
+}
+
+func (o InventoryIssue) Values() []string {
+	return InventoryIssues.Values()
+}
+
+func (o *InventoryIssue) FromString(s string) error {
+	if InventoryIssues.Parse(s) == nil {
+		return newEnumParseError(o, s)
+	}
+	o.Value = s
+	return nil
+}
+
+func (o InventoryIssue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o *InventoryIssue) UnmarshalJSON(bytes []byte) error {
+	var s string
+	err := json.Unmarshal(bytes, &s)
+	if err != nil {
+		return err
+	}
+	return o.FromString(s)
+}
+
 var (
 	_ enum             = (*JunosEVPNIRBMode)(nil)
 	_ json.Marshaler   = (*JunosEVPNIRBMode)(nil)
@@ -828,6 +1072,10 @@ func (o JunosEVPNIRBMode) String() string {
 	return o.Value
 }
 
+func (o JunosEVPNIRBMode) Values() []string {
+	return JunosEVPNIRBModes.Values()
+}
+
 func (o *JunosEVPNIRBMode) FromString(s string) error {
 	if JunosEVPNIRBModes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -859,6 +1107,10 @@ func (o LAGMode) String() string {
 	return o.Value
 }
 
+func (o LAGMode) Values() []string {
+	return LAGModes.Values()
+}
+
 func (o *LAGMode) FromString(s string) error {
 	if LAGModes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -890,6 +1142,10 @@ func (o LeafRedundancyProtocol) String() string {
 	return o.Value
 }
 
+func (o LeafRedundancyProtocol) Values() []string {
+	return LeafRedundancyProtocols.Values()
+}
+
 func (o *LeafRedundancyProtocol) FromString(s string) error {
 	if LeafRedundancyProtocols.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -921,6 +1177,10 @@ func (o LinkAttachmentType) String() string {
 	return o.Value
 }
 
+func (o LinkAttachmentType) Values() []string {
+	return LinkAttachmentTypes.Values()
+}
+
 func (o *LinkAttachmentType) FromString(s string) error {
 	if LinkAttachmentTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -952,6 +1212,10 @@ func (o LinkRole) String() string {
 	return o.Value
 }
 
+func (o LinkRole) Values() []string {
+	return LinkRoles.Values()
+}
+
 func (o *LinkRole) FromString(s string) error {
 	if LinkRoles.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -983,6 +1247,10 @@ func (o LinkSpeed) String() string {
 	return o.Value
 }
 
+func (o LinkSpeed) Values() []string {
+	return LinkSpeeds.Values()
+}
+
 func (o *LinkSpeed) FromString(s string) error {
 	if LinkSpeeds.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1014,6 +1282,10 @@ func (o LinkSwitchPeer) String() string {
 	return o.Value
 }
 
+func (o LinkSwitchPeer) Values() []string {
+	return LinkSwitchPeers.Values()
+}
+
 func (o *LinkSwitchPeer) FromString(s string) error {
 	if LinkSwitchPeers.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1045,6 +1317,10 @@ func (o LinkType) String() string {
 	return o.Value
 }
 
+func (o LinkType) Values() []string {
+	return LinkTypes.Values()
+}
+
 func (o *LinkType) FromString(s string) error {
 	if LinkTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1076,6 +1352,10 @@ func (o LockStatus) String() string {
 	return o.Value
 }
 
+func (o LockStatus) Values() []string {
+	return LockStatuses.Values()
+}
+
 func (o *LockStatus) FromString(s string) error {
 	if LockStatuses.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1107,6 +1387,10 @@ func (o LockType) String() string {
 	return o.Value
 }
 
+func (o LockType) Values() []string {
+	return LockTypes.Values()
+}
+
 func (o *LockType) FromString(s string) error {
 	if LockTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1138,6 +1422,10 @@ func (o NodeRole) String() string {
 	return o.Value
 }
 
+func (o NodeRole) Values() []string {
+	return NodeRoles.Values()
+}
+
 func (o *NodeRole) FromString(s string) error {
 	if NodeRoles.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1159,6 +1447,76 @@ func (o *NodeRole) UnmarshalJSON(bytes []byte) error {
 	return o.FromString(s)
 }
 
+var (
+	_ enum             = (*OnboardingStep)(nil)
+	_ json.Marshaler   = (*OnboardingStep)(nil)
+	_ json.Unmarshaler = (*OnboardingStep)(nil)
+)
+
+func (o OnboardingStep) String() string {
+	return o.Value
+}
+
+func (o OnboardingStep) Values() []string {
+	return OnboardingSteps.Values()
+}
+
+func (o *OnboardingStep) FromString(s string) error {
+	if OnboardingSteps.Parse(s) == nil {
+		return newEnumParseError(o, s)
+	}
+	o.Value = s
+	return nil
+}
+
+func (o OnboardingStep) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o *OnboardingStep) UnmarshalJSON(bytes []byte) error {
+	var s string
+	err := json.Unmarshal(bytes, &s)
+	if err != nil {
+		return err
+	}
+	return o.FromString(s)
+}
+
+var (
+	_ enum             = (*OsUpgradeStatus)(nil)
+	_ json.Marshaler   = (*OsUpgradeStatus)(nil)
+	_ json.Unmarshaler = (*OsUpgradeStatus)(nil)
+)
+
+func (o OsUpgradeStatus) String() string {
+	return o.Value
+}
+
+func (o OsUpgradeStatus) Values() []string {
+	return OsUpgradeStatuss.Values()
+}
+
+func (o *OsUpgradeStatus) FromString(s string) error {
+	if OsUpgradeStatuss.Parse(s) == nil {
+		return newEnumParseError(o, s)
+	}
+	o.Value = s
+	return nil
+}
+
+func (o OsUpgradeStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o *OsUpgradeStatus) UnmarshalJSON(bytes []byte) error {
+	var s string
+	err := json.Unmarshal(bytes, &s)
+	if err != nil {
+		return err
+	}
+	return o.FromString(s)
+}
+
 var (
 	_ enum             = (*OverlayControlProtocol)(nil)
 	_ json.Marshaler   = (*OverlayControlProtocol)(nil)
@@ -1169,6 +1527,10 @@ func (o OverlayControlProtocol) String() string {
 	return o.Value
 }
 
+func (o OverlayControlProtocol) Values() []string {
+	return OverlayControlProtocols.Values()
+}
+
 func (o *OverlayControlProtocol) FromString(s string) error {
 	if OverlayControlProtocols.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1200,6 +1562,10 @@ func (o PolicyAddressFamily) String() string {
 	return o.Value
 }
 
+func (o PolicyAddressFamily) Values() []string {
+	return PolicyAddressFamilies.Values()
+}
+
 func (o *PolicyAddressFamily) FromString(s string) error {
 	if PolicyAddressFamilies.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1231,6 +1597,10 @@ func (o PolicyApplicationPointType) String() string {
 	return o.Value
 }
 
+func (o PolicyApplicationPointType) Values() []string {
+	return PolicyApplicationPointTypes.Values()
+}
+
 func (o *PolicyApplicationPointType) FromString(s string) error {
 	if PolicyApplicationPointTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1262,6 +1632,10 @@ func (o PolicyRuleAction) String() string {
 	return o.Value
 }
 
+func (o PolicyRuleAction) Values() []string {
+	return PolicyRuleActions.Values()
+}
+
 func (o *PolicyRuleAction) FromString(s string) error {
 	if PolicyRuleActions.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1283,6 +1657,41 @@ func (o *PolicyRuleAction) UnmarshalJSON(bytes []byte) error {
 	return o.FromString(s)
 }
 
+var (
+	_ enum             = (*PolicyRuleIssue)(nil)
+	_ json.Marshaler   = (*PolicyRuleIssue)(nil)
+	_ json.Unmarshaler = (*PolicyRuleIssue)(nil)
+)
+
+func (o PolicyRuleIssue) String() string {
+	return o.Value
+}
+
+func (o PolicyRuleIssue) Values() []string {
+	return PolicyRuleIssues.Values()
+}
+
+func (o *PolicyRuleIssue) FromString(s string) error {
+	if PolicyRuleIssues.Parse(s) == nil {
+		return newEnumParseError(o, s)
+	}
+	o.Value = s
+	return nil
+}
+
+func (o PolicyRuleIssue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o *PolicyRuleIssue) UnmarshalJSON(bytes []byte) error {
+	var s string
+	err := json.Unmarshal(bytes, &s)
+	if err != nil {
+		return err
+	}
+	return o.FromString(s)
+}
+
 var (
 	_ enum             = (*PolicyRuleProtocol)(nil)
 	_ json.Marshaler   = (*PolicyRuleProtocol)(nil)
@@ -1293,6 +1702,10 @@ func (o PolicyRuleProtocol) String() string {
 	return o.Value
 }
 
+func (o PolicyRuleProtocol) Values() []string {
+	return PolicyRuleProtocols.Values()
+}
+
 func (o *PolicyRuleProtocol) FromString(s string) error {
 	if PolicyRuleProtocols.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1324,6 +1737,10 @@ func (o PortRole) String() string {
 	return o.Value
 }
 
+func (o PortRole) Values() []string {
+	return PortRoles.Values()
+}
+
 func (o *PortRole) FromString(s string) error {
 	if PortRoles.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1345,6 +1762,41 @@ func (o *PortRole) UnmarshalJSON(bytes []byte) error {
 	return o.FromString(s)
 }
 
+var (
+	_ enum             = (*PrefixFilterIssue)(nil)
+	_ json.Marshaler   = (*PrefixFilterIssue)(nil)
+	_ json.Unmarshaler = (*PrefixFilterIssue)(nil)
+)
+
+func (o PrefixFilterIssue) String() string {
+	return o.Value
+}
+
+func (o PrefixFilterIssue) Values() []string {
+	return PrefixFilterIssues.Values()
+}
+
+func (o *PrefixFilterIssue) FromString(s string) error {
+	if PrefixFilterIssues.Parse(s) == nil {
+		return newEnumParseError(o, s)
+	}
+	o.Value = s
+	return nil
+}
+
+func (o PrefixFilterIssue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o *PrefixFilterIssue) UnmarshalJSON(bytes []byte) error {
+	var s string
+	err := json.Unmarshal(bytes, &s)
+	if err != nil {
+		return err
+	}
+	return o.FromString(s)
+}
+
 var (
 	_ enum             = (*RedundancyGroupType)(nil)
 	_ json.Marshaler   = (*RedundancyGroupType)(nil)
@@ -1355,6 +1807,10 @@ func (o RedundancyGroupType) String() string {
 	return o.Value
 }
 
+func (o RedundancyGroupType) Values() []string {
+	return RedundancyGroupTypes.Values()
+}
+
 func (o *RedundancyGroupType) FromString(s string) error {
 	if RedundancyGroupTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1386,6 +1842,10 @@ func (o RefDesign) String() string {
 	return o.Value
 }
 
+func (o RefDesign) Values() []string {
+	return RefDesigns.Values()
+}
+
 func (o *RefDesign) FromString(s string) error {
 	if RefDesigns.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1417,6 +1877,10 @@ func (o RefDesignCapability) String() string {
 	return o.Value
 }
 
+func (o RefDesignCapability) Values() []string {
+	return RefDesignCapabilities.Values()
+}
+
 func (o *RefDesignCapability) FromString(s string) error {
 	if RefDesignCapabilities.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1448,6 +1912,10 @@ func (o RemoteGatewayRouteType) String() string {
 	return o.Value
 }
 
+func (o RemoteGatewayRouteType) Values() []string {
+	return RemoteGatewayRouteTypes.Values()
+}
+
 func (o *RemoteGatewayRouteType) FromString(s string) error {
 	if RemoteGatewayRouteTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1479,6 +1947,10 @@ func (o RenderedConfigType) String() string {
 	return o.Value
 }
 
+func (o RenderedConfigType) Values() []string {
+	return RenderedConfigTypes.Values()
+}
+
 func (o *RenderedConfigType) FromString(s string) error {
 	if RenderedConfigTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1510,6 +1982,10 @@ func (o ResourcePoolType) String() string {
 	return o.Value
 }
 
+func (o ResourcePoolType) Values() []string {
+	return ResourcePoolTypes.Values()
+}
+
 func (o *ResourcePoolType) FromString(s string) error {
 	if ResourcePoolTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1531,6 +2007,76 @@ func (o *ResourcePoolType) UnmarshalJSON(bytes []byte) error {
 	return o.FromString(s)
 }
 
+var (
+	_ enum             = (*RouteSource)(nil)
+	_ json.Marshaler   = (*RouteSource)(nil)
+	_ json.Unmarshaler = (*RouteSource)(nil)
+)
+
+func (o RouteSource) String() string {
+	return o.Value
+}
+
+func (o RouteSource) Values() []string {
+	return RouteSources.Values()
+}
+
+func (o *RouteSource) FromString(s string) error {
+	if RouteSources.Parse(s) == nil {
+		return newEnumParseError(o, s)
+	}
+	o.Value = s
+	return nil
+}
+
+func (o RouteSource) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o *RouteSource) UnmarshalJSON(bytes []byte) error {
+	var s string
+	err := json.Unmarshal(bytes, &s)
+	if err != nil {
+		return err
+	}
+	return o.FromString(s)
+}
+
+var (
+	_ enum             = (*RouteTargetIssue)(nil)
+	_ json.Marshaler   = (*RouteTargetIssue)(nil)
+	_ json.Unmarshaler = (*RouteTargetIssue)(nil)
+)
+
+func (o RouteTargetIssue) String() string {
+	return o.Value
+}
+
+func (o RouteTargetIssue) Values() []string {
+	return RouteTargetIssues.Values()
+}
+
+func (o *RouteTargetIssue) FromString(s string) error {
+	if RouteTargetIssues.Parse(s) == nil {
+		return newEnumParseError(o, s)
+	}
+	o.Value = s
+	return nil
+}
+
+func (o RouteTargetIssue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o *RouteTargetIssue) UnmarshalJSON(bytes []byte) error {
+	var s string
+	err := json.Unmarshal(bytes, &s)
+	if err != nil {
+		return err
+	}
+	return o.FromString(s)
+}
+
 var (
 	_ enum             = (*RoutingZoneConstraintMode)(nil)
 	_ json.Marshaler   = (*RoutingZoneConstraintMode)(nil)
@@ -1541,6 +2087,10 @@ func (o RoutingZoneConstraintMode) String() string {
 	return o.Value
 }
 
+func (o RoutingZoneConstraintMode) Values() []string {
+	return RoutingZoneConstraintModes.Values()
+}
+
 func (o *RoutingZoneConstraintMode) FromString(s string) error {
 	if RoutingZoneConstraintModes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1572,6 +2122,10 @@ func (o SecurityZoneType) String() string {
 	return o.Value
 }
 
+func (o SecurityZoneType) Values() []string {
+	return SecurityZoneTypes.Values()
+}
+
 func (o *SecurityZoneType) FromString(s string) error {
 	if SecurityZoneTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1603,6 +2157,10 @@ func (o SpeedUnit) String() string {
 	return o.Value
 }
 
+func (o SpeedUnit) Values() []string {
+	return SpeedUnits.Values()
+}
+
 func (o *SpeedUnit) FromString(s string) error {
 	if SpeedUnits.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1634,6 +2192,10 @@ func (o StorageSchemaPath) String() string {
 	return o.Value
 }
 
+func (o StorageSchemaPath) Values() []string {
+	return StorageSchemaPaths.Values()
+}
+
 func (o *StorageSchemaPath) FromString(s string) error {
 	if StorageSchemaPaths.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1655,6 +2217,41 @@ func (o *StorageSchemaPath) UnmarshalJSON(bytes []byte) error {
 	return o.FromString(s)
 }
 
+var (
+	_ enum             = (*SwitchingZoneIssue)(nil)
+	_ json.Marshaler   = (*SwitchingZoneIssue)(nil)
+	_ json.Unmarshaler = (*SwitchingZoneIssue)(nil)
+)
+
+func (o SwitchingZoneIssue) String() string {
+	return o.Value
+}
+
+func (o SwitchingZoneIssue) Values() []string {
+	return SwitchingZoneIssues.Values()
+}
+
+func (o *SwitchingZoneIssue) FromString(s string) error {
+	if SwitchingZoneIssues.Parse(s) == nil {
+		return newEnumParseError(o, s)
+	}
+	o.Value = s
+	return nil
+}
+
+func (o SwitchingZoneIssue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o *SwitchingZoneIssue) UnmarshalJSON(bytes []byte) error {
+	var s string
+	err := json.Unmarshal(bytes, &s)
+	if err != nil {
+		return err
+	}
+	return o.FromString(s)
+}
+
 var (
 	_ enum             = (*SwitchingZoneMACVRFServiceType)(nil)
 	_ json.Marshaler   = (*SwitchingZoneMACVRFServiceType)(nil)
@@ -1665,6 +2262,10 @@ func (o SwitchingZoneMACVRFServiceType) String() string {
 	return o.Value
 }
 
+func (o SwitchingZoneMACVRFServiceType) Values() []string {
+	return SwitchingZoneMACVRFServiceTypes.Values()
+}
+
 func (o *SwitchingZoneMACVRFServiceType) FromString(s string) error {
 	if SwitchingZoneMACVRFServiceTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1696,6 +2297,10 @@ func (o SystemManagementLevel) String() string {
 	return o.Value
 }
 
+func (o SystemManagementLevel) Values() []string {
+	return SystemManagementLevels.Values()
+}
+
 func (o *SystemManagementLevel) FromString(s string) error {
 	if SystemManagementLevels.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1727,6 +2332,10 @@ func (o SystemNodeRole) String() string {
 	return o.Value
 }
 
+func (o SystemNodeRole) Values() []string {
+	return SystemNodeRoles.Values()
+}
+
 func (o *SystemNodeRole) FromString(s string) error {
 	if SystemNodeRoles.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1758,6 +2367,10 @@ func (o SystemType) String() string {
 	return o.Value
 }
 
+func (o SystemType) Values() []string {
+	return SystemTypes.Values()
+}
+
 func (o *SystemType) FromString(s string) error {
 	if SystemTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1789,6 +2402,10 @@ func (o TcpStateQualifier) String() string {
 	return o.Value
 }
 
+func (o TcpStateQualifier) Values() []string {
+	return TcpStateQualifiers.Values()
+}
+
 func (o *TcpStateQualifier) FromString(s string) error {
 	if TcpStateQualifiers.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1820,6 +2437,10 @@ func (o TemplateCapability) String() string {
 	return o.Value
 }
 
+func (o TemplateCapability) Values() []string {
+	return TemplateCapabilities.Values()
+}
+
 func (o *TemplateCapability) FromString(s string) error {
 	if TemplateCapabilities.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1851,6 +2472,10 @@ func (o TemplateType) String() string {
 	return o.Value
 }
 
+func (o TemplateType) Values() []string {
+	return TemplateTypes.Values()
+}
+
 func (o *TemplateType) FromString(s string) error {
 	if TemplateTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1872,6 +2497,41 @@ func (o *TemplateType) UnmarshalJSON(bytes []byte) error {
 	return o.FromString(s)
 }
 
+var (
+	_ enum             = (*VirtualInfraType)(nil)
+	_ json.Marshaler   = (*VirtualInfraType)(nil)
+	_ json.Unmarshaler = (*VirtualInfraType)(nil)
+)
+
+func (o VirtualInfraType) String() string {
+	return o.Value
+}
+
+func (o VirtualInfraType) Values() []string {
+	return VirtualInfraTypes.Values()
+}
+
+func (o *VirtualInfraType) FromString(s string) error {
+	if VirtualInfraTypes.Parse(s) == nil {
+		return newEnumParseError(o, s)
+	}
+	o.Value = s
+	return nil
+}
+
+func (o VirtualInfraType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.String())
+}
+
+func (o *VirtualInfraType) UnmarshalJSON(bytes []byte) error {
+	var s string
+	err := json.Unmarshal(bytes, &s)
+	if err != nil {
+		return err
+	}
+	return o.FromString(s)
+}
+
 var (
 	_ enum             = (*VnType)(nil)
 	_ json.Marshaler   = (*VnType)(nil)
@@ -1882,6 +2542,10 @@ func (o VnType) String() string {
 	return o.Value
 }
 
+func (o VnType) Values() []string {
+	return VnTypes.Values()
+}
+
 func (o *VnType) FromString(s string) error {
 	if VnTypes.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -1971,6 +2635,20 @@ var (
 		DesignLogicalDevicePanelPortIndexingTBLR,
 	)
 
+	_                          enum = new(DeviceOsImageChecksumType)
+	DeviceOsImageChecksumTypes      = oenum.New(
+		DeviceOsImageChecksumTypeMd5,
+		DeviceOsImageChecksumTypeSha512,
+	)
+
+	_                 enum = new(DeviceOsPlatform)
+	DeviceOsPlatforms      = oenum.New(
+		DeviceOsPlatformEos,
+		DeviceOsPlatformJunos,
+		DeviceOsPlatformNxos,
+		DeviceOsPlatformSonic,
+	)
+
 	_                  enum = new(DeviceProfileType)
 	DeviceProfileTypes      = oenum.New(
 		DeviceProfileTypeModular,
@@ -1990,6 +2668,13 @@ var (
 		EndpointPolicyStatusReady,
 	)
 
+	_             enum = new(EnsureAction)
+	EnsureActions      = oenum.New(
+		EnsureActionCreated,
+		EnsureActionNone,
+		EnsureActionUpdated,
+	)
+
 	_               enum = new(FFResourceType)
 	FFResourceTypes      = oenum.New(
 		FFResourceTypeAsn,
@@ -2107,6 +2792,15 @@ var (
 		InterfaceTypeUnicastVtep,
 	)
 
+	_               enum = new(InventoryIssue)
+	InventoryIssues      = oenum.New(
+		InventoryIssueAgentState,
+		InventoryIssueHostnameMismatch,
+		InventoryIssueMissing,
+		InventoryIssueOsVersionMismatch,
+		InventoryIssueUnexpected,
+	)
+
 	_                 enum = new(JunosEVPNIRBMode)
 	JunosEVPNIRBModes      = oenum.New(
 		JunosEVPNIRBModeAsymmetric,
@@ -2225,6 +2919,24 @@ var (
 		NodeRoleSuperspine,
 	)
 
+	_               enum = new(OnboardingStep)
+	OnboardingSteps      = oenum.New(
+		OnboardingStepCreateAgent,
+		OnboardingStepInstallAgent,
+		OnboardingStepAwaitConnection,
+		OnboardingStepAcknowledgeSystem,
+		OnboardingStepAssignSystem,
+		OnboardingStepSetDeployMode,
+	)
+
+	_                enum = new(OsUpgradeStatus)
+	OsUpgradeStatuss      = oenum.New(
+		OsUpgradeStatusFailed,
+		OsUpgradeStatusNotAttempted,
+		OsUpgradeStatusSkipped,
+		OsUpgradeStatusSuccess,
+	)
+
 	_                       enum = new(OverlayControlProtocol)
 	OverlayControlProtocols      = oenum.New(
 		OverlayControlProtocolEVPN,
@@ -2257,6 +2969,13 @@ var (
 		PolicyRuleActionPermitLog,
 	)
 
+	_                enum = new(PolicyRuleIssue)
+	PolicyRuleIssues      = oenum.New(
+		PolicyRuleIssueConflicting,
+		PolicyRuleIssueRedundant,
+		PolicyRuleIssueShadowed,
+	)
+
 	_                   enum = new(PolicyRuleProtocol)
 	PolicyRuleProtocols      = oenum.New(
 		PolicyRuleProtocolIcmp,
@@ -2277,6 +2996,13 @@ var (
 		PortRoleUnused,
 	)
 
+	_                  enum = new(PrefixFilterIssue)
+	PrefixFilterIssues      = oenum.New(
+		PrefixFilterIssueInvalid,
+		PrefixFilterIssueOverlapping,
+		PrefixFilterIssueUnreachable,
+	)
+
 	_                    enum = new(RedundancyGroupType)
 	RedundancyGroupTypes      = oenum.New(
 		RedundancyGroupTypeEsi,
@@ -2318,6 +3044,25 @@ var (
 		ResourcePoolTypeVni,
 	)
 
+	_            enum = new(RouteSource)
+	RouteSources      = oenum.New(
+		RouteSourceExternal,
+		RouteSourceL2EdgeSubnet,
+		RouteSourceL3EdgeServerLink,
+		RouteSourceLoopback,
+		RouteSourceSpineLeafLink,
+		RouteSourceSpineSuperspineLink,
+		RouteSourceStaticRoute,
+	)
+
+	_                 enum = new(RouteTargetIssue)
+	RouteTargetIssues      = oenum.New(
+		RouteTargetIssueAsymmetricImport,
+		RouteTargetIssueOrphanExport,
+		RouteTargetIssueOrphanImport,
+		RouteTargetIssueUnintendedLeak,
+	)
+
 	_                          enum = new(RoutingZoneConstraintMode)
 	RoutingZoneConstraintModes      = oenum.New(
 		RoutingZoneConstraintModeAllow,
@@ -2363,6 +3108,13 @@ var (
 		StorageSchemaPathXcvr,
 	)
 
+	_                   enum = new(SwitchingZoneIssue)
+	SwitchingZoneIssues      = oenum.New(
+		SwitchingZoneIssueReservedVlanConflict,
+		SwitchingZoneIssueVlanConflict,
+		SwitchingZoneIssueVniConflict,
+	)
+
 	_                               enum = new(SwitchingZoneMACVRFServiceType)
 	SwitchingZoneMACVRFServiceTypes      = oenum.New(
 		SwitchingZoneMACVRFServiceTypeVLANAware,
@@ -2412,6 +3164,12 @@ var (
 		TemplateTypeRailCollapsed,
 	)
 
+	_                 enum = new(VirtualInfraType)
+	VirtualInfraTypes      = oenum.New(
+		VirtualInfraTypeNsxt,
+		VirtualInfraTypeVcenter,
+	)
+
 	_       enum = new(VnType)
 	VnTypes      = oenum.New(
 		VnTypeExternal,
@@ -1,4 +1,4 @@
-// Copyright (c) Juniper Networks, Inc., 2024-2025.
+// Copyright (c) Juniper Networks, Inc., 2024-2026.
 // All rights reserved.
 // SPDX-License-Identifier: Apache-2.0
 
@@ -49,6 +49,10 @@ func (o {{ $key }}) String() string {
 	return o.Value
 }
 
+func (o {{ $key }}) Values() []string {
+	return {{ $value.Plural }}.Values()
+}
+
 func (o *{{ $key }}) FromString(s string) error {
 	if {{ $value.Plural }}.Parse(s) == nil {
 		return newEnumParseError(o, s)
@@ -16,6 +16,7 @@ require (
 	github.com/stretchr/testify v1.11.1
 	golang.org/x/tools v0.38.0
 	google.golang.org/protobuf v1.33.0
+	gopkg.in/yaml.v3 v3.0.1
 	mvdan.cc/gofumpt v0.9.2
 )
 
@@ -52,6 +53,5 @@ require (
 	golang.org/x/sync v0.17.0 // indirect
 	golang.org/x/sys v0.37.0 // indirect
 	golang.org/x/text v0.30.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 	k8s.io/klog/v2 v2.130.1 // indirect
 )
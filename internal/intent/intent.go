@@ -0,0 +1,164 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package intent describes how SDK types are represented in human-authored
+// intent documents (YAML, and the JSON Schema which describes that YAML). The
+// representation follows the exported Go fields of each type rather than the
+// API's wire format: enums are their string values, speed.Speed is a string
+// like "10G", and types which implement encoding.TextMarshaler are strings.
+package intent
+
+import (
+	"encoding"
+	"net"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/Juniper/apstra-go-sdk/speed"
+)
+
+// Kind classifies the representation of a Go type in an intent document.
+type Kind int
+
+const (
+	KindUnsupported Kind = iota
+	KindBool
+	KindInt
+	KindUint
+	KindFloat
+	KindString
+	KindEnum  // string constrained to the values returned by the type's Values() method
+	KindSpeed // string parsed by speed.Speed
+	KindText  // string handled by encoding.TextMarshaler and encoding.TextUnmarshaler
+	KindIPNet // *net.IPNet represented as a CIDR string
+	KindMAC   // net.HardwareAddr represented as a colon-delimited string
+	KindStruct
+	KindSlice
+	KindPointer
+)
+
+// Enum is implemented by pointers to the types in the enum package.
+type Enum interface {
+	String() string
+	FromString(string) error
+	Values() []string
+}
+
+var (
+	enumType            = reflect.TypeFor[Enum]()
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	ipNetPtrType        = reflect.TypeFor[*net.IPNet]()
+	macType             = reflect.TypeFor[net.HardwareAddr]()
+	speedType           = reflect.TypeFor[speed.Speed]()
+)
+
+// KindOf returns the Kind of t.
+func KindOf(t reflect.Type) Kind {
+	switch t {
+	case ipNetPtrType:
+		return KindIPNet
+	case macType:
+		return KindMAC
+	case speedType:
+		return KindSpeed
+	}
+
+	ptr := reflect.PointerTo(t)
+	if ptr.Implements(enumType) {
+		return KindEnum
+	}
+	if t.Implements(textMarshalerType) && ptr.Implements(textUnmarshalerType) {
+		return KindText
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return KindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return KindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return KindUint
+	case reflect.Float32, reflect.Float64:
+		return KindFloat
+	case reflect.String:
+		return KindString
+	case reflect.Struct:
+		return KindStruct
+	case reflect.Slice:
+		return KindSlice
+	case reflect.Pointer:
+		return KindPointer
+	}
+
+	return KindUnsupported
+}
+
+// EnumValues returns the permitted values of enum type t, which must be of
+// KindEnum.
+func EnumValues(t reflect.Type) []string {
+	return reflect.New(t).Interface().(Enum).Values()
+}
+
+// Field describes an exported struct field as it appears in an intent document.
+type Field struct {
+	Name  string // document key
+	Index int    // index for reflect.Value.Field
+	Type  reflect.Type
+}
+
+// Fields returns the exported fields of struct type t in declaration order.
+// Document keys are taken from a `yaml` struct tag when present, otherwise
+// they're the snake_case form of the Go field name. Fields tagged `yaml:"-"`
+// are skipped.
+func Fields(t reflect.Type) []Field {
+	var result []Field
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := SnakeCase(f.Name)
+		if tag, ok := f.Tag.Lookup("yaml"); ok {
+			tag, _, _ = strings.Cut(tag, ",")
+			switch tag {
+			case "-":
+				continue
+			case "":
+			default:
+				name = tag
+			}
+		}
+
+		result = append(result, Field{Name: name, Index: i, Type: f.Type})
+	}
+
+	return result
+}
+
+// snakeCaseReplacer handles mixed-case initialisms which would otherwise be
+// split in surprising places.
+var snakeCaseReplacer = strings.NewReplacer("IPv4", "Ipv4", "IPv6", "Ipv6")
+
+// SnakeCase converts a Go identifier like "DeviceProfileID" to "device_profile_id".
+func SnakeCase(s string) string {
+	r := []rune(snakeCaseReplacer.Replace(s))
+
+	var sb strings.Builder
+	for i, c := range r {
+		if unicode.IsUpper(c) && i > 0 {
+			prev := r[i-1]
+			nextIsLower := i+1 < len(r) && unicode.IsLower(r[i+1])
+			nextIsPlural := i+1 < len(r) && r[i+1] == 's' && (i+2 == len(r) || !unicode.IsLower(r[i+2])) // "IDs", "NICsPer"
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower && !nextIsPlural) {
+				sb.WriteRune('_')
+			}
+		}
+		sb.WriteRune(unicode.ToLower(c))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,93 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package intent_test
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/intent"
+	"github.com/Juniper/apstra-go-sdk/speed"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnakeCase(t *testing.T) {
+	testCases := map[string]string{
+		"Label":                     "label",
+		"FabricConnectivityDesign":  "fabric_connectivity_design",
+		"DeviceProfileID":           "device_profile_id",
+		"AccessSwitchNodeIDs":       "access_switch_node_ids",
+		"NICsPerServer":             "nics_per_server",
+		"ExportRTs":                 "export_rts",
+		"SVIIPs":                    "sviips",
+		"IPv4Subnet":                "ipv4_subnet",
+		"VirtualGatewayIPv6Enabled": "virtual_gateway_ipv6_enabled",
+		"L3MTU":                     "l3_mtu",
+		"LeafLeafL3LinkCount":       "leaf_leaf_l3_link_count",
+		"SpineASNScheme":            "spine_asn_scheme",
+		"COPPStrict":                "copp_strict",
+		"ID":                        "id",
+		"GPUServerCount":            "gpu_server_count",
+	}
+
+	for in, expected := range testCases {
+		t.Run(in, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, expected, intent.SnakeCase(in))
+		})
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	testCases := map[string]struct {
+		t        reflect.Type
+		expected intent.Kind
+	}{
+		"bool":           {t: reflect.TypeFor[bool](), expected: intent.KindBool},
+		"int":            {t: reflect.TypeFor[int](), expected: intent.KindInt},
+		"uint16":         {t: reflect.TypeFor[uint16](), expected: intent.KindUint},
+		"string":         {t: reflect.TypeFor[string](), expected: intent.KindString},
+		"enum":           {t: reflect.TypeFor[enum.PortRole](), expected: intent.KindEnum},
+		"speed":          {t: reflect.TypeFor[speed.Speed](), expected: intent.KindSpeed},
+		"port_ranges":    {t: reflect.TypeFor[datacenter.PortRanges](), expected: intent.KindText},
+		"dhcp_enabled":   {t: reflect.TypeFor[datacenter.DHCPServiceEnabled](), expected: intent.KindText},
+		"ip":             {t: reflect.TypeFor[net.IP](), expected: intent.KindText},
+		"time":           {t: reflect.TypeFor[time.Time](), expected: intent.KindText},
+		"ip_net_pointer": {t: reflect.TypeFor[*net.IPNet](), expected: intent.KindIPNet},
+		"mac":            {t: reflect.TypeFor[net.HardwareAddr](), expected: intent.KindMAC},
+		"struct":         {t: reflect.TypeFor[datacenter.PolicyRule](), expected: intent.KindStruct},
+		"slice":          {t: reflect.TypeFor[[]string](), expected: intent.KindSlice},
+		"pointer":        {t: reflect.TypeFor[*int](), expected: intent.KindPointer},
+		"map":            {t: reflect.TypeFor[map[string]string](), expected: intent.KindUnsupported},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tCase.expected, intent.KindOf(tCase.t))
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	type s struct {
+		Label       string
+		Renamed     int  `yaml:"other_name,omitempty"`
+		Skipped     bool `yaml:"-"`
+		unexported  string
+		DeviceModel string
+	}
+
+	result := intent.Fields(reflect.TypeFor[s]())
+	require.Equal(t, []intent.Field{
+		{Name: "label", Index: 0, Type: reflect.TypeFor[string]()},
+		{Name: "other_name", Index: 1, Type: reflect.TypeFor[int]()},
+		{Name: "device_model", Index: 4, Type: reflect.TypeFor[string]()},
+	}, result)
+}
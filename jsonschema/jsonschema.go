@@ -0,0 +1,164 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jsonschema generates JSON Schema (draft 2020-12) documents which
+// describe the YAML intent documents produced and consumed by the yaml
+// package. The schemas are intended for editor validation and CI linting of
+// intent authored outside the SDK.
+package jsonschema
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/Juniper/apstra-go-sdk/internal/intent"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+)
+
+const (
+	Draft = "https://json-schema.org/draft/2020-12/schema"
+
+	// SpeedPattern matches the strings accepted by speed.Speed
+	SpeedPattern = `^\s*[0-9]+\s*[MmGg]?\s*([Bb][Pp][Ss]|[Bb]/[Ss])?\s*$`
+
+	defsPrefix = "#/$defs/"
+)
+
+// Schema is the subset of JSON Schema used to describe intent documents.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// For returns the schema describing intent documents for type T.
+func For[T any]() (*Schema, error) {
+	return Generate(reflect.TypeFor[T]())
+}
+
+// Generate returns the schema describing intent documents for type t. Named
+// struct types are placed in the "$defs" section of the returned document and
+// referenced by name, so recursive types are supported.
+func Generate(t reflect.Type) (*Schema, error) {
+	g := generator{defs: make(map[string]*Schema)}
+
+	result, err := g.schema(t)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(g.defs) > 0 {
+		result.Defs = g.defs
+	}
+
+	// the root type itself is the most useful title
+	if result.Ref != "" {
+		result.Title = strings.TrimPrefix(result.Ref, defsPrefix)
+	}
+
+	result.Schema = Draft
+	return result, nil
+}
+
+type generator struct {
+	defs map[string]*Schema
+}
+
+func (g *generator) schema(t reflect.Type) (*Schema, error) {
+	switch intent.KindOf(t) {
+	case intent.KindBool:
+		return &Schema{Type: "boolean"}, nil
+	case intent.KindInt:
+		result := Schema{Type: "integer"}
+		if t.Bits() < 64 {
+			result.Minimum = pointer.To(-math.Pow(2, float64(t.Bits()-1)))
+			result.Maximum = pointer.To(math.Pow(2, float64(t.Bits()-1)) - 1)
+		}
+		return &result, nil
+	case intent.KindUint:
+		result := Schema{Type: "integer", Minimum: pointer.To(0.0)}
+		if t.Bits() < 64 {
+			result.Maximum = pointer.To(math.Pow(2, float64(t.Bits())) - 1)
+		}
+		return &result, nil
+	case intent.KindFloat:
+		return &Schema{Type: "number"}, nil
+	case intent.KindString:
+		return &Schema{Type: "string"}, nil
+	case intent.KindSpeed:
+		return &Schema{Type: "string", Pattern: SpeedPattern}, nil
+	case intent.KindEnum:
+		return &Schema{Type: "string", Enum: intent.EnumValues(t)}, nil
+	case intent.KindText:
+		return &Schema{Type: "string"}, nil
+	case intent.KindIPNet:
+		return &Schema{Type: "string", Format: "cidr"}, nil
+	case intent.KindMAC:
+		return &Schema{Type: "string", Format: "mac"}, nil
+	case intent.KindSlice:
+		items, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case intent.KindPointer:
+		return g.schema(t.Elem())
+	case intent.KindStruct:
+		if t.Name() == "" {
+			return g.structSchema(t) // anonymous structs are described inline
+		}
+
+		name := defName(t)
+		if _, ok := g.defs[name]; !ok {
+			g.defs[name] = nil // placeholder permits recursion
+			def, err := g.structSchema(t)
+			if err != nil {
+				return nil, err
+			}
+			def.Title = name
+			g.defs[name] = def
+		}
+
+		return &Schema{Ref: defsPrefix + name}, nil
+	}
+
+	return nil, fmt.Errorf("cannot generate schema for unsupported type %s", t)
+}
+
+func (g *generator) structSchema(t reflect.Type) (*Schema, error) {
+	result := Schema{
+		Type:                 "object",
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: pointer.To(false),
+	}
+
+	for _, field := range intent.Fields(t) {
+		s, err := g.schema(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("generating schema for %s field %q: %w", t, field.Name, err)
+		}
+		result.Properties[field.Name] = s
+	}
+
+	return &result, nil
+}
+
+// defName returns a "$defs" key like "design.LogicalDevice" for named type t.
+func defName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	return pkg[strings.LastIndex(pkg, "/")+1:] + "." + t.Name()
+}
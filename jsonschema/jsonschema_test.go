@@ -0,0 +1,221 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/design"
+	"github.com/Juniper/apstra-go-sdk/device"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/jsonschema"
+	"github.com/Juniper/apstra-go-sdk/policy"
+	"github.com/Juniper/apstra-go-sdk/yaml"
+	"github.com/stretchr/testify/require"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+var schemaTypes = []reflect.Type{
+	reflect.TypeFor[datacenter.Policy](),
+	reflect.TypeFor[datacenter.VirtualNetwork](),
+	reflect.TypeFor[design.ConfigTemplate](),
+	reflect.TypeFor[design.Configlet](),
+	reflect.TypeFor[design.InterfaceMap](),
+	reflect.TypeFor[design.LogicalDevice](),
+	reflect.TypeFor[design.RackType](),
+	reflect.TypeFor[design.Tag](),
+	reflect.TypeFor[design.TemplateL3Collapsed](),
+	reflect.TypeFor[design.TemplatePodBased](),
+	reflect.TypeFor[design.TemplateRackBased](),
+	reflect.TypeFor[design.TemplateRailCollapsed](),
+	reflect.TypeFor[device.Profile](),
+	reflect.TypeFor[policy.AntiAffinity](),
+}
+
+func TestGenerate(t *testing.T) {
+	for _, typ := range schemaTypes {
+		t.Run(typ.String(), func(t *testing.T) {
+			t.Parallel()
+
+			s, err := jsonschema.Generate(typ)
+			require.NoError(t, err)
+			require.Equal(t, jsonschema.Draft, s.Schema)
+			require.Equal(t, typ.String(), s.Title)
+			require.Contains(t, s.Defs, typ.String())
+
+			// every reference must resolve
+			b, err := json.Marshal(s)
+			require.NoError(t, err)
+			for _, m := range regexp.MustCompile(`"\$ref":"#/\$defs/([^"]+)"`).FindAllStringSubmatch(string(b), -1) {
+				require.Contains(t, s.Defs, m[1])
+				require.NotNil(t, s.Defs[m[1]])
+			}
+		})
+	}
+}
+
+func TestFor(t *testing.T) {
+	s, err := jsonschema.For[design.LogicalDevice]()
+	require.NoError(t, err)
+
+	ld := s.Defs["design.LogicalDevice"]
+	require.Equal(t, "object", ld.Type)
+	require.NotNil(t, ld.AdditionalProperties)
+	require.False(t, *ld.AdditionalProperties)
+	require.Equal(t, "string", ld.Properties["label"].Type)
+	require.Equal(t, "array", ld.Properties["panels"].Type)
+	require.Equal(t, "#/$defs/design.LogicalDevicePanel", ld.Properties["panels"].Items.Ref)
+
+	panel := s.Defs["design.LogicalDevicePanel"]
+	require.Equal(t, enum.DesignLogicalDevicePanelPortIndexings.Values(), panel.Properties["port_indexing"].Enum)
+
+	portGroup := s.Defs["design.LogicalDevicePanelPortGroup"]
+	require.Equal(t, jsonschema.SpeedPattern, portGroup.Properties["speed"].Pattern)
+	require.Equal(t, enum.PortRoles.Values(), portGroup.Properties["roles"].Items.Enum)
+
+	s, err = jsonschema.For[datacenter.PortRange]()
+	require.NoError(t, err)
+	require.Equal(t, "string", s.Type) // encoding.TextMarshaler
+
+	s, err = jsonschema.For[map[string]string]()
+	require.Error(t, err)
+}
+
+func TestSpeedPattern(t *testing.T) {
+	re := regexp.MustCompile(jsonschema.SpeedPattern)
+	for _, s := range []string{"10G", "10g", "100Gbps", "100 Gb/s", "1000M", "1000000000"} {
+		require.True(t, re.MatchString(s), s)
+	}
+	for _, s := range []string{"", "fast", "10T", "1.5G"} {
+		require.False(t, re.MatchString(s), s)
+	}
+}
+
+// TestDocumentsValidate ensures that documents produced by the yaml package
+// are accepted by the generated schemas.
+func TestDocumentsValidate(t *testing.T) {
+	testCases := map[string]any{
+		"logical_device": design.LogicalDevice{
+			Label: "ld",
+			Panels: []design.LogicalDevicePanel{
+				{
+					PanelLayout:  design.LogicalDevicePanelLayout{RowCount: 1, ColumnCount: 2},
+					PortGroups:   []design.LogicalDevicePanelPortGroup{{Count: 2, Speed: "100G", Roles: design.LogicalDevicePortRoles{enum.PortRoleLeaf}}},
+					PortIndexing: enum.DesignLogicalDevicePanelPortIndexingTBLR,
+				},
+			},
+		},
+		"policy": datacenter.Policy{
+			Label: "policy",
+			Rules: []datacenter.PolicyRule{
+				{
+					Label:    "rule",
+					Protocol: enum.PolicyRuleProtocolTcp,
+					Action:   enum.PolicyRuleActionPermit,
+					DstPort:  datacenter.PortRanges{{First: 80, Last: 90}},
+				},
+			},
+		},
+		"anti_affinity": policy.AntiAffinity{MaxLinksPerPort: 1, Mode: enum.AntiAffinityModeLoose},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := jsonschema.Generate(reflect.TypeOf(tCase))
+			require.NoError(t, err)
+
+			b, err := yaml.Marshal(tCase)
+			require.NoError(t, err)
+
+			var doc any
+			require.NoError(t, yamlv3.Unmarshal(b, &doc))
+			require.NoError(t, validate(s, s, doc, ""), string(b))
+		})
+	}
+
+	t.Run("rejects_unknown_key", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := jsonschema.For[design.Tag]()
+		require.NoError(t, err)
+		require.Error(t, validate(s, s, map[string]any{"label": "a", "colour": "red"}, ""))
+	})
+
+	t.Run("rejects_bad_enum", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := jsonschema.For[policy.AntiAffinity]()
+		require.NoError(t, err)
+		require.Error(t, validate(s, s, map[string]any{"mode": "sometimes"}, ""))
+	})
+}
+
+// validate is a minimal validator supporting the keywords used by this package.
+func validate(root, s *jsonschema.Schema, doc any, path string) error {
+	if s.Ref != "" {
+		return validate(root, root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")], doc, path)
+	}
+
+	switch s.Type {
+	case "object":
+		m, ok := doc.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", path, doc)
+		}
+		for k, v := range m {
+			prop, ok := s.Properties[k]
+			if !ok {
+				return fmt.Errorf("%s: unexpected property %q", path, k)
+			}
+			if err := validate(root, prop, v, path+"."+k); err != nil {
+				return err
+			}
+		}
+	case "array":
+		a, ok := doc.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", path, doc)
+		}
+		for i, v := range a {
+			if err := validate(root, s.Items, v, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case "string":
+		str, ok := doc.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected string, got %T", path, doc)
+		}
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+			return fmt.Errorf("%s: %q not in %v", path, str, s.Enum)
+		}
+		if s.Pattern != "" && !regexp.MustCompile(s.Pattern).MatchString(str) {
+			return fmt.Errorf("%s: %q does not match %s", path, str, s.Pattern)
+		}
+	case "integer":
+		i, ok := doc.(int)
+		if !ok {
+			return fmt.Errorf("%s: expected integer, got %T", path, doc)
+		}
+		if s.Minimum != nil && float64(i) < *s.Minimum || s.Maximum != nil && float64(i) > *s.Maximum {
+			return fmt.Errorf("%s: %d out of range", path, i)
+		}
+	case "boolean":
+		if _, ok := doc.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", path, doc)
+		}
+	}
+
+	return nil
+}
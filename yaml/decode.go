@@ -0,0 +1,238 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package yaml
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/Juniper/apstra-go-sdk/internal/intent"
+	"github.com/Juniper/apstra-go-sdk/internal/parse"
+	"github.com/Juniper/apstra-go-sdk/speed"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const mergeKey = "<<"
+
+type decoder struct {
+	path []string
+}
+
+func (d *decoder) errorf(node *yamlv3.Node, format string, a ...any) error {
+	return &Error{
+		Line:   node.Line,
+		Column: node.Column,
+		Path:   strings.TrimPrefix(strings.Join(d.path, ""), "."),
+		Err:    fmt.Errorf(format, a...),
+	}
+}
+
+func (d *decoder) push(s string) { d.path = append(d.path, s) }
+func (d *decoder) pop()          { d.path = d.path[:len(d.path)-1] }
+
+// decode populates v, which must be settable, from node.
+func (d *decoder) decode(node *yamlv3.Node, v reflect.Value) error {
+	if node.Kind == yamlv3.AliasNode {
+		node = node.Alias
+	}
+
+	if node.Kind == yamlv3.ScalarNode && node.ShortTag() == "!!null" {
+		v.SetZero()
+		return nil
+	}
+
+	kind := intent.KindOf(v.Type())
+	switch kind {
+	case intent.KindStruct:
+		return d.decodeStruct(node, v)
+	case intent.KindSlice:
+		return d.decodeSlice(node, v)
+	case intent.KindPointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return d.decode(node, v.Elem())
+	case intent.KindUnsupported:
+		return d.errorf(node, "cannot unmarshal into unsupported type %s", v.Type())
+	}
+
+	// everything else is a scalar
+	if node.Kind != yamlv3.ScalarNode {
+		return d.errorf(node, "expected a scalar value for %s", v.Type())
+	}
+
+	switch kind {
+	case intent.KindBool:
+		b, err := strconv.ParseBool(node.Value)
+		if err != nil || node.ShortTag() != "!!bool" {
+			return d.errorf(node, "cannot parse %q as a boolean", node.Value)
+		}
+		v.SetBool(b)
+	case intent.KindInt:
+		i, err := strconv.ParseInt(node.Value, 0, 64)
+		if err != nil || v.OverflowInt(i) {
+			return d.errorf(node, "cannot parse %q as %s", node.Value, v.Type())
+		}
+		v.SetInt(i)
+	case intent.KindUint:
+		u, err := strconv.ParseUint(node.Value, 0, 64)
+		if err != nil || v.OverflowUint(u) {
+			return d.errorf(node, "cannot parse %q as %s", node.Value, v.Type())
+		}
+		v.SetUint(u)
+	case intent.KindFloat:
+		f, err := strconv.ParseFloat(node.Value, 64)
+		if err != nil || v.OverflowFloat(f) {
+			return d.errorf(node, "cannot parse %q as %s", node.Value, v.Type())
+		}
+		v.SetFloat(f)
+	case intent.KindString:
+		v.SetString(node.Value)
+	case intent.KindSpeed:
+		s := speed.Speed(node.Value)
+		if s != "" && s.BitsPerSecond() == 0 {
+			return d.errorf(node, "cannot parse %q as a speed", node.Value)
+		}
+		v.Set(reflect.ValueOf(s))
+	case intent.KindEnum:
+		err := v.Addr().Interface().(intent.Enum).FromString(node.Value)
+		if err != nil {
+			return d.errorf(node, "%w - expected one of %s", err, strings.Join(intent.EnumValues(v.Type()), ", "))
+		}
+	case intent.KindText:
+		err := v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(node.Value))
+		if err != nil {
+			return d.errorf(node, "%w", err)
+		}
+	case intent.KindIPNet:
+		ipNet, err := parse.IPNetFromString(node.Value)
+		if err != nil {
+			return d.errorf(node, "%w", err)
+		}
+		v.Set(reflect.ValueOf(ipNet))
+	case intent.KindMAC:
+		mac, err := parse.MACFromString(node.Value)
+		if err != nil {
+			return d.errorf(node, "%w", err)
+		}
+		v.Set(reflect.ValueOf(mac))
+	}
+
+	return nil
+}
+
+func (d *decoder) decodeSlice(node *yamlv3.Node, v reflect.Value) error {
+	if node.Kind != yamlv3.SequenceNode {
+		return d.errorf(node, "expected a sequence for %s", v.Type())
+	}
+
+	result := reflect.MakeSlice(v.Type(), len(node.Content), len(node.Content))
+	for i, item := range node.Content {
+		d.push("[" + strconv.Itoa(i) + "]")
+		err := d.decode(item, result.Index(i))
+		d.pop()
+		if err != nil {
+			return err
+		}
+	}
+
+	v.Set(result)
+	return nil
+}
+
+func (d *decoder) decodeStruct(node *yamlv3.Node, v reflect.Value) error {
+	pairs, err := d.mappingPairs(node, v.Type())
+	if err != nil {
+		return err
+	}
+
+	fields := make(map[string]intent.Field)
+	for _, field := range intent.Fields(v.Type()) {
+		fields[field.Name] = field
+	}
+
+	seen := make(map[string]bool, len(pairs))
+	for _, pair := range pairs {
+		key, value := pair[0], pair[1]
+		if seen[key.Value] {
+			return d.errorf(key, "duplicate key %q", key.Value)
+		}
+		seen[key.Value] = true
+
+		field, ok := fields[key.Value]
+		if !ok {
+			return d.errorf(key, "unknown key %q for %s", key.Value, v.Type())
+		}
+
+		d.push("." + field.Name)
+		err = d.decode(value, v.Field(field.Index))
+		d.pop()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mappingPairs returns the key/value pairs found in mapping node, expanding
+// "<<" merge keys. Explicit keys take precedence over merged keys.
+func (d *decoder) mappingPairs(node *yamlv3.Node, t reflect.Type) ([][2]*yamlv3.Node, error) {
+	if node.Kind != yamlv3.MappingNode {
+		return nil, d.errorf(node, "expected a mapping for %s", t)
+	}
+
+	var explicit, merged [][2]*yamlv3.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Kind != yamlv3.ScalarNode {
+			return nil, d.errorf(key, "mapping keys must be scalars")
+		}
+
+		if key.Value != mergeKey || key.ShortTag() != "!!merge" {
+			explicit = append(explicit, [2]*yamlv3.Node{key, value})
+			continue
+		}
+
+		if value.Kind == yamlv3.AliasNode {
+			value = value.Alias
+		}
+
+		sources := []*yamlv3.Node{value}
+		if value.Kind == yamlv3.SequenceNode {
+			sources = value.Content
+		}
+
+		for _, source := range sources {
+			if source.Kind == yamlv3.AliasNode {
+				source = source.Alias
+			}
+			pairs, err := d.mappingPairs(source, t)
+			if err != nil {
+				return nil, err
+			}
+			merged = append(merged, pairs...)
+		}
+	}
+
+	// drop merged pairs which are overridden explicitly or by an earlier merge
+	seen := make(map[string]bool, len(explicit)+len(merged))
+	for _, pair := range explicit {
+		seen[pair[0].Value] = true
+	}
+
+	result := explicit
+	for _, pair := range merged {
+		if !seen[pair[0].Value] {
+			seen[pair[0].Value] = true
+			result = append(result, pair)
+		}
+	}
+
+	return result, nil
+}
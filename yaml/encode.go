@@ -0,0 +1,102 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package yaml
+
+import (
+	"encoding"
+	"fmt"
+	"net"
+	"reflect"
+	"strconv"
+
+	"github.com/Juniper/apstra-go-sdk/internal/intent"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+func scalarNode(tag, value string) *yamlv3.Node {
+	return &yamlv3.Node{Kind: yamlv3.ScalarNode, Tag: tag, Value: value}
+}
+
+func stringNode(s string) *yamlv3.Node {
+	var result yamlv3.Node
+	result.SetString(s) // uses literal style for multi-line strings
+	return &result
+}
+
+func nullNode() *yamlv3.Node {
+	return scalarNode("!!null", "null")
+}
+
+func encode(v reflect.Value) (*yamlv3.Node, error) {
+	if !v.IsValid() {
+		return nullNode(), nil
+	}
+
+	switch intent.KindOf(v.Type()) {
+	case intent.KindBool:
+		return scalarNode("!!bool", strconv.FormatBool(v.Bool())), nil
+	case intent.KindInt:
+		return scalarNode("!!int", strconv.FormatInt(v.Int(), 10)), nil
+	case intent.KindUint:
+		return scalarNode("!!int", strconv.FormatUint(v.Uint(), 10)), nil
+	case intent.KindFloat:
+		return scalarNode("!!float", strconv.FormatFloat(v.Float(), 'g', -1, 64)), nil
+	case intent.KindString, intent.KindSpeed:
+		return stringNode(v.String()), nil
+	case intent.KindEnum:
+		return stringNode(v.Interface().(fmt.Stringer).String()), nil
+	case intent.KindText:
+		b, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, fmt.Errorf("marshaling %s: %w", v.Type(), err)
+		}
+		return stringNode(string(b)), nil
+	case intent.KindIPNet:
+		if v.IsNil() {
+			return nullNode(), nil
+		}
+		return stringNode(v.Interface().(*net.IPNet).String()), nil
+	case intent.KindMAC:
+		return stringNode(v.Interface().(net.HardwareAddr).String()), nil
+	case intent.KindStruct:
+		return encodeStruct(v)
+	case intent.KindSlice:
+		result := yamlv3.Node{Kind: yamlv3.SequenceNode, Tag: "!!seq"}
+		for i := range v.Len() {
+			item, err := encode(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			result.Content = append(result.Content, item)
+		}
+		return &result, nil
+	case intent.KindPointer:
+		if v.IsNil() {
+			return nullNode(), nil
+		}
+		return encode(v.Elem())
+	}
+
+	return nil, fmt.Errorf("cannot marshal unsupported type %s", v.Type())
+}
+
+func encodeStruct(v reflect.Value) (*yamlv3.Node, error) {
+	result := yamlv3.Node{Kind: yamlv3.MappingNode, Tag: "!!map"}
+	for _, field := range intent.Fields(v.Type()) {
+		fv := v.Field(field.Index)
+		if fv.IsZero() {
+			continue // zero values are implied by omission
+		}
+
+		value, err := encode(fv)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling %s field %q: %w", v.Type(), field.Name, err)
+		}
+
+		result.Content = append(result.Content, stringNode(field.Name), value)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package yaml encodes and decodes SDK types (design, datacenter, device and
+// policy objects) as YAML intent documents.
+//
+// Documents follow the exported Go fields of each type rather than the API
+// wire format. Keys are the snake_case form of the Go field names, enum
+// values are their strings, speed.Speed values are strings like "10G", and
+// types which implement encoding.TextMarshaler (e.g. datacenter.PortRanges)
+// are strings. Zero-valued fields are omitted. Object metadata (IDs and
+// timestamps) is not part of the document. The jsonschema package generates
+// schemas which describe these documents.
+package yaml
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Marshal returns the YAML intent document representing v.
+func Marshal(v any) ([]byte, error) {
+	node, err := encode(reflect.ValueOf(v))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := yamlv3.NewEncoder(&buf)
+	enc.SetIndent(2)
+
+	err = enc.Encode(node)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %T: %w", v, err)
+	}
+
+	err = enc.Close()
+	if err != nil {
+		return nil, fmt.Errorf("encoding %T: %w", v, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Unmarshal decodes the YAML intent document in data into v, which must be a
+// non-nil pointer. Decoding is strict: unknown keys, duplicate keys and values
+// which are not valid for the target type produce an *Error.
+func Unmarshal(data []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("cannot unmarshal into non-pointer or nil %T", v)
+	}
+
+	var doc yamlv3.Node
+	err := yamlv3.Unmarshal(data, &doc)
+	if err != nil {
+		return fmt.Errorf("parsing YAML: %w", err)
+	}
+
+	if doc.Kind == 0 {
+		return nil // empty document
+	}
+
+	return new(decoder).decode(doc.Content[0], rv.Elem())
+}
+
+// Error describes a problem found at a particular location in a YAML document.
+type Error struct {
+	Line   int
+	Column int
+	Path   string // e.g. "panels[0].port_groups[1].speed"; empty at the document root
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("line %d column %d: %s", e.Line, e.Column, e.Err)
+	}
+	return fmt.Sprintf("line %d column %d: %s: %s", e.Line, e.Column, e.Path, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
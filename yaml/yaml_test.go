@@ -0,0 +1,269 @@
+// Copyright (c) Juniper Networks, Inc., 2026-2026.
+// All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package yaml_test
+
+import (
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/Juniper/apstra-go-sdk/datacenter"
+	"github.com/Juniper/apstra-go-sdk/design"
+	"github.com/Juniper/apstra-go-sdk/enum"
+	"github.com/Juniper/apstra-go-sdk/internal/pointer"
+	"github.com/Juniper/apstra-go-sdk/policy"
+	"github.com/Juniper/apstra-go-sdk/speed"
+	"github.com/Juniper/apstra-go-sdk/yaml"
+	"github.com/stretchr/testify/require"
+)
+
+var testLogicalDevice = design.LogicalDevice{
+	Label: "AOS-48x10+6x40-1",
+	Panels: []design.LogicalDevicePanel{
+		{
+			PanelLayout: design.LogicalDevicePanelLayout{RowCount: 2, ColumnCount: 24},
+			PortGroups: []design.LogicalDevicePanelPortGroup{
+				{Count: 48, Speed: "10G", Roles: design.LogicalDevicePortRoles{enum.PortRoleAccess, enum.PortRoleGeneric}},
+			},
+			PortIndexing: enum.DesignLogicalDevicePanelPortIndexingTBLR,
+		},
+		{
+			PanelLayout: design.LogicalDevicePanelLayout{RowCount: 2, ColumnCount: 3},
+			PortGroups: []design.LogicalDevicePanelPortGroup{
+				{Count: 6, Speed: "40G", Roles: design.LogicalDevicePortRoles{enum.PortRoleSpine, enum.PortRoleLeaf}},
+			},
+			PortIndexing: enum.DesignLogicalDevicePanelPortIndexingLRTB,
+		},
+	},
+}
+
+var testRackType = design.RackType{
+	Label:                    "rack",
+	Description:              "a rack\nwith a multi-line description",
+	FabricConnectivityDesign: enum.FabricConnectivityDesignL3Clos,
+	LeafSwitches: []design.RackTypeLeafSwitch{
+		{
+			Label:              "leaf",
+			LinkPerSpineCount:  pointer.To(2),
+			LinkPerSpineSpeed:  pointer.To(speed.Speed("40G")),
+			LogicalDevice:      testLogicalDevice,
+			RedundancyProtocol: enum.LeafRedundancyProtocolESI,
+			Tags:               []design.Tag{{Label: "a", Description: "tag a"}},
+		},
+	},
+	GenericSystems: []design.RackTypeGenericSystem{
+		{
+			Count:           4,
+			Label:           "server",
+			LogicalDevice:   testLogicalDevice,
+			Loopback:        &enum.FeatureSwitchDisabled,
+			ManagementLevel: enum.SystemManagementLevelUnmanaged,
+			Links: []design.RackTypeLink{
+				{
+					Label:              "link",
+					TargetSwitchLabel:  "leaf",
+					LinkPerSwitchCount: 1,
+					Speed:              "10G",
+					AttachmentType:     enum.LinkAttachmentTypeDual,
+					LAGMode:            enum.LAGModeActiveLACP,
+				},
+			},
+		},
+	},
+}
+
+var testTemplate = design.TemplateRackBased{
+	Label:               "template",
+	Racks:               []design.RackTypeWithCount{{Count: 2, RackType: testRackType}},
+	ASNAllocationPolicy: &policy.ASNAllocation{SpineASNScheme: enum.ASNAllocationSchemeDistinct},
+	DHCPServiceIntent:   policy.DHCPServiceIntent{Active: true},
+	Spine: design.Spine{
+		Count:         2,
+		LogicalDevice: testLogicalDevice,
+	},
+	VirtualNetworkPolicy: &policy.VirtualNetwork{OverlayControlProtocol: enum.OverlayControlProtocolEVPN},
+}
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	t.Helper()
+	ip, ipNet, err := net.ParseCIDR(s)
+	require.NoError(t, err)
+	ipNet.IP = ip
+	return ipNet
+}
+
+func TestRoundTrip(t *testing.T) {
+	mac, err := net.ParseMAC("00:1c:73:00:00:01")
+	require.NoError(t, err)
+
+	testCases := map[string]any{
+		"logical_device": &testLogicalDevice,
+		"rack_type":      &testRackType,
+		"template":       &testTemplate,
+		"tag":            &design.Tag{Label: "a", Description: "b"},
+		"policy": &datacenter.Policy{
+			Enabled: true,
+			Label:   "policy",
+			Rules: []datacenter.PolicyRule{
+				{
+					Label:    "rule",
+					Protocol: enum.PolicyRuleProtocolTcp,
+					Action:   enum.PolicyRuleActionDeny,
+					DstPort:  datacenter.PortRanges{{First: 80, Last: 80}, {First: 8000, Last: 8080}},
+				},
+			},
+			Tags: []string{"x", "y"},
+		},
+		"virtual_network": &datacenter.VirtualNetwork{
+			Bindings:           []datacenter.VNBinding{{SystemID: "leaf1", VLAN: pointer.To(uint16(10))}},
+			DHCPService:        true,
+			IPv4Enabled:        true,
+			IPv4Subnet:         mustParseCIDR(t, "10.0.0.0/24"),
+			IPv6Subnet:         mustParseCIDR(t, "2001:db8::/64"),
+			Label:              "vn",
+			L3MTU:              pointer.To(9000),
+			RTPolicy:           &datacenter.RTPolicy{ImportRTs: []string{"100:100"}},
+			SVIIPs:             []datacenter.SVIAddressing{{SystemID: "leaf1", IPv4Addr: mustParseCIDR(t, "10.0.0.2/24"), IPv4Mode: enum.IPv4SVIModeEnabled}},
+			Type:               enum.VnTypeVxlan,
+			VirtualGatewayIPv4: net.ParseIP("10.0.0.1"),
+			VNI:                pointer.To(uint32(10010)),
+			VirtualMAC:         mac,
+		},
+	}
+
+	for tName, tCase := range testCases {
+		t.Run(tName, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := yaml.Marshal(tCase)
+			require.NoError(t, err)
+
+			result := reflect.New(reflect.TypeOf(tCase).Elem())
+			err = yaml.Unmarshal(b, result.Interface())
+			require.NoError(t, err, string(b))
+			require.Equal(t, tCase, result.Interface(), string(b))
+
+			// a second trip should produce identical YAML
+			b2, err := yaml.Marshal(result.Interface())
+			require.NoError(t, err)
+			require.Equal(t, string(b), string(b2))
+		})
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	b, err := yaml.Marshal(design.LogicalDevice{
+		Label: "ld",
+		Panels: []design.LogicalDevicePanel{
+			{
+				PanelLayout:  design.LogicalDevicePanelLayout{RowCount: 1, ColumnCount: 2},
+				PortGroups:   []design.LogicalDevicePanelPortGroup{{Count: 2, Speed: "100G", Roles: design.LogicalDevicePortRoles{enum.PortRoleLeaf}}},
+				PortIndexing: enum.DesignLogicalDevicePanelPortIndexingTBLR,
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, `label: ld
+panels:
+  - panel_layout:
+      row_count: 1
+      column_count: 2
+    port_groups:
+      - count: 2
+        speed: 100G
+        roles:
+          - leaf
+    port_indexing: T-B, L-R
+`, string(b))
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Run("anchors_and_merge_keys", func(t *testing.T) {
+		t.Parallel()
+
+		var result []design.LogicalDevicePanelPortGroup
+		err := yaml.Unmarshal([]byte(`
+- &base
+  count: 2
+  speed: 10g
+  roles: [leaf]
+- <<: *base
+  speed: 25 Gbps
+`), &result)
+		require.NoError(t, err)
+		require.Equal(t, []design.LogicalDevicePanelPortGroup{
+			{Count: 2, Speed: "10g", Roles: design.LogicalDevicePortRoles{enum.PortRoleLeaf}},
+			{Count: 2, Speed: "25 Gbps", Roles: design.LogicalDevicePortRoles{enum.PortRoleLeaf}},
+		}, result)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		t.Parallel()
+
+		result := design.RackTypeLeafSwitch{LinkPerSpineCount: pointer.To(1)}
+		err := yaml.Unmarshal([]byte("link_per_spine_count: null\n"), &result)
+		require.NoError(t, err)
+		require.Nil(t, result.LinkPerSpineCount)
+	})
+
+	t.Run("not_a_pointer", func(t *testing.T) {
+		t.Parallel()
+		require.Error(t, yaml.Unmarshal([]byte("label: x"), design.Tag{}))
+	})
+
+	type errTestCase struct {
+		doc     string
+		expLine int
+		expPath string
+	}
+
+	errTestCases := map[string]errTestCase{
+		"unknown_key": {
+			doc:     "label: ld\npanels:\n  - panel_layout:\n      rows: 1\n",
+			expLine: 4,
+			expPath: "panels[0].panel_layout",
+		},
+		"duplicate_key": {
+			doc:     "label: a\nlabel: b\n",
+			expLine: 2,
+		},
+		"bad_enum": {
+			doc:     "label: ld\npanels:\n  - port_indexing: sideways\n",
+			expLine: 3,
+			expPath: "panels[0].port_indexing",
+		},
+		"bad_speed": {
+			doc:     "panels:\n  - port_groups:\n      - speed: fast\n",
+			expLine: 3,
+			expPath: "panels[0].port_groups[0].speed",
+		},
+		"bad_int": {
+			doc:     "panels:\n  - port_groups:\n      - count: two\n",
+			expLine: 3,
+			expPath: "panels[0].port_groups[0].count",
+		},
+		"wrong_shape": {
+			doc:     "panels:\n  label: x\n",
+			expLine: 2,
+			expPath: "panels",
+		},
+	}
+
+	for tName, tCase := range errTestCases {
+		t.Run(tName, func(t *testing.T) {
+			t.Parallel()
+
+			var result design.LogicalDevice
+			err := yaml.Unmarshal([]byte(tCase.doc), &result)
+			require.Error(t, err)
+
+			var yamlErr *yaml.Error
+			require.True(t, errors.As(err, &yamlErr), err.Error())
+			require.Equal(t, tCase.expLine, yamlErr.Line, err.Error())
+			require.Equal(t, tCase.expPath, yamlErr.Path, err.Error())
+		})
+	}
+}